@@ -6,6 +6,7 @@ function parseArgs() {
         filePath?: string;
         reportEndpoint?: string;
         subgraphName?: string;
+        branch?: string;
         headers?: Record<string, string>;
         silent?: boolean;
     } = {};
@@ -17,6 +18,8 @@ function parseArgs() {
             options.reportEndpoint = args[++i];
         } else if (arg === '--subgraph-name' && i + 1 < args.length) {
             options.subgraphName = args[++i];
+        } else if (arg === '--branch' && i + 1 < args.length) {
+            options.branch = args[++i];
         } else if (arg === '--header' && i + 1 < args.length) {
             const headerPair = args[++i];
             const [key, value] = headerPair.split('=', 2);
@@ -46,6 +49,7 @@ Usage: bun run index.ts <schema-file> [options]
 Options:
   --report-endpoint <url>     Send results to this HTTP endpoint
   --subgraph-name <name>      Name of the subgraph for reporting
+  --branch <name>             Branch the schema was built from (used as quality gate baseline)
   --header <key=value>        Add custom header for reporting (can be used multiple times)
   --silent                    Suppress console output
   --help, -h                  Show this help message
@@ -86,7 +90,8 @@ async function main() {
         validateOptions.subgraphName = options.subgraphName;
         validateOptions.metadata = {
             filePath: options.filePath,
-            timestamp: new Date().toISOString()
+            timestamp: new Date().toISOString(),
+            ...(options.branch ? {branch: options.branch} : {})
         };
     }
 
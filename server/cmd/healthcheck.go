@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// healthCheckTimeout stays below the 3s timeout of the Docker HEALTHCHECK
+const healthCheckTimeout = 2 * time.Second
+
+// runHealthCheck probes the readiness endpoint of a running server and returns an error
+// unless it answers 200. It does not open a database connection itself.
+func runHealthCheck(url string, out io.Writer) error {
+	client := &http.Client{Timeout: healthCheckTimeout}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("health check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	fmt.Fprintf(out, "%s %s\n", resp.Status, body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server is not ready: %s", resp.Status)
+	}
+	return nil
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"schema-score-server/internal/domain"
+	"schema-score-server/internal/web"
+	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -14,64 +20,229 @@ import (
 	_ "github.com/lib/pq"
 
 	httpHandlers "schema-score-server/internal/adapters/http"
-	"schema-score-server/internal/adapters/postgres"
+	"schema-score-server/internal/adapters/sqlite"
+	"schema-score-server/internal/adapters/webhook"
 )
 
+// workerGracePeriod is how long shutdown waits for background workers beyond their own deadline
+const workerGracePeriod = 5 * time.Second
+
 func main() {
+	dev := flag.Bool("dev", false, "Reload templates and static files from internal/web on every request")
+	storageMode := flag.String("storage", storageDatabase, "Where to keep the data: database (see DB_DRIVER and DATABASE_URL) or memory (lost on restart)")
+	healthCheck := flag.Bool("health-check", false, "Probe the readiness endpoint of the running server and exit 0 if it is ready")
+	flag.Parse()
+
 	// Load environment variables
-	if err := godotenv.Load(); err != nil {
+	if err := godotenv.Load(); err != nil && !*healthCheck {
 		log.Println("No .env file found, using environment variables")
 	}
 
-	// Database connection
-	db, err := initDB()
+	// Probe the running server instead of starting one, as used by the Docker HEALTHCHECK
+	if *healthCheck {
+		url := fmt.Sprintf("http://localhost:%s/api/health/ready", getEnv("PORT", "8080"))
+		if err := runHealthCheck(url, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Database connection, or in-memory repositories for demos and tests
+	store, err := openStorage(*storageMode)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		log.Fatal("Failed to set up storage:", err)
 	}
-	defer db.Close()
+	defer store.close()
 
-	// Test database connection
-	if err := db.Ping(); err != nil {
-		log.Fatal("Failed to ping database:", err)
+	// Run a management command instead of the server when one is given
+	if flag.NArg() > 0 {
+		if store.db == nil {
+			log.Fatal("Management commands change the database and cannot run with in-memory storage")
+		}
+		if err := runCommand(context.Background(), store, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
-	log.Println("Database connection established")
 
-	// Run database migrations
-	migrator := postgres.NewMigrator(db)
-	if err := migrator.RunMigrations("./migrations"); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	// Apply pending migrations, refusing to start when an applied one was modified
+	if err := store.migrate(); err != nil {
+		log.Fatal(err)
 	}
-	log.Println("Database migrations completed")
 
 	// Initialize DDD layers
-	// 1. Infrastructure layer - Database repository
-	schemaReportRepo := postgres.NewPostgresSchemaReportRepository(db)
+	// 1. Infrastructure layer - Database repositories
+	schemaReportRepo := store.schemaReports
+	suppressionRepo := store.suppressions
+	gateRepo := store.gates
+	ruleRepo := store.rules
+	rescoreRepo := store.rescores
+	webhookRepo := store.webhooks
+	apiTokenRepo := store.apiTokens
 
 	// 2. Domain layer - Business logic services
-	schemaReportService := domain.NewSchemaReportService(schemaReportRepo)
+	missingSubgraphPolicy, err := domain.ParseMissingSubgraphPolicy(getEnv("MISSING_SUBGRAPH", string(domain.MissingSubgraphQuarantine)))
+	if err != nil {
+		log.Fatalf("Invalid MISSING_SUBGRAPH: %v", err)
+	}
+	scoreMismatchPolicy, err := domain.ParseScoreMismatchPolicy(getEnv("SCORE_MISMATCH", string(domain.ScoreMismatchFlag)))
+	if err != nil {
+		log.Fatalf("Invalid SCORE_MISMATCH: %v", err)
+	}
+	validatorOptions := []domain.ReportValidatorOption{
+		domain.WithMissingSubgraphPolicy(missingSubgraphPolicy),
+		domain.WithScoreMismatchPolicy(scoreMismatchPolicy),
+		domain.WithScoreTolerance(getEnvFloat("SCORE_TOLERANCE", domain.DefaultScoreTolerance)),
+		domain.WithMaxFutureSkew(getEnvDuration("MAX_FUTURE_SKEW", domain.DefaultMaxFutureSkew)),
+	}
+	if getEnv("STRICT_REPORTS", "false") == "true" {
+		validatorOptions = append(validatorOptions, domain.WithStrictFields())
+	}
+
+	webhookService := domain.NewWebhookService(webhookRepo, schemaReportRepo)
+	ruleService := domain.NewRuleCatalogService(ruleRepo)
+	// Reports are weighed and verified with the reviewed rules of the catalog
+	validatorOptions = append(validatorOptions, domain.WithRuleDefinitions(ruleService))
+	rescoreService := domain.NewRescoreService(schemaReportRepo, ruleRepo, rescoreRepo)
+	schemaReportService := domain.NewSchemaReportService(schemaReportRepo,
+		domain.WithSuppressions(suppressionRepo),
+		domain.WithWebhooks(webhookService),
+		domain.WithRuleCatalog(ruleService),
+		domain.WithReportValidator(domain.NewReportValidator(validatorOptions...)))
+	suppressionService := domain.NewSuppressionService(suppressionRepo)
+	gateService := domain.NewGateService(gateRepo, schemaReportRepo)
+	apiTokenService := domain.NewAPITokenService(apiTokenRepo)
+
+	// Optionally accept reports with 202 and store them from a bounded queue
+	shutdownTimeout := getEnvDuration("SHUTDOWN_TIMEOUT", httpHandlers.DefaultServerConfig("").ShutdownTimeout)
+	var ingestionQueue *domain.IngestionQueue
+	if getEnv("ASYNC_INGESTION", "false") == "true" {
+		ingestionQueue = domain.NewIngestionQueue(schemaReportService,
+			int(getEnvInt64("INGESTION_QUEUE_SIZE", 1000)),
+			int(getEnvInt64("INGESTION_WORKERS", 4)),
+			domain.WithIngestionGates(gateService),
+			domain.WithDrainTimeout(shutdownTimeout))
+	}
 
 	// 3. Application layer - HTTP handlers
-	apiHandler := httpHandlers.NewAPIHandler(schemaReportService)
-	webHandler := httpHandlers.NewWebHandler(schemaReportService)
+	maxReportBytes := getEnvInt64("MAX_REPORT_BYTES", httpHandlers.DefaultMaxReportBytes)
+	apiOptions := []httpHandlers.APIHandlerOption{
+		httpHandlers.WithGates(gateService),
+		httpHandlers.WithMaxReportBytes(maxReportBytes),
+	}
+	metricsOptions := []httpHandlers.MetricsHandlerOption{httpHandlers.WithWebhookMetrics(webhookService)}
+	if ingestionQueue != nil {
+		apiOptions = append(apiOptions, httpHandlers.WithIngestionQueue(ingestionQueue))
+		metricsOptions = append(metricsOptions, httpHandlers.WithIngestionQueueMetrics(ingestionQueue))
+	}
+	apiHandler := httpHandlers.NewAPIHandler(schemaReportService, apiOptions...)
+	webOptions := []httpHandlers.WebHandlerOption{
+		httpHandlers.WithRulePages(ruleService),
+		httpHandlers.WithRescoredHistory(rescoreService),
+	}
+	if *dev {
+		log.Println("Development mode: reloading templates and static files from disk")
+		webOptions = append(webOptions, httpHandlers.WithTemplateReload())
+	}
+	webHandler, err := httpHandlers.NewWebHandler(schemaReportService, web.Templates(*dev), webOptions...)
+	if err != nil {
+		log.Fatal("Failed to load templates:", err)
+	}
+	suppressionHandler := httpHandlers.NewSuppressionHandler(suppressionService)
+	gateHandler := httpHandlers.NewGateHandler(gateService, schemaReportService)
+	ruleHandler := httpHandlers.NewRuleHandler(ruleService)
+	rescoreHandler := httpHandlers.NewRescoreHandler(rescoreService)
+	webhookHandler := httpHandlers.NewWebhookHandler(webhookService)
+	healthHandler := httpHandlers.NewHealthHandler(schemaReportService, store.migrationVersion)
+	httpMetrics := httpHandlers.NewHTTPMetrics()
+	metricsHandler := httpHandlers.NewMetricsHandler(schemaReportService, httpMetrics, store.dbStats(), metricsOptions...)
+
+	// Stop on SIGTERM (container shutdown) and SIGINT (Ctrl+C)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	// Deliver queued webhook notifications in the background
+	dispatcherDone := make(chan struct{})
+	go func() {
+		webhook.NewDispatcher(webhookService).Run(ctx)
+		close(dispatcherDone)
+	}()
+
+	// Store queued reports until the server has stopped accepting them
+	ingestionCtx, stopIngestion := context.WithCancel(context.Background())
+	ingestionDone := make(chan struct{})
+	if ingestionQueue != nil {
+		log.Printf("Async ingestion: queue size %d, %d workers", ingestionQueue.Stats().Capacity, ingestionQueue.Stats().Workers)
+		go func() {
+			ingestionQueue.Run(ingestionCtx)
+			close(ingestionDone)
+		}()
+	} else {
+		close(ingestionDone)
+	}
 
 	// Setup routes
 	router := mux.NewRouter()
 
 	// API routes
 	api := router.PathPrefix("/api").Subrouter()
+	api.NotFoundHandler = http.HandlerFunc(httpHandlers.NotFound)
+	// No API request body may be larger than a report
+	api.Use(httpHandlers.LimitRequestBody(maxReportBytes))
 	api.HandleFunc("/health", apiHandler.HealthCheck).Methods("GET")
-	api.HandleFunc("/reports", apiHandler.ReceiveReport).Methods("POST")
+	api.HandleFunc("/health/live", healthHandler.Live).Methods("GET")
+	api.HandleFunc("/health/ready", healthHandler.Ready).Methods("GET")
+	requireAPIToken := httpHandlers.RequireAPIToken(apiTokenService, getEnv("ALLOW_ANONYMOUS_REPORTS", "false") == "true")
+	allowAnonymousAdmin := getEnv("ALLOW_ANONYMOUS_ADMIN", "false") == "true"
+	requireAdminToken := httpHandlers.RequireAdminToken(apiTokenService, allowAnonymousAdmin)
+	// Scoped tokens may change the configuration of their own subgraphs, which the handlers check
+	requireSubgraphToken := httpHandlers.RequireAPIToken(apiTokenService, allowAnonymousAdmin)
+	api.Handle("/reports", requireAPIToken(http.HandlerFunc(apiHandler.ReceiveReport))).Methods("POST")
 	api.HandleFunc("/reports", apiHandler.GetReports).Methods("GET")
+	api.HandleFunc("/reports/status/{ticket}", apiHandler.GetReportStatus).Methods("GET")
+	api.HandleFunc("/reports/diff", apiHandler.GetReportDiff).Methods("GET")
 	api.HandleFunc("/report", apiHandler.GetReport).Methods("GET")
+	api.HandleFunc("/violations", apiHandler.GetOpenViolations).Methods("GET")
+	api.HandleFunc("/suppressions", suppressionHandler.ListSuppressions).Methods("GET")
+	api.Handle("/suppressions", requireSubgraphToken(http.HandlerFunc(suppressionHandler.CreateSuppression))).Methods("POST")
+	api.HandleFunc("/suppression", suppressionHandler.GetSuppression).Methods("GET")
+	api.Handle("/suppression", requireSubgraphToken(http.HandlerFunc(suppressionHandler.UpdateSuppression))).Methods("PUT")
+	api.Handle("/suppression", requireSubgraphToken(http.HandlerFunc(suppressionHandler.DeleteSuppression))).Methods("DELETE")
+	api.HandleFunc("/gates", gateHandler.ListGates).Methods("GET")
+	api.HandleFunc("/gates/evaluate", gateHandler.EvaluateGate).Methods("POST")
+	api.HandleFunc("/gate", gateHandler.GetGate).Methods("GET")
+	api.Handle("/gate", requireAdminToken(http.HandlerFunc(gateHandler.SaveGate))).Methods("PUT")
+	api.Handle("/gate", requireAdminToken(http.HandlerFunc(gateHandler.DeleteGate))).Methods("DELETE")
+	api.HandleFunc("/rules", ruleHandler.ListRules).Methods("GET")
+	api.Handle("/rules", requireAdminToken(http.HandlerFunc(ruleHandler.CreateRule))).Methods("POST")
+	api.HandleFunc("/rules/{name:.+}", ruleHandler.GetRule).Methods("GET")
+	api.Handle("/rules/{name:.+}", requireAdminToken(http.HandlerFunc(ruleHandler.UpdateRule))).Methods("PUT")
+	api.Handle("/rules/{name:.+}", requireAdminToken(http.HandlerFunc(ruleHandler.DeleteRule))).Methods("DELETE")
+	api.Handle("/admin/rescore", requireAdminToken(http.HandlerFunc(rescoreHandler.Rescore))).Methods("POST")
+	api.Handle("/admin/ruleset-versions", requireAdminToken(http.HandlerFunc(rescoreHandler.ListRulesetVersions))).Methods("GET")
+	api.Handle("/webhooks", requireAdminToken(http.HandlerFunc(webhookHandler.ListWebhooks))).Methods("GET")
+	api.Handle("/webhooks", requireAdminToken(http.HandlerFunc(webhookHandler.CreateWebhook))).Methods("POST")
+	api.Handle("/webhook", requireAdminToken(http.HandlerFunc(webhookHandler.GetWebhook))).Methods("GET")
+	api.Handle("/webhook", requireAdminToken(http.HandlerFunc(webhookHandler.DeleteWebhook))).Methods("DELETE")
+	api.Handle("/webhook-deliveries", requireAdminToken(http.HandlerFunc(webhookHandler.ListDeliveries))).Methods("GET")
+	api.Handle("/webhook-delivery", requireAdminToken(http.HandlerFunc(webhookHandler.GetDelivery))).Methods("GET")
+	api.Handle("/webhook-delivery/retry", requireAdminToken(http.HandlerFunc(webhookHandler.RetryDelivery))).Methods("POST")
+
+	// Prometheus metrics
+	router.Handle("/metrics", metricsHandler).Methods("GET")
 
 	// Web routes
 	router.HandleFunc("/", webHandler.Dashboard).Methods("GET")
 	router.HandleFunc("/about", webHandler.About).Methods("GET")
 	router.HandleFunc("/report", webHandler.ReportDetail).Methods("GET")
 	router.HandleFunc("/subgraph", webHandler.SubgraphHistory).Methods("GET")
+	router.HandleFunc("/compare", webHandler.CompareReports).Methods("GET")
+	router.HandleFunc("/rules", webHandler.RuleCatalog).Methods("GET")
+	router.HandleFunc("/rules/{name:.+}", webHandler.RuleDetail).Methods("GET")
 
 	// Static files (for any additional assets)
-	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("./internal/static/"))))
+	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.FS(web.Static(*dev)))))
 
 	// Add CORS middleware for API endpoints
 	router.Use(corsMiddleware)
@@ -79,19 +250,100 @@ func main() {
 	// Add logging middleware
 	router.Use(loggingMiddleware)
 
+	// Cancel database work that outlives the query timeout or the client connection
+	router.Use(httpHandlers.QueryTimeout(getEnvDuration("QUERY_TIMEOUT", 10*time.Second)))
+
 	port := getEnv("PORT", "8080")
 	log.Printf("Server starting on port %s", port)
 	log.Printf("Dashboard: http://localhost:%s", port)
 	log.Printf("API Health: http://localhost:%s/api/health", port)
 	log.Printf("Reports endpoint: http://localhost:%s/api/reports", port)
+	log.Printf("Metrics: http://localhost:%s/metrics", port)
+
+	serverConfig := httpHandlers.DefaultServerConfig(":" + port)
+	serverConfig.ReadTimeout = getEnvDuration("HTTP_READ_TIMEOUT", serverConfig.ReadTimeout)
+	serverConfig.ReadHeaderTimeout = getEnvDuration("HTTP_READ_HEADER_TIMEOUT", serverConfig.ReadHeaderTimeout)
+	serverConfig.WriteTimeout = getEnvDuration("HTTP_WRITE_TIMEOUT", serverConfig.WriteTimeout)
+	serverConfig.IdleTimeout = getEnvDuration("HTTP_IDLE_TIMEOUT", serverConfig.IdleTimeout)
+	serverConfig.ShutdownTimeout = shutdownTimeout
 
-	if err := http.ListenAndServe(":"+port, router); err != nil {
-		log.Fatal("Server failed to start:", err)
+	// Serve until a signal arrives, then drain in-flight requests before the database is closed
+	// Request IDs and request metrics wrap the router so that unmatched routes are covered too
+	// A failed server still stops the background work and closes the database before exiting
+	serveErr := httpHandlers.NewServer(httpHandlers.RequestID(httpMetrics.Handler(router)), serverConfig).ListenAndServe(ctx)
+	if serveErr != nil {
+		log.Printf("Server failed: %v", serveErr)
+	}
+	stop()
+
+	// No more reports can arrive, so store the ones that are still queued. The queue gives up
+	// after the shutdown timeout, the extra grace period only guards against a stuck worker.
+	stopIngestion()
+	workersCtx, cancelWorkers := context.WithTimeout(context.Background(), shutdownTimeout+workerGracePeriod)
+	defer cancelWorkers()
+	select {
+	case <-ingestionDone:
+	case <-workersCtx.Done():
+		stats := ingestionQueue.Stats()
+		log.Printf("Abandoned %d queued reports that were not stored in time", stats.Depth+stats.InProgress)
+	}
+	select {
+	case <-dispatcherDone:
+	case <-workersCtx.Done():
+		log.Println("Abandoned webhook deliveries that were not finished in time")
+	}
+	log.Println("Server stopped, closing database connections")
+
+	if serveErr != nil {
+		_ = store.close()
+		os.Exit(1)
 	}
 }
 
-func initDB() (*sql.DB, error) {
+// runCommand executes a management subcommand such as "tokens create". Every command
+// except "migrate" migrates the database first.
+func runCommand(ctx context.Context, store *storage, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrateCommand(ctx, store, args[1:], os.Stdout)
+	case "tokens":
+		if err := store.migrate(); err != nil {
+			return err
+		}
+		tokenService := domain.NewAPITokenService(store.apiTokens)
+		return runTokensCommand(ctx, tokenService, args[1:], os.Stdout)
+	case "rescore":
+		if err := store.migrate(); err != nil {
+			return err
+		}
+		rescoreService := domain.NewRescoreService(store.schemaReports, store.rules, store.rescores)
+		return runRescoreCommand(ctx, rescoreService, args[1:], os.Stdout)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// initDB opens the database selected by DB_DRIVER or the DATABASE_URL scheme and
+// returns it together with the driver name
+func initDB() (*sql.DB, string, error) {
 	dbURL := getEnv("DATABASE_URL", "")
+	driver := databaseDriver(getEnv("DB_DRIVER", ""), dbURL)
+
+	if driver == driverSQLite {
+		if dbURL == "" {
+			dbURL = "schema-score.db"
+		}
+		db, err := sqlite.Open(dbURL)
+		if err != nil {
+			return nil, "", err
+		}
+		log.Printf("Using SQLite database %s", dbURL)
+		return db, driver, nil
+	}
+	if driver != driverPostgres {
+		return nil, "", fmt.Errorf("unsupported DB_DRIVER %q, use %s or %s", driver, driverPostgres, driverSQLite)
+	}
+
 	if dbURL == "" {
 		// Build connection string from individual components
 		host := getEnv("DB_HOST", "localhost")
@@ -107,7 +359,7 @@ func initDB() (*sql.DB, error) {
 
 	db, err := sql.Open("postgres", dbURL)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// Configure connection pool
@@ -117,13 +369,13 @@ func initDB() (*sql.DB, error) {
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	log.Printf("Database connection pool configured: max_open=%d, max_idle=%d, max_lifetime=%v",
 		25, 5, 5*time.Minute)
 
-	return db, nil
+	return db, driver, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -133,12 +385,51 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvDuration reads a duration such as "30s"; an invalid value stops the server
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s %q: %v", key, value, err)
+	}
+	return duration
+}
+
+// getEnvInt64 reads an integer; an invalid value stops the server
+func getEnvInt64(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s %q: %v", key, value, err)
+	}
+	return parsed
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed < 0 {
+		log.Fatalf("Invalid %s %q: must be a non-negative number", key, value)
+	}
+	return parsed
+}
+
 // CORS middleware
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if r.Method == "OPTIONS" {
 			return
@@ -151,7 +442,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 // Logging middleware
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s %s", r.Method, r.RequestURI, r.RemoteAddr)
+		log.Printf("%s %s %s %s", r.Method, r.RequestURI, r.RemoteAddr, httpHandlers.RequestIDFromContext(r.Context()))
 		next.ServeHTTP(w, r)
 	})
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"schema-score-server/migrations"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrateCommand implements "migrate status|up|down"
+func runMigrateCommand(ctx context.Context, store *storage, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate status|up|down")
+	}
+
+	switch args[0] {
+	case "status":
+		statuses, err := store.migrator.Status(ctx, store.migrationFiles)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "MIGRATION\tSTATUS\tAPPLIED\tDOWN")
+		for _, status := range statuses {
+			appliedAt := "-"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			down := "no"
+			if status.HasDown {
+				down = "yes"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", status.Name, migrationState(status), appliedAt, down)
+		}
+		return writer.Flush()
+
+	case "up":
+		if err := store.migrate(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "All migrations applied")
+		return nil
+
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		steps := flags.Int("steps", 1, "Number of applied migrations to roll back, newest first")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *steps < 1 {
+			return fmt.Errorf("-steps must be at least 1")
+		}
+
+		reverted, err := store.migrator.Rollback(ctx, store.migrationFiles, *steps)
+		for _, name := range reverted {
+			fmt.Fprintf(out, "Rolled back %s\n", name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Fprintln(out, "No applied migrations to roll back")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q, use status, up or down", args[0])
+	}
+}
+
+// migrationState describes a migration for "migrate status"
+func migrationState(status migrations.Status) string {
+	switch {
+	case !status.Applied:
+		return "pending"
+	case status.Checksum == "":
+		return "applied, file missing"
+	case status.Drifted():
+		return "modified since applied"
+	default:
+		return "applied"
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"schema-score-server/internal/domain"
+	"text/tabwriter"
+	"time"
+)
+
+// runRescoreCommand implements "rescore run|versions"
+func runRescoreCommand(ctx context.Context, rescoreService *domain.RescoreService, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: rescore run|versions")
+	}
+
+	switch args[0] {
+	case "run":
+		flags := flag.NewFlagSet("rescore run", flag.ContinueOnError)
+		version := flags.String("version", domain.CurrentRulesetVersion, "Ruleset version to rescore with, or current for the weights of the rule catalog")
+		subgraph := flags.String("subgraph", "", "Subgraph to rescore (default all)")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		result, err := rescoreService.Rescore(ctx, *version, *subgraph)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Rescored %d reports with ruleset version %s\n", result.Rescored, result.RulesetVersion)
+		if result.Skipped > 0 {
+			fmt.Fprintf(out, "Skipped %d reports without fields\n", result.Skipped)
+		}
+		return nil
+
+	case "versions":
+		current, err := rescoreService.CurrentRulesetVersion(ctx)
+		if err != nil {
+			return err
+		}
+		versions, err := rescoreService.ListRulesetVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tCREATED\tRULES\tCURRENT")
+		for _, version := range versions {
+			isCurrent := ""
+			if version.Version == current.Version {
+				isCurrent = "yes"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%d\t%s\n",
+				version.Version, version.CreatedAt.Format(time.RFC3339), len(version.Weights), isCurrent)
+		}
+		return writer.Flush()
+
+	default:
+		return fmt.Errorf("unknown rescore command %q, expected run or versions", args[0])
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"schema-score-server/internal/adapters/memory"
+	"schema-score-server/internal/adapters/postgres"
+	"schema-score-server/internal/adapters/sqlite"
+	"schema-score-server/internal/domain"
+	"schema-score-server/migrations"
+	"strings"
+)
+
+// Supported values of --storage
+const (
+	storageDatabase = "database"
+	storageMemory   = "memory"
+)
+
+// Supported values of DB_DRIVER
+const (
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+)
+
+// storage holds the repositories of the configured database
+type storage struct {
+	// db is nil for in-memory storage
+	db *sql.DB
+
+	schemaReports domain.SchemaReportRepository
+	suppressions  domain.SuppressionRepository
+	gates         domain.GateRepository
+	rules         domain.RuleRepository
+	rescores      domain.RescoreRepository
+	webhooks      domain.WebhookRepository
+	apiTokens     domain.APITokenRepository
+
+	// migrator and migrationFiles are nil for in-memory storage
+	migrator       migrator
+	migrationFiles fs.FS
+
+	// migrationVersion returns the latest applied migration for the readiness probe
+	migrationVersion func(ctx context.Context) (string, error)
+}
+
+// migrator applies, lists and reverts the migrations of a database
+type migrator interface {
+	RunMigrations(fsys fs.FS) error
+	Status(ctx context.Context, fsys fs.FS) ([]migrations.Status, error)
+	Rollback(ctx context.Context, fsys fs.FS, steps int) ([]string, error)
+}
+
+// openStorage connects to the database, or creates empty in-memory repositories when
+// mode is "memory". The database is not migrated until migrate is called.
+func openStorage(mode string) (*storage, error) {
+	switch mode {
+	case storageMemory:
+		log.Println("Using in-memory storage, all data is lost when the server stops")
+		return newMemoryStorage(), nil
+	case storageDatabase:
+	default:
+		return nil, fmt.Errorf("unsupported storage %q, use %s or %s", mode, storageDatabase, storageMemory)
+	}
+
+	db, driver, err := initDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// Test database connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+	log.Printf("Database connection established (%s)", driver)
+
+	// Create the repositories of the driver
+	store, err := newStorage(db, driver)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// migrate applies the pending migrations, and fails when an applied migration file was
+// modified. In-memory storage has nothing to migrate.
+func (s *storage) migrate() error {
+	if s.migrator == nil {
+		return nil
+	}
+	if err := s.migrator.RunMigrations(s.migrationFiles); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	log.Println("Database migrations completed")
+	return nil
+}
+
+// close releases the database connections
+func (s *storage) close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// dbStats returns the connection pool statistics for /metrics, or nil without a database
+func (s *storage) dbStats() func() sql.DBStats {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Stats
+}
+
+// newMemoryStorage creates empty in-memory repositories, except for the rule catalog which
+// starts with the default rules. Without migrations the readiness probe skips the
+// migration check.
+func newMemoryStorage() *storage {
+	return &storage{
+		schemaReports: memory.NewMemorySchemaReportRepository(),
+		suppressions:  memory.NewMemorySuppressionRepository(),
+		gates:         memory.NewMemoryGateRepository(),
+		rules:         memory.NewSeededMemoryRuleRepository(),
+		rescores:      memory.NewMemoryRescoreRepository(),
+		webhooks:      memory.NewMemoryWebhookRepository(),
+		apiTokens:     memory.NewMemoryAPITokenRepository(),
+	}
+}
+
+// newStorage creates the repositories and the migrator for the database driver
+func newStorage(db *sql.DB, driver string) (*storage, error) {
+	switch driver {
+	case driverPostgres:
+		migrator := postgres.NewMigrator(db)
+		return &storage{
+			db:               db,
+			schemaReports:    postgres.NewPostgresSchemaReportRepository(db),
+			suppressions:     postgres.NewPostgresSuppressionRepository(db),
+			gates:            postgres.NewPostgresGateRepository(db),
+			rules:            postgres.NewPostgresRuleRepository(db),
+			rescores:         postgres.NewPostgresRescoreRepository(db),
+			webhooks:         postgres.NewPostgresWebhookRepository(db),
+			apiTokens:        postgres.NewPostgresAPITokenRepository(db),
+			migrator:         migrator,
+			migrationFiles:   migrations.FS,
+			migrationVersion: migrator.AppliedVersion,
+		}, nil
+	case driverSQLite:
+		migrator := sqlite.NewMigrator(db)
+		return &storage{
+			db:               db,
+			schemaReports:    sqlite.NewSQLiteSchemaReportRepository(db),
+			suppressions:     sqlite.NewSQLiteSuppressionRepository(db),
+			gates:            sqlite.NewSQLiteGateRepository(db),
+			rules:            sqlite.NewSQLiteRuleRepository(db),
+			rescores:         sqlite.NewSQLiteRescoreRepository(db),
+			webhooks:         sqlite.NewSQLiteWebhookRepository(db),
+			apiTokens:        sqlite.NewSQLiteAPITokenRepository(db),
+			migrator:         migrator,
+			migrationFiles:   migrations.SQLite,
+			migrationVersion: migrator.AppliedVersion,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
+
+// databaseDriver picks the driver from DB_DRIVER, or else from the scheme of the
+// database URL. PostgreSQL is the default.
+func databaseDriver(configured, databaseURL string) string {
+	if configured != "" {
+		return strings.ToLower(configured)
+	}
+	if strings.HasPrefix(databaseURL, "sqlite:") || strings.HasPrefix(databaseURL, "file:") {
+		return driverSQLite
+	}
+	return driverPostgres
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"schema-score-server/internal/domain"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// stringList collects the values of a repeatable flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// runTokensCommand implements "tokens create|list|revoke"
+func runTokensCommand(ctx context.Context, tokenService *domain.APITokenService, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: tokens create|list|revoke")
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("tokens create", flag.ContinueOnError)
+		name := flags.String("name", "", "Name describing where the token is used")
+		var subgraphs stringList
+		flags.Var(&subgraphs, "subgraph", "Subgraph the token may submit reports for (repeatable, default all)")
+		admin := flags.Bool("admin", false, "Allow the token to use admin endpoints, requires a token for all subgraphs")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		token, plaintext, err := tokenService.CreateToken(ctx, *name, subgraphs, *admin)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Created API token %s (%s)\n", token.ID, token.Name)
+		fmt.Fprintf(out, "Scope: %s\n", tokenScope(*token))
+		fmt.Fprintf(out, "\n%s\n\n", plaintext)
+		fmt.Fprintln(out, "Store this token now, it cannot be shown again.")
+		return nil
+
+	case "list":
+		tokens, err := tokenService.ListTokens(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\tNAME\tPREFIX\tSCOPE\tCREATED\tLAST USED\tSTATUS")
+		for _, token := range tokens {
+			status := "active"
+			if token.IsRevoked() {
+				status = "revoked " + token.RevokedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s…\t%s\t%s\t%s\t%s\n",
+				token.ID, token.Name, token.Prefix, tokenScope(token),
+				token.CreatedAt.Format(time.RFC3339), formatOptionalTime(token.LastUsedAt), status)
+		}
+		return writer.Flush()
+
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: tokens revoke <id>")
+		}
+		if err := tokenService.RevokeToken(ctx, args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Revoked API token %s\n", args[1])
+		return nil
+
+	default:
+		return fmt.Errorf("unknown tokens command %q, expected create, list or revoke", args[0])
+	}
+}
+
+func tokenScope(token domain.APIToken) string {
+	if token.Admin {
+		return "admin"
+	}
+	if len(token.Subgraphs) == 0 {
+		return "all subgraphs"
+	}
+	return strings.Join(token.Subgraphs, ",")
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -2,74 +2,212 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"schema-score-server/internal/domain"
 	"strconv"
 	"time"
+
+	"github.com/gorilla/mux"
 )
 
+// DefaultMaxReportBytes is the default size limit of a report request body
+const DefaultMaxReportBytes int64 = 10 << 20
+
 // APIHandler handles HTTP API requests
 type APIHandler struct {
 	schemaReportService *domain.SchemaReportService
+	gateService         *domain.GateService
+	maxReportBytes      int64
+	ingestionQueue      *domain.IngestionQueue
+}
+
+// APIHandlerOption configures optional features of the API handler
+type APIHandlerOption func(*APIHandler)
+
+// WithGates evaluates every received report against the quality gate of its subgraph
+func WithGates(gateService *domain.GateService) APIHandlerOption {
+	return func(h *APIHandler) {
+		h.gateService = gateService
+	}
+}
+
+// WithMaxReportBytes limits the size of report request bodies
+func WithMaxReportBytes(maxBytes int64) APIHandlerOption {
+	return func(h *APIHandler) {
+		h.maxReportBytes = maxBytes
+	}
+}
+
+// WithIngestionQueue accepts reports with 202 Accepted and leaves storing them to the
+// workers of the queue
+func WithIngestionQueue(queue *domain.IngestionQueue) APIHandlerOption {
+	return func(h *APIHandler) {
+		h.ingestionQueue = queue
+	}
 }
 
 // NewAPIHandler creates a new API handler
-func NewAPIHandler(schemaReportService *domain.SchemaReportService) *APIHandler {
-	return &APIHandler{
+func NewAPIHandler(schemaReportService *domain.SchemaReportService, opts ...APIHandlerOption) *APIHandler {
+	h := &APIHandler{
 		schemaReportService: schemaReportService,
+		maxReportBytes:      DefaultMaxReportBytes,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // ReceiveReport handles incoming schema reports
 func (h *APIHandler) ReceiveReport(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeProblem(w, r, http.StatusMethodNotAllowed, "")
 		return
 	}
 
-	var incoming domain.IncomingReport
-	if err := json.NewDecoder(r.Body).Decode(&incoming); err != nil {
-		log.Printf("Error decoding request: %v", err)
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxReportBytes)
+
+	validator := h.schemaReportService.ReportValidator()
+	incoming, ok := decodeReport(w, r, validator)
+	if !ok {
 		return
 	}
 
 	// Convert DTO to domain entities
-	report, ruleResults, err := incoming.ToDomainEntity()
+	report, ruleResults, err := validator.Convert(r.Context(), incoming)
 	if err != nil {
-		log.Printf("Error converting to domain entity: %v", err)
-		http.Error(w, "Invalid data format", http.StatusBadRequest)
+		writeError(w, r, "Rejected invalid report", err)
+		return
+	}
+
+	// Scoped tokens may only submit reports for their own subgraphs
+	if !allowSubgraph(w, r, report.SubgraphName) {
+		return
+	}
+	token, authenticated := APITokenFromContext(r.Context())
+
+	submission := domain.ReportSubmission{
+		Report:      report,
+		RuleResults: ruleResults,
+	}
+
+	// Record which token submitted the report
+	if authenticated {
+		submission.APITokenID = &token.ID
+	}
+
+	// Leave storing the report to the ingestion workers in async mode
+	if h.ingestionQueue != nil {
+		h.enqueueReport(w, r, submission)
 		return
 	}
 
 	// Store the report using the domain service
-	storedReport, err := h.schemaReportService.StoreReport(
-		&report.SubgraphName,
-		report.Score,
-		report.TotalFields,
-		report.TotalWeightedViolations,
-		report.Timestamp,
-		report.Metadata,
-		ruleResults,
-	)
+	storedReport, err := h.schemaReportService.StoreSubmission(r.Context(), submission)
 	if err != nil {
-		log.Printf("Error storing report: %v", err)
-		http.Error(w, "Failed to store report", http.StatusInternalServerError)
+		writeError(w, r, "Error storing report", err)
 		return
 	}
 
 	log.Printf("Stored report for subgraph: %v, score: %.2f",
 		report.SubgraphName, report.Score)
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+	response := map[string]interface{}{
 		"success":   true,
 		"report_id": storedReport.ID,
 		"message":   "Report stored successfully",
+	}
+
+	if storedReport.RecomputedScore != nil {
+		response["recomputed_score"] = *storedReport.RecomputedScore
+		response["score_mismatch"] = storedReport.ScoreMismatch
+	}
+
+	// The report is already stored, so a gate error is reported instead of failing the request
+	if h.gateService != nil {
+		result, err := h.gateService.Evaluate(r.Context(), storedReport)
+		if err != nil {
+			log.Printf("Error evaluating quality gate: %v", err)
+			response["gate"] = "error"
+		} else {
+			response["gate"] = result.Status
+			response["gate_result"] = gateResultResponse(result)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// enqueueReport queues a report for the ingestion workers and points the client at its
+// status. A full queue is answered with 503 so that clients back off and retry.
+func (h *APIHandler) enqueueReport(w http.ResponseWriter, r *http.Request, submission domain.ReportSubmission) {
+	ticket, err := h.ingestionQueue.Enqueue(submission)
+	if err != nil {
+		if errors.Is(err, domain.ErrIngestionQueueFull) {
+			w.Header().Set("Retry-After", "5")
+		}
+		writeError(w, r, "Error queueing report", err)
+		return
+	}
+
+	log.Printf("Queued report for subgraph: %v, ticket: %s", ticket.SubgraphName, ticket.ID)
+
+	statusURL := "/api/reports/status/" + ticket.ID
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", statusURL)
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"ticket":      ticket.ID,
+		"status":      ticket.Status,
+		"status_url":  statusURL,
+		"queue_depth": h.ingestionQueue.Stats().Depth,
+		"message":     "Report queued for storage",
 	})
 }
 
+// GetReportStatus returns the ingestion status of a queued report
+func (h *APIHandler) GetReportStatus(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["ticket"]
+	if h.ingestionQueue == nil {
+		writeError(w, r, "Error getting ticket", fmt.Errorf("ticket %s: %w", id, domain.ErrIngestionTicketNotFound))
+		return
+	}
+
+	ticket, err := h.ingestionQueue.Ticket(id)
+	if err != nil {
+		writeError(w, r, "Error getting ticket", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"ticket":     ticket.ID,
+		"status":     ticket.Status,
+		"subgraph":   ticket.SubgraphName,
+		"queued_at":  ticket.QueuedAt,
+		"updated_at": ticket.UpdatedAt,
+	}
+	switch ticket.Status {
+	case domain.IngestionStatusStored:
+		response["report_id"] = ticket.ReportID
+		if ticket.GateError != "" {
+			response["gate"] = "error"
+		} else if ticket.GateResult != nil {
+			response["gate"] = ticket.GateResult.Status
+			response["gate_result"] = gateResultResponse(ticket.GateResult)
+		}
+	case domain.IngestionStatusFailed:
+		response["error"] = ticket.Error
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
 // GetReports returns a list of reports with optional filtering
 func (h *APIHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
@@ -81,7 +219,7 @@ func (h *APIHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
-		http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "Invalid limit parameter")
 		return
 	}
 
@@ -89,10 +227,9 @@ func (h *APIHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 
 	if subgraph != "" {
 		// Get reports for specific subgraph
-		subgraphReports, err := h.schemaReportService.GetSubgraphHistory(subgraph, limit)
+		subgraphReports, err := h.schemaReportService.GetSubgraphHistory(r.Context(), subgraph, limit)
 		if err != nil {
-			log.Printf("Error getting subgraph reports: %v", err)
-			http.Error(w, "Failed to get reports", http.StatusInternalServerError)
+			writeError(w, r, "Error getting subgraph reports", err)
 			return
 		}
 		for _, report := range subgraphReports {
@@ -100,10 +237,9 @@ func (h *APIHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		// Get recent reports
-		recentReports, err := h.schemaReportService.GetDashboardData()
+		recentReports, err := h.schemaReportService.GetDashboardData(r.Context())
 		if err != nil {
-			log.Printf("Error getting recent reports: %v", err)
-			http.Error(w, "Failed to get reports", http.StatusInternalServerError)
+			writeError(w, r, "Error getting recent reports", err)
 			return
 		}
 		for _, report := range recentReports.RecentReports {
@@ -119,18 +255,13 @@ func (h *APIHandler) GetReports(w http.ResponseWriter, r *http.Request) {
 func (h *APIHandler) GetReport(w http.ResponseWriter, r *http.Request) {
 	reportID := r.URL.Query().Get("id")
 	if reportID == "" {
-		http.Error(w, "Report ID required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "Report ID required")
 		return
 	}
 
-	report, err := h.schemaReportService.GetReportByID(reportID)
+	report, err := h.schemaReportService.GetReportByID(r.Context(), reportID)
 	if err != nil {
-		log.Printf("Error getting report: %v", err)
-		if err.Error() == "report with ID "+reportID+" not found" {
-			http.Error(w, "Report not found", http.StatusNotFound)
-		} else {
-			http.Error(w, "Failed to get report", http.StatusInternalServerError)
-		}
+		writeError(w, r, "Error getting report", err)
 		return
 	}
 
@@ -138,12 +269,86 @@ func (h *APIHandler) GetReport(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(report)
 }
 
+// GetReportDiff returns the violation diff between a base and a head report
+func (h *APIHandler) GetReportDiff(w http.ResponseWriter, r *http.Request) {
+	baseID := r.URL.Query().Get("base")
+	headID := r.URL.Query().Get("head")
+	if baseID == "" || headID == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Base and head report IDs required")
+		return
+	}
+
+	diff, err := h.schemaReportService.CompareReports(r.Context(), baseID, headID)
+	if err != nil {
+		writeError(w, r, "Error comparing reports", err)
+		return
+	}
+
+	rules := make([]map[string]interface{}, 0, len(diff.Rules))
+	for _, rule := range diff.Rules {
+		rules = append(rules, map[string]interface{}{
+			"rule":      rule.RuleName,
+			"new":       rule.New,
+			"fixed":     rule.Fixed,
+			"unchanged": rule.Unchanged,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"base_report_id":     diff.Base.ID,
+		"head_report_id":     diff.Head.ID,
+		"base_score":         diff.Base.Score,
+		"head_score":         diff.Head.Score,
+		"score_delta":        diff.ScoreDelta,
+		"total_fields_delta": diff.TotalFieldsDelta,
+		"new_count":          diff.NewCount,
+		"fixed_count":        diff.FixedCount,
+		"unchanged_count":    diff.UnchangedCount,
+		"rules":              rules,
+	})
+}
+
+// GetOpenViolations returns the unresolved violations of a subgraph with their age, oldest first
+func (h *APIHandler) GetOpenViolations(w http.ResponseWriter, r *http.Request) {
+	subgraph := r.URL.Query().Get("subgraph")
+	if subgraph == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Subgraph name required")
+		return
+	}
+
+	violations, err := h.schemaReportService.GetOpenViolations(r.Context(), subgraph)
+	if err != nil {
+		writeError(w, r, "Error getting open violations", err)
+		return
+	}
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(violations))
+	for _, violation := range violations {
+		result = append(result, map[string]interface{}{
+			"fingerprint":          violation.Fingerprint,
+			"rule":                 violation.RuleName,
+			"coordinate":           violation.LocationCoordinate,
+			"message":              violation.Message,
+			"first_seen_report_id": violation.FirstSeenReportID,
+			"first_seen_at":        violation.FirstSeenAt,
+			"last_seen_report_id":  violation.LastSeenReportID,
+			"last_seen_at":         violation.LastSeenAt,
+			"age_days":             violation.AgeDays(now),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
 // HealthCheck provides a simple health check endpoint
 func (h *APIHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	err := h.schemaReportService.HealthCheck()
+	err := h.schemaReportService.HealthCheck(r.Context())
 	if err != nil {
 		log.Printf("Health check failed: %v", err)
-		http.Error(w, "Service unavailable", http.StatusServiceUnavailable)
+		writeProblem(w, r, http.StatusServiceUnavailable, "")
 		return
 	}
 
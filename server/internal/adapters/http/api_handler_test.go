@@ -2,7 +2,9 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -195,6 +197,136 @@ func TestAPIHandler_GetReports(t *testing.T) {
 	}
 }
 
+func TestAPIHandler_GetReportDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+	}{
+		{
+			name:           "successful diff",
+			queryParams:    "base=1&head=2",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing head parameter",
+			queryParams:    "base=1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown report",
+			queryParams:    "base=1&head=999",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSchemaReportRepository()
+			repo.Reports["1"] = &domain.SchemaReport{
+				ID:          "1",
+				Score:       80.0,
+				TotalFields: 20,
+				RuleResults: []domain.RuleResult{
+					{
+						RuleName: "PII",
+						Violations: []domain.Violation{
+							{Message: "Field 'email' contains PII", LocationCoordinate: stringPtr("User.email")},
+						},
+					},
+				},
+			}
+			repo.Reports["2"] = &domain.SchemaReport{
+				ID:          "2",
+				Score:       100.0,
+				TotalFields: 22,
+			}
+			service := domain.NewSchemaReportService(repo)
+
+			handler := NewAPIHandler(service)
+
+			req := httptest.NewRequest("GET", "/api/reports/diff?"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetReportDiff(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Equal(t, 20.0, response["score_delta"])
+				assert.Equal(t, 2.0, response["total_fields_delta"])
+				assert.Equal(t, 1.0, response["fixed_count"])
+				assert.Equal(t, 0.0, response["new_count"])
+			}
+		})
+	}
+}
+
+func TestAPIHandler_GetOpenViolations(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		shouldFail     bool
+		expectedStatus int
+	}{
+		{
+			name:           "successful retrieval",
+			queryParams:    "subgraph=user-service",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing subgraph",
+			queryParams:    "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "repository failure",
+			queryParams:    "subgraph=user-service",
+			shouldFail:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSchemaReportRepository().
+				WithOpenViolations([]domain.TrackedViolation{
+					{
+						Fingerprint:  "abc",
+						SubgraphName: "user-service",
+						RuleName:     "PII",
+						Message:      "Field 'email' contains PII",
+						FirstSeenAt:  time.Now().Add(-48 * time.Hour),
+					},
+				})
+			repo.ShouldFailGetOpenViolations = tt.shouldFail
+			service := domain.NewSchemaReportService(repo)
+
+			handler := NewAPIHandler(service)
+
+			req := httptest.NewRequest("GET", "/api/violations?"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetOpenViolations(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []map[string]interface{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Len(t, response, 1)
+				assert.Equal(t, 2.0, response[0]["age_days"])
+			}
+		})
+	}
+}
+
 func TestAPIHandler_HealthCheck(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -377,3 +509,255 @@ func TestAPIHandler_ContentTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIHandler_ReceiveReport_Async(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	service := domain.NewSchemaReportService(repo)
+	queue := domain.NewIngestionQueue(service, 10, 1)
+	handler := NewAPIHandler(service, WithIngestionQueue(queue))
+
+	body, _ := json.Marshal(domain.IncomingReport{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		SubgraphName: stringPtr("user-service"),
+		Score:        85.5,
+		TotalFields:  42,
+	})
+	req := httptest.NewRequest("POST", "/api/reports", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ReceiveReport(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, repo.Reports, "the report is stored by the workers")
+
+	var accepted map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+	ticket, _ := accepted["ticket"].(string)
+	assert.NotEmpty(t, ticket)
+	assert.Equal(t, "queued", accepted["status"])
+	assert.Equal(t, "/api/reports/status/"+ticket, accepted["status_url"])
+	assert.Equal(t, "/api/reports/status/"+ticket, w.Header().Get("Location"))
+	assert.Equal(t, float64(1), accepted["queue_depth"])
+
+	getStatus := func() map[string]interface{} {
+		req := mux.SetURLVars(httptest.NewRequest("GET", "/api/reports/status/"+ticket, nil),
+			map[string]string{"ticket": ticket})
+		w := httptest.NewRecorder()
+		handler.GetReportStatus(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var status map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		return status
+	}
+
+	status := getStatus()
+	assert.Equal(t, "queued", status["status"])
+	assert.Equal(t, "user-service", status["subgraph"])
+	assert.NotContains(t, status, "report_id")
+
+	// Stopping the queue stores the queued report
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx)
+
+	status = getStatus()
+	assert.Equal(t, "stored", status["status"])
+	assert.Contains(t, repo.Reports, status["report_id"])
+}
+
+func TestAPIHandler_ReceiveReport_QueueFull(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+	handler := NewAPIHandler(service, WithIngestionQueue(domain.NewIngestionQueue(service, 1, 1)))
+
+	body, _ := json.Marshal(domain.IncomingReport{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		SubgraphName: stringPtr("user-service"),
+	})
+
+	codes := make([]int, 2)
+	for i := range codes {
+		w := httptest.NewRecorder()
+		handler.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", bytes.NewReader(body)))
+		codes[i] = w.Code
+		if w.Code == http.StatusServiceUnavailable {
+			assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		}
+	}
+
+	assert.Equal(t, []int{http.StatusAccepted, http.StatusServiceUnavailable}, codes)
+}
+
+func TestAPIHandler_GetReportStatus_NotFound(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+
+	for name, handler := range map[string]*APIHandler{
+		"async":       NewAPIHandler(service, WithIngestionQueue(domain.NewIngestionQueue(service, 1, 1))),
+		"synchronous": NewAPIHandler(service),
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest("GET", "/api/reports/status/missing", nil),
+				map[string]string{"ticket": "missing"})
+			w := httptest.NewRecorder()
+			handler.GetReportStatus(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+		})
+	}
+}
+
+func TestAPIHandler_GetReport_NotFound(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+	handler := NewAPIHandler(service)
+
+	req := httptest.NewRequest("GET", "/api/report?id=missing", nil)
+	w := httptest.NewRecorder()
+	RequestID(http.HandlerFunc(handler.GetReport)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	var problem Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, "urn:schema-score:problem:report-not-found", problem.Type)
+	assert.Equal(t, "Report not found", problem.Title)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "report with ID missing: report not found", problem.Detail)
+	assert.Equal(t, "/api/report?id=missing", problem.Instance)
+	assert.Equal(t, w.Header().Get(RequestIDHeader), problem.RequestID)
+	assert.NotEmpty(t, problem.RequestID)
+}
+
+func TestAPIHandler_ReceiveReport_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expectedType  string
+		expectedParam InvalidParam
+	}{
+		{
+			name:          "invalid timestamp",
+			body:          `{"timestamp": "yesterday", "subgraphName": "users"}`,
+			expectedType:  "urn:schema-score:problem:invalid-report",
+			expectedParam: InvalidParam{Path: "timestamp", Reason: "must be an RFC3339 timestamp or milliseconds since the Unix epoch"},
+		},
+		{
+			name:          "wrong type",
+			body:          `{"timestamp": "2025-01-30T17:30:00Z", "totalFields": "many"}`,
+			expectedType:  "urn:schema-score:problem:invalid-report",
+			expectedParam: InvalidParam{Path: "totalFields", Reason: "must be an integer"},
+		},
+		{
+			name:          "null body",
+			body:          `null`,
+			expectedType:  "urn:schema-score:problem:invalid-report",
+			expectedParam: InvalidParam{Path: "$", Reason: "must be a JSON object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAPIHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository()))
+
+			req := httptest.NewRequest("POST", "/api/reports", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.ReceiveReport(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+			var problem Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, tt.expectedType, problem.Type)
+			assert.Equal(t, []InvalidParam{tt.expectedParam}, problem.InvalidParams)
+		})
+	}
+}
+
+func TestAPIHandler_ReceiveReport_StrictFields(t *testing.T) {
+	body := `{"timestamp": "2025-01-30T17:30:00Z", "subgraphName": "users", "subgraph_name": "users"}`
+
+	lenient := NewAPIHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository()))
+	w := httptest.NewRecorder()
+	lenient.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	strict := NewAPIHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository(),
+		domain.WithReportValidator(domain.NewReportValidator(domain.WithStrictFields()))))
+	w = httptest.NewRecorder()
+	strict.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var problem Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, []InvalidParam{{Path: "subgraph_name", Reason: "is not a known field"}}, problem.InvalidParams)
+}
+
+func TestAPIHandler_ReceiveReport_SnakeCase(t *testing.T) {
+	body := `{"timestamp": 1738258200000, "subgraph_name": "users", "score": 90, "total_fields": 20,
+		"total_weighted_violations": 2, "rule_results": [{"rule": "PII", "violations": [{"message": "email"}]}]}`
+
+	repo := NewMockSchemaReportRepository()
+	handler := NewAPIHandler(domain.NewSchemaReportService(repo))
+
+	w := httptest.NewRecorder()
+	handler.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	if assert.Len(t, repo.Reports, 1) {
+		for _, report := range repo.Reports {
+			assert.Equal(t, "users", report.SubgraphName)
+			assert.Equal(t, 20, report.TotalFields)
+			assert.Equal(t, time.Date(2025, 1, 30, 17, 30, 0, 0, time.UTC), report.Timestamp)
+		}
+	}
+}
+
+func TestAPIHandler_ReceiveReport_MissingSubgraph(t *testing.T) {
+	body := `{"timestamp": "2025-01-30T17:30:00Z", "score": 90, "totalFields": -1,
+		"ruleResults": [{"rule": "PII"}, {"rule": "PII"}]}`
+
+	repo := NewMockSchemaReportRepository()
+	handler := NewAPIHandler(domain.NewSchemaReportService(repo,
+		domain.WithReportValidator(domain.NewReportValidator(domain.WithMissingSubgraphPolicy(domain.MissingSubgraphReject)))))
+
+	w := httptest.NewRecorder()
+	handler.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, repo.Reports)
+
+	var problem Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, []InvalidParam{
+		{Path: "subgraphName", Reason: "is required"},
+		{Path: "totalFields", Reason: "must not be negative"},
+		{Path: "ruleResults[1].rule", Reason: "duplicates ruleResults[0].rule"},
+	}, problem.InvalidParams)
+}
+
+func TestAPIHandler_ReceiveReport_ScoreMismatch(t *testing.T) {
+	// PII: 10 × 1^1.5 = 10 of 20 fields recomputes to 50, not the claimed 90
+	body := `{"timestamp": "2025-01-30T17:30:00Z", "subgraphName": "users", "score": 90, "totalFields": 20,
+		"totalWeightedViolations": 2, "ruleResults": [{"rule": "PII", "violations": [{"message": "email"}]}]}`
+
+	repo := NewMockSchemaReportRepository()
+	flagging := NewAPIHandler(domain.NewSchemaReportService(repo))
+	w := httptest.NewRecorder()
+	flagging.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, true, response["score_mismatch"])
+	assert.InDelta(t, 50, response["recomputed_score"], 0.0001)
+
+	rejecting := NewAPIHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository(),
+		domain.WithReportValidator(domain.NewReportValidator(domain.WithScoreMismatchPolicy(domain.ScoreMismatchReject)))))
+	w = httptest.NewRecorder()
+	rejecting.ReceiveReport(w, httptest.NewRequest("POST", "/api/reports", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var problem Problem
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, []InvalidParam{{Path: "score", Reason: "must match the score of 50.00 computed from the rule results"}},
+		problem.InvalidParams)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+	"strings"
+)
+
+type contextKey string
+
+const apiTokenContextKey contextKey = "api-token"
+
+// APITokenFromContext returns the API token that authenticated a request, if any
+func APITokenFromContext(ctx context.Context) (*domain.APIToken, bool) {
+	token, ok := ctx.Value(apiTokenContextKey).(*domain.APIToken)
+	return token, ok
+}
+
+// RequireAPIToken authenticates requests with an "Authorization: Bearer <token>" header and
+// stores the token in the request context. Requests without a token are rejected unless
+// allowAnonymous is set; a token that is present is always verified.
+func RequireAPIToken(tokenService *domain.APITokenService, allowAnonymous bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			plaintext, ok := bearerToken(r)
+			if !ok {
+				if allowAnonymous {
+					next.ServeHTTP(w, r)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer realm="schema-score"`)
+				writeProblem(w, r, http.StatusUnauthorized, "API token required")
+				return
+			}
+
+			token, err := tokenService.Authenticate(r.Context(), plaintext)
+			if err != nil {
+				if errors.Is(err, domain.ErrUnauthorized) {
+					log.Printf("Rejected API token from %s: %v", r.RemoteAddr, err)
+					w.Header().Set("WWW-Authenticate", `Bearer realm="schema-score", error="invalid_token"`)
+					writeProblem(w, r, http.StatusUnauthorized, "Invalid API token")
+					return
+				}
+				writeError(w, r, "Error authenticating API token", err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), apiTokenContextKey, token)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireAdminToken protects admin endpoints like RequireAPIToken, and additionally rejects
+// tokens that were not created as admin tokens
+func RequireAdminToken(tokenService *domain.APITokenService, allowAnonymous bool) func(http.Handler) http.Handler {
+	requireAPIToken := RequireAPIToken(tokenService, allowAnonymous)
+	return func(next http.Handler) http.Handler {
+		return requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := APITokenFromContext(r.Context()); ok && !token.Admin {
+				writeProblem(w, r, http.StatusForbidden, "Admin endpoints require an admin API token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// allowSubgraph writes a 403 response and returns false when the request was authenticated
+// with a token that is scoped to other subgraphs
+func allowSubgraph(w http.ResponseWriter, r *http.Request, subgraphName string) bool {
+	token, authenticated := APITokenFromContext(r.Context())
+	if authenticated && !token.AllowsSubgraph(subgraphName) {
+		log.Printf("API token %s is not allowed for subgraph: %s", token.Prefix, subgraphName)
+		writeProblem(w, r, http.StatusForbidden, "API token not allowed for this subgraph")
+		return false
+	}
+	return true
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer" header
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRequireAPIToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		allowAnonymous bool
+		expectedStatus int
+		expectedToken  bool
+	}{
+		{
+			name:           "valid token",
+			authorization:  "Bearer ss_valid",
+			expectedStatus: http.StatusOK,
+			expectedToken:  true,
+		},
+		{
+			name:           "case insensitive scheme",
+			authorization:  "bearer ss_valid",
+			expectedStatus: http.StatusOK,
+			expectedToken:  true,
+		},
+		{
+			name:           "missing token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown token",
+			authorization:  "Bearer ss_unknown",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "basic auth is not a token",
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "anonymous allowed",
+			allowAnonymous: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid token with anonymous allowed",
+			authorization:  "Bearer ss_unknown",
+			allowAnonymous: true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenService := domain.NewAPITokenService(NewMockAPITokenRepository().WithToken("ss_valid"))
+
+			var sawToken bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, sawToken = APITokenFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest("POST", "/api/reports", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			RequireAPIToken(tokenService, tt.allowAnonymous)(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedToken, sawToken)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAPIHandler_ReceiveReport_WithAPIToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		subgraphName   *string
+		expectedStatus int
+	}{
+		{
+			name:           "subgraph in scope",
+			subgraphName:   stringPtr("user-service"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "subgraph out of scope",
+			subgraphName:   stringPtr("billing-service"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing subgraph",
+			subgraphName:   nil,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSchemaReportRepository()
+			handler := NewAPIHandler(domain.NewSchemaReportService(repo))
+			tokenService := domain.NewAPITokenService(NewMockAPITokenRepository().WithToken("ss_valid", "user-service"))
+
+			body, _ := json.Marshal(domain.IncomingReport{
+				Timestamp:    time.Now().Format(time.RFC3339),
+				SubgraphName: tt.subgraphName,
+				Score:        90,
+				TotalFields:  10,
+				RuleResults:  []domain.IncomingRuleResult{},
+			})
+
+			req := httptest.NewRequest("POST", "/api/reports", bytes.NewBuffer(body))
+			req.Header.Set("Authorization", "Bearer ss_valid")
+			w := httptest.NewRecorder()
+
+			RequireAPIToken(tokenService, false)(http.HandlerFunc(handler.ReceiveReport)).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "1", *repo.LastStore.APITokenID)
+			} else {
+				assert.Nil(t, repo.LastStore)
+			}
+		})
+	}
+}
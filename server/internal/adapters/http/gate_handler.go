@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+)
+
+// GateHandler handles HTTP API requests for quality gates
+type GateHandler struct {
+	gateService         *domain.GateService
+	schemaReportService *domain.SchemaReportService
+}
+
+// NewGateHandler creates a new quality gate handler
+func NewGateHandler(gateService *domain.GateService, schemaReportService *domain.SchemaReportService) *GateHandler {
+	return &GateHandler{
+		gateService:         gateService,
+		schemaReportService: schemaReportService,
+	}
+}
+
+// ListGates returns the gate configurations of all subgraphs
+func (h *GateHandler) ListGates(w http.ResponseWriter, r *http.Request) {
+	configs, err := h.gateService.ListConfigs(r.Context())
+	if err != nil {
+		writeError(w, r, "Error listing gate configs", err)
+		return
+	}
+
+	if configs == nil {
+		configs = []domain.GateConfig{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(configs)
+}
+
+// GetGate returns the gate configuration of a subgraph
+func (h *GateHandler) GetGate(w http.ResponseWriter, r *http.Request) {
+	subgraph := r.URL.Query().Get("subgraph")
+	if subgraph == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Subgraph name required")
+		return
+	}
+
+	config, err := h.gateService.GetConfig(r.Context(), subgraph)
+	if err != nil {
+		writeError(w, r, "Error getting gate config", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(config)
+}
+
+// SaveGate creates or replaces the gate configuration of a subgraph
+func (h *GateHandler) SaveGate(w http.ResponseWriter, r *http.Request) {
+	subgraph := r.URL.Query().Get("subgraph")
+	if subgraph == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Subgraph name required")
+		return
+	}
+
+	var incoming domain.IncomingGateConfig
+	if !decodeJSON(w, r, &incoming) {
+		return
+	}
+
+	config, err := h.gateService.SaveConfig(r.Context(), incoming.ToDomainEntity(subgraph))
+	if err != nil {
+		writeError(w, r, "Error saving gate config", err)
+		return
+	}
+
+	log.Printf("Saved quality gate for subgraph: %s", subgraph)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(config)
+}
+
+// DeleteGate removes the gate configuration of a subgraph
+func (h *GateHandler) DeleteGate(w http.ResponseWriter, r *http.Request) {
+	subgraph := r.URL.Query().Get("subgraph")
+	if subgraph == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Subgraph name required")
+		return
+	}
+
+	if err := h.gateService.DeleteConfig(r.Context(), subgraph); err != nil {
+		writeError(w, r, "Error deleting gate config", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EvaluateGate evaluates a report against the quality gate of its subgraph without storing it
+func (h *GateHandler) EvaluateGate(w http.ResponseWriter, r *http.Request) {
+	validator := h.schemaReportService.ReportValidator()
+	incoming, ok := decodeReport(w, r, validator)
+	if !ok {
+		return
+	}
+
+	report, ruleResults, err := validator.Convert(r.Context(), incoming)
+	if err != nil {
+		writeError(w, r, "Rejected invalid report", err)
+		return
+	}
+
+	prepared, err := h.schemaReportService.PrepareReport(
+		r.Context(),
+		&report.SubgraphName,
+		report.Score,
+		report.TotalFields,
+		report.TotalWeightedViolations,
+		report.Timestamp,
+		report.Metadata,
+		ruleResults,
+	)
+	if err != nil {
+		writeError(w, r, "Error preparing report", err)
+		return
+	}
+
+	result, err := h.gateService.Evaluate(r.Context(), prepared)
+	if err != nil {
+		writeError(w, r, "Error evaluating quality gate", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(gateResultResponse(result))
+}
+
+// gateResultResponse converts a gate verdict into its JSON response representation
+func gateResultResponse(result *domain.GateResult) map[string]interface{} {
+	failures := make([]map[string]interface{}, 0, len(result.Failures))
+	for _, failure := range result.Failures {
+		entry := map[string]interface{}{
+			"check":     failure.Check,
+			"threshold": failure.Threshold,
+			"actual":    failure.Actual,
+			"message":   failure.Message,
+		}
+		if failure.RuleName != "" {
+			entry["rule"] = failure.RuleName
+		}
+		failures = append(failures, entry)
+	}
+
+	return map[string]interface{}{
+		"gate":               result.Status,
+		"subgraph_name":      result.SubgraphName,
+		"score":              result.Score,
+		"baseline_report_id": result.BaselineReportID,
+		"baseline_score":     result.BaselineScore,
+		"failures":           failures,
+		"notes":              result.Notes,
+	}
+}
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestGateHandler() (*GateHandler, *MockGateRepository, *MockSchemaReportRepository) {
+	minScore := 80.0
+	gates := NewMockGateRepository().WithConfig(domain.GateConfig{
+		SubgraphName:      "user-service",
+		MinScore:          &minScore,
+		RuleMaxViolations: map[string]int{"PII": 0},
+	})
+	reports := NewMockSchemaReportRepository()
+	gateService := domain.NewGateService(gates, reports)
+
+	return NewGateHandler(gateService, domain.NewSchemaReportService(reports)), gates, reports
+}
+
+func TestGateHandler_EvaluateGate_TooLarge(t *testing.T) {
+	handler, _, _ := newTestGateHandler()
+	body := `{"timestamp": "2024-01-15T10:30:00Z", "subgraphName": "` + strings.Repeat("x", 100) + `"}`
+
+	req := httptest.NewRequest("POST", "/api/gates/evaluate", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	LimitRequestBody(64)(http.HandlerFunc(handler.EvaluateGate)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "Request body is larger than 64 bytes")
+}
+
+func TestGateHandler_EvaluateGate(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedGate     string
+		expectedFailures int
+	}{
+		{
+			name:           "passing report",
+			body:           `{"timestamp": "2024-01-15T10:30:00Z", "subgraphName": "user-service", "score": 92, "totalFields": 40, "ruleResults": []}`,
+			expectedStatus: http.StatusOK,
+			expectedGate:   domain.GateStatusPassed,
+		},
+		{
+			name:             "failing report",
+			body:             `{"timestamp": "2024-01-15T10:30:00Z", "subgraphName": "user-service", "score": 70, "totalFields": 40, "ruleResults": [{"rule": "PII", "message": "PII found", "violations": [{"message": "Field 'email' contains PII"}]}]}`,
+			expectedStatus:   http.StatusOK,
+			expectedGate:     domain.GateStatusFailed,
+			expectedFailures: 2,
+		},
+		{
+			name:           "subgraph without gate",
+			body:           `{"timestamp": "2024-01-15T10:30:00Z", "subgraphName": "order-service", "score": 10, "totalFields": 40, "ruleResults": []}`,
+			expectedStatus: http.StatusOK,
+			expectedGate:   domain.GateStatusSkipped,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid timestamp",
+			body:           `{"timestamp": "yesterday"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, reports := newTestGateHandler()
+
+			req := httptest.NewRequest("POST", "/api/gates/evaluate", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.EvaluateGate(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Empty(t, reports.Reports, "evaluation must not store the report")
+
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Equal(t, tt.expectedGate, response["gate"])
+				assert.Len(t, response["failures"], tt.expectedFailures)
+			}
+		})
+	}
+}
+
+func TestGateHandler_SaveGate(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "successful save",
+			queryParams:    "?subgraph=order-service",
+			body:           `{"minScore": 75, "maxScoreDrop": 2.5, "defaultBranch": "develop", "ruleMaxViolations": {"PII": 0}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing subgraph",
+			queryParams:    "",
+			body:           `{"minScore": 75}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			queryParams:    "?subgraph=order-service",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative threshold",
+			queryParams:    "?subgraph=order-service",
+			body:           `{"maxScoreDrop": -1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, gates, _ := newTestGateHandler()
+
+			req := httptest.NewRequest("PUT", "/api/gate"+tt.queryParams, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.SaveGate(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				saved := gates.Configs["order-service"]
+				assert.Equal(t, 75.0, *saved.MinScore)
+				assert.Equal(t, "develop", saved.DefaultBranch)
+				assert.Equal(t, 0, saved.RuleMaxViolations["PII"])
+			}
+		})
+	}
+}
+
+func TestGateHandler_GetGate(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+	}{
+		{name: "existing gate", queryParams: "?subgraph=user-service", expectedStatus: http.StatusOK},
+		{name: "unknown subgraph", queryParams: "?subgraph=order-service", expectedStatus: http.StatusNotFound},
+		{name: "missing subgraph", queryParams: "", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _ := newTestGateHandler()
+
+			req := httptest.NewRequest("GET", "/api/gate"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetGate(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestGateHandler_DeleteGate(t *testing.T) {
+	handler, gates, _ := newTestGateHandler()
+
+	req := httptest.NewRequest("DELETE", "/api/gate?subgraph=user-service", nil)
+	w := httptest.NewRecorder()
+	handler.DeleteGate(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, gates.Configs)
+
+	req = httptest.NewRequest("DELETE", "/api/gate?subgraph=user-service", nil)
+	w = httptest.NewRecorder()
+	handler.DeleteGate(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAPIHandler_ReceiveReport_WithGates(t *testing.T) {
+	minScore := 80.0
+	repo := NewMockSchemaReportRepository()
+	gates := NewMockGateRepository().WithConfig(domain.GateConfig{SubgraphName: "user-service", MinScore: &minScore})
+	service := domain.NewSchemaReportService(repo)
+	handler := NewAPIHandler(service, WithGates(domain.NewGateService(gates, repo)))
+
+	body, _ := json.Marshal(domain.IncomingReport{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		SubgraphName: stringPtr("user-service"),
+		Score:        60,
+		TotalFields:  42,
+		RuleResults:  []domain.IncomingRuleResult{},
+	})
+
+	req := httptest.NewRequest("POST", "/api/reports", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+
+	handler.ReceiveReport(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, repo.Reports, 1)
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, domain.GateStatusFailed, response["gate"])
+
+	gateResult := response["gate_result"].(map[string]interface{})
+	assert.Len(t, gateResult["failures"], 1)
+}
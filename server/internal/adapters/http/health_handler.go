@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+	"time"
+)
+
+// HealthHandler serves liveness and readiness probes
+type HealthHandler struct {
+	schemaReportService *domain.SchemaReportService
+	migrationVersion    func(ctx context.Context) (string, error)
+}
+
+// NewHealthHandler creates a new health handler. migrationVersion returns the latest
+// applied database migration and is optional.
+func NewHealthHandler(schemaReportService *domain.SchemaReportService, migrationVersion func(ctx context.Context) (string, error)) *HealthHandler {
+	return &HealthHandler{
+		schemaReportService: schemaReportService,
+		migrationVersion:    migrationVersion,
+	}
+}
+
+// Live reports that the process is up and serving requests. It does not touch the
+// database, so a database outage does not get the server restarted.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"status": "alive",
+		"time":   time.Now().Format(time.RFC3339),
+	})
+}
+
+// Ready reports whether the server can handle traffic: the database is reachable and
+// migrated. It responds with 503 and the failed checks otherwise. The probe is not
+// authenticated, so the causes of failed checks are logged rather than returned.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	ready := true
+
+	start := time.Now()
+	err := h.schemaReportService.HealthCheck(r.Context())
+	database := map[string]interface{}{
+		"status":     "ok",
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		log.Printf("Readiness check failed: database: %v", err)
+		database["status"] = "error"
+		ready = false
+	}
+	checks := map[string]interface{}{
+		"database": database,
+	}
+
+	if h.migrationVersion != nil {
+		migrations := map[string]interface{}{
+			"status": "ok",
+		}
+		version, err := h.migrationVersion(r.Context())
+		switch {
+		case err != nil:
+			log.Printf("Readiness check failed: migrations: %v", err)
+			migrations["status"] = "error"
+			ready = false
+		case version == "":
+			migrations["status"] = "error"
+			migrations["error"] = "no migrations applied"
+			ready = false
+		default:
+			migrations["version"] = version
+		}
+		checks["migrations"] = migrations
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not_ready", http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": status,
+		"time":   time.Now().Format(time.RFC3339),
+		"checks": checks,
+	})
+}
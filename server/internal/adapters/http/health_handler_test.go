@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHandler_Live(t *testing.T) {
+	// Liveness must not depend on the database
+	repo := NewMockSchemaReportRepository().WithHealthCheckFailure()
+	handler := NewHealthHandler(domain.NewSchemaReportService(repo), nil)
+
+	req := httptest.NewRequest("GET", "/api/health/live", nil)
+	w := httptest.NewRecorder()
+	handler.Live(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "alive", response["status"])
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name             string
+		failDatabase     bool
+		migrationVersion func(ctx context.Context) (string, error)
+		expectedStatus   int
+		expectedBody     string
+		expectedDatabase string
+		expectedMigrate  string
+	}{
+		{
+			name:             "ready",
+			migrationVersion: func(ctx context.Context) (string, error) { return "006_api_tokens", nil },
+			expectedStatus:   http.StatusOK,
+			expectedBody:     "ready",
+			expectedDatabase: "ok",
+			expectedMigrate:  "ok",
+		},
+		{
+			name:             "database unreachable",
+			failDatabase:     true,
+			migrationVersion: func(ctx context.Context) (string, error) { return "006_api_tokens", nil },
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedBody:     "not_ready",
+			expectedDatabase: "error",
+			expectedMigrate:  "ok",
+		},
+		{
+			name:             "migration version unavailable",
+			migrationVersion: func(ctx context.Context) (string, error) { return "", errors.New("relation does not exist") },
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedBody:     "not_ready",
+			expectedDatabase: "ok",
+			expectedMigrate:  "error",
+		},
+		{
+			name:             "no migrations applied",
+			migrationVersion: func(ctx context.Context) (string, error) { return "", nil },
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedBody:     "not_ready",
+			expectedDatabase: "ok",
+			expectedMigrate:  "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSchemaReportRepository()
+			repo.ShouldFailHealthCheck = tt.failDatabase
+			handler := NewHealthHandler(domain.NewSchemaReportService(repo), tt.migrationVersion)
+
+			req := httptest.NewRequest("GET", "/api/health/ready", nil)
+			w := httptest.NewRecorder()
+			handler.Ready(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response struct {
+				Status string `json:"status"`
+				Checks map[string]struct {
+					Status    string   `json:"status"`
+					Error     string   `json:"error"`
+					Version   string   `json:"version"`
+					LatencyMS *float64 `json:"latency_ms"`
+				} `json:"checks"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedBody, response.Status)
+			assert.Equal(t, tt.expectedDatabase, response.Checks["database"].Status)
+			assert.NotNil(t, response.Checks["database"].LatencyMS)
+			assert.Equal(t, tt.expectedMigrate, response.Checks["migrations"].Status)
+			if tt.expectedMigrate == "ok" {
+				assert.Equal(t, "006_api_tokens", response.Checks["migrations"].Version)
+			}
+
+			// Database errors are logged, never returned to the unauthenticated caller
+			assert.Empty(t, response.Checks["database"].Error)
+			assert.NotContains(t, w.Body.String(), "relation does not exist")
+			assert.NotContains(t, w.Body.String(), "mock health check error")
+		})
+	}
+}
+
+func TestHealthHandler_ReadyWithoutMigrations(t *testing.T) {
+	handler := NewHealthHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository()), nil)
+
+	req := httptest.NewRequest("GET", "/api/health/ready", nil)
+	w := httptest.NewRecorder()
+	handler.Ready(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "migrations")
+}
@@ -0,0 +1,337 @@
+package http
+
+import (
+	"bytes"
+	"database/sql"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// httpDurationBuckets are the upper bounds, in seconds, of the request latency histogram
+var httpDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// unmatchedRoute labels requests that did not match a registered route
+const unmatchedRoute = "unmatched"
+
+type requestKey struct {
+	method string
+	route  string
+	status int
+}
+
+type routeKey struct {
+	method string
+	route  string
+}
+
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// HTTPMetrics counts HTTP requests and records their latency per route
+type HTTPMetrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	durations map[routeKey]*histogram
+}
+
+// NewHTTPMetrics creates an empty set of HTTP metrics
+func NewHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[routeKey]*histogram),
+	}
+}
+
+// Handler wraps the router and records every request it handles. Requests are labelled
+// with the route template rather than the raw path to keep the number of series bounded.
+// It wraps the router instead of being one of its middlewares, because mux skips those
+// for requests that match no route.
+func (m *HTTPMetrics) Handler(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		route := unmatchedRoute
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if template, err := match.Route.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		router.ServeHTTP(recorder, r)
+
+		m.Observe(r.Method, route, recorder.status, time.Since(start))
+	})
+}
+
+// Observe records a single handled request
+func (m *HTTPMetrics) Observe(method, route string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method: method, route: route, status: status}]++
+
+	key := routeKey{method: method, route: route}
+	h, ok := m.durations[key]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(httpDurationBuckets))}
+		m.durations[key] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, bound := range httpDurationBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// write emits the request counters and latency histograms in a stable order
+func (m *HTTPMetrics) write(mw *metricWriter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, b := requestKeys[i], requestKeys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.status < b.status
+	})
+
+	mw.family("schema_score_http_requests_total", "Total number of HTTP requests handled.", "counter")
+	for _, key := range requestKeys {
+		mw.sample("schema_score_http_requests_total", float64(m.requests[key]),
+			"method", key.method, "route", key.route, "status", strconv.Itoa(key.status))
+	}
+
+	routeKeys := make([]routeKey, 0, len(m.durations))
+	for key := range m.durations {
+		routeKeys = append(routeKeys, key)
+	}
+	sort.Slice(routeKeys, func(i, j int) bool {
+		a, b := routeKeys[i], routeKeys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		return a.method < b.method
+	})
+
+	mw.family("schema_score_http_request_duration_seconds", "HTTP request latency in seconds.", "histogram")
+	for _, key := range routeKeys {
+		h := m.durations[key]
+		for i, bound := range httpDurationBuckets {
+			mw.sample("schema_score_http_request_duration_seconds_bucket", float64(h.buckets[i]),
+				"method", key.method, "route", key.route, "le", formatMetricValue(bound))
+		}
+		mw.sample("schema_score_http_request_duration_seconds_bucket", float64(h.count),
+			"method", key.method, "route", key.route, "le", "+Inf")
+		mw.sample("schema_score_http_request_duration_seconds_sum", h.sum,
+			"method", key.method, "route", key.route)
+		mw.sample("schema_score_http_request_duration_seconds_count", float64(h.count),
+			"method", key.method, "route", key.route)
+	}
+}
+
+// statusRecorder remembers the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// MetricsHandler serves schema score, HTTP and database pool metrics for Prometheus
+type MetricsHandler struct {
+	schemaReportService *domain.SchemaReportService
+	httpMetrics         *HTTPMetrics
+	dbStats             func() sql.DBStats
+	ingestionQueue      *domain.IngestionQueue
+	webhookService      *domain.WebhookService
+	now                 func() time.Time
+}
+
+// MetricsHandlerOption configures optional metrics of the metrics handler
+type MetricsHandlerOption func(*MetricsHandler)
+
+// WithIngestionQueueMetrics adds the depth and throughput of the ingestion queue
+func WithIngestionQueueMetrics(queue *domain.IngestionQueue) MetricsHandlerOption {
+	return func(h *MetricsHandler) {
+		h.ingestionQueue = queue
+	}
+}
+
+// WithWebhookMetrics adds the number of reports whose webhook notifications were lost
+func WithWebhookMetrics(webhookService *domain.WebhookService) MetricsHandlerOption {
+	return func(h *MetricsHandler) {
+		h.webhookService = webhookService
+	}
+}
+
+// NewMetricsHandler creates a new metrics handler. httpMetrics and dbStats are optional.
+func NewMetricsHandler(schemaReportService *domain.SchemaReportService, httpMetrics *HTTPMetrics, dbStats func() sql.DBStats, opts ...MetricsHandlerOption) *MetricsHandler {
+	h := &MetricsHandler{
+		schemaReportService: schemaReportService,
+		httpMetrics:         httpMetrics,
+		dbStats:             dbStats,
+		now:                 time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format
+func (h *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.schemaReportService.GetLatestReports(r.Context())
+	if err != nil {
+		log.Printf("Error collecting metrics: %v", err)
+		http.Error(w, "Failed to collect metrics", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	mw := &metricWriter{w: &buf}
+	writeSubgraphMetrics(mw, reports, h.now())
+	if h.httpMetrics != nil {
+		h.httpMetrics.write(mw)
+	}
+	if h.dbStats != nil {
+		writeDBStats(mw, h.dbStats())
+	}
+	if h.ingestionQueue != nil {
+		writeIngestionStats(mw, h.ingestionQueue.Stats())
+	}
+	if h.webhookService != nil {
+		mw.family("schema_score_webhook_enqueue_failures_total", "Total number of stored reports whose webhook notifications could not be queued.", "counter")
+		mw.sample("schema_score_webhook_enqueue_failures_total", float64(h.webhookService.EnqueueFailures()))
+	}
+
+	w.Header().Set("Content-Type", prometheusContentType)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// writeSubgraphMetrics emits gauges describing the latest report of every subgraph
+func writeSubgraphMetrics(mw *metricWriter, reports []domain.SchemaReport, now time.Time) {
+	sort.Slice(reports, func(i, j int) bool {
+		return metricSubgraphName(reports[i]) < metricSubgraphName(reports[j])
+	})
+
+	gauges := []struct {
+		name  string
+		help  string
+		value func(report domain.SchemaReport) float64
+	}{
+		{"schema_score_subgraph_score", "Score of the latest report of a subgraph.",
+			func(report domain.SchemaReport) float64 { return report.Score }},
+		{"schema_score_subgraph_effective_score", "Score of the latest report of a subgraph after suppressions.",
+			func(report domain.SchemaReport) float64 { return report.EffectiveScore }},
+		{"schema_score_subgraph_total_fields", "Number of fields in the latest schema of a subgraph.",
+			func(report domain.SchemaReport) float64 { return float64(report.TotalFields) }},
+		{"schema_score_subgraph_weighted_violations", "Total weighted violations in the latest report of a subgraph.",
+			func(report domain.SchemaReport) float64 { return report.TotalWeightedViolations }},
+		{"schema_score_subgraph_last_report_timestamp_seconds", "Unix time of the latest report of a subgraph.",
+			func(report domain.SchemaReport) float64 { return float64(report.Timestamp.Unix()) }},
+		{"schema_score_subgraph_seconds_since_last_report", "Seconds since the latest report of a subgraph.",
+			func(report domain.SchemaReport) float64 { return now.Sub(report.Timestamp).Seconds() }},
+	}
+
+	for _, gauge := range gauges {
+		mw.family(gauge.name, gauge.help, "gauge")
+		for _, report := range reports {
+			mw.sample(gauge.name, gauge.value(report), "subgraph", metricSubgraphName(report))
+		}
+	}
+
+	mw.family("schema_score_subgraph_rule_violations", "Violations per rule in the latest report of a subgraph.", "gauge")
+	for _, report := range reports {
+		ruleResults := append([]domain.RuleResult(nil), report.RuleResults...)
+		sort.Slice(ruleResults, func(i, j int) bool {
+			return ruleResults[i].RuleName < ruleResults[j].RuleName
+		})
+		for _, ruleResult := range ruleResults {
+			mw.sample("schema_score_subgraph_rule_violations", float64(ruleResult.ViolationCount),
+				"subgraph", metricSubgraphName(report), "rule", ruleResult.RuleName)
+		}
+	}
+}
+
+// writeDBStats emits the connection pool statistics of the database handle
+func writeDBStats(mw *metricWriter, stats sql.DBStats) {
+	metrics := []struct {
+		name  string
+		help  string
+		kind  string
+		value float64
+	}{
+		{"schema_score_db_max_open_connections", "Maximum number of open database connections.", "gauge", float64(stats.MaxOpenConnections)},
+		{"schema_score_db_open_connections", "Number of established database connections.", "gauge", float64(stats.OpenConnections)},
+		{"schema_score_db_in_use_connections", "Number of database connections currently in use.", "gauge", float64(stats.InUse)},
+		{"schema_score_db_idle_connections", "Number of idle database connections.", "gauge", float64(stats.Idle)},
+		{"schema_score_db_wait_count_total", "Total number of connections waited for.", "counter", float64(stats.WaitCount)},
+		{"schema_score_db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", "counter", stats.WaitDuration.Seconds()},
+		{"schema_score_db_max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns.", "counter", float64(stats.MaxIdleClosed)},
+		{"schema_score_db_max_idle_time_closed_total", "Total number of connections closed due to SetConnMaxIdleTime.", "counter", float64(stats.MaxIdleTimeClosed)},
+		{"schema_score_db_max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime.", "counter", float64(stats.MaxLifetimeClosed)},
+	}
+
+	for _, metric := range metrics {
+		mw.family(metric.name, metric.help, metric.kind)
+		mw.sample(metric.name, metric.value)
+	}
+}
+
+// writeIngestionStats emits the depth and throughput of the ingestion queue
+func writeIngestionStats(mw *metricWriter, stats domain.IngestionQueueStats) {
+	metrics := []struct {
+		name  string
+		help  string
+		kind  string
+		value float64
+	}{
+		{"schema_score_ingestion_queue_depth", "Number of reports waiting to be stored.", "gauge", float64(stats.Depth)},
+		{"schema_score_ingestion_queue_capacity", "Maximum number of reports that can wait to be stored.", "gauge", float64(stats.Capacity)},
+		{"schema_score_ingestion_workers", "Number of workers storing queued reports.", "gauge", float64(stats.Workers)},
+		{"schema_score_ingestion_in_progress", "Number of queued reports being stored.", "gauge", float64(stats.InProgress)},
+		{"schema_score_ingestion_stored_total", "Total number of queued reports that were stored.", "counter", float64(stats.Stored)},
+		{"schema_score_ingestion_failed_total", "Total number of queued reports that could not be stored.", "counter", float64(stats.Failed)},
+		{"schema_score_ingestion_rejected_total", "Total number of reports rejected because the queue was full.", "counter", float64(stats.Rejected)},
+	}
+
+	for _, metric := range metrics {
+		mw.family(metric.name, metric.help, metric.kind)
+		mw.sample(metric.name, metric.value)
+	}
+}
+
+// metricSubgraphName returns the subgraph label of a report, matching the dashboard's naming
+func metricSubgraphName(report domain.SchemaReport) string {
+	if report.SubgraphName == "" {
+		return "Unknown"
+	}
+	return report.SubgraphName
+}
@@ -0,0 +1,263 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHandler_SubgraphMetrics(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	reports := []domain.SchemaReport{
+		{
+			ID:                      "2",
+			SubgraphName:            "user-service",
+			Score:                   85.5,
+			EffectiveScore:          90,
+			TotalFields:             42,
+			TotalWeightedViolations: 10.25,
+			Timestamp:               now.Add(-90 * time.Second),
+			RuleResults: []domain.RuleResult{
+				{RuleName: "require-description", ViolationCount: 3},
+				{RuleName: "naming-convention", ViolationCount: 1},
+			},
+		},
+		{
+			ID:          "1",
+			Score:       70,
+			TotalFields: 7,
+			Timestamp:   now.Add(-time.Hour),
+		},
+	}
+
+	mockRepo := NewMockSchemaReportRepository().WithLatestReports(reports)
+	handler := NewMetricsHandler(domain.NewSchemaReportService(mockRepo), nil, nil)
+	handler.now = func() time.Time { return now }
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# HELP schema_score_subgraph_score Score of the latest report of a subgraph.\n"+
+		"# TYPE schema_score_subgraph_score gauge\n"+
+		"schema_score_subgraph_score{subgraph=\"Unknown\"} 70\n"+
+		"schema_score_subgraph_score{subgraph=\"user-service\"} 85.5\n")
+	assert.Contains(t, body, "schema_score_subgraph_effective_score{subgraph=\"user-service\"} 90\n")
+	assert.Contains(t, body, "schema_score_subgraph_total_fields{subgraph=\"user-service\"} 42\n")
+	assert.Contains(t, body, "schema_score_subgraph_weighted_violations{subgraph=\"user-service\"} 10.25\n")
+	assert.Contains(t, body, "schema_score_subgraph_seconds_since_last_report{subgraph=\"user-service\"} 90\n")
+	assert.Contains(t, body, "schema_score_subgraph_seconds_since_last_report{subgraph=\"Unknown\"} 3600\n")
+	assert.Contains(t, body, "schema_score_subgraph_last_report_timestamp_seconds{subgraph=\"user-service\"} 1.70929431e+09\n")
+	assert.Contains(t, body, "# TYPE schema_score_subgraph_rule_violations gauge\n"+
+		"schema_score_subgraph_rule_violations{subgraph=\"user-service\",rule=\"naming-convention\"} 1\n"+
+		"schema_score_subgraph_rule_violations{subgraph=\"user-service\",rule=\"require-description\"} 3\n")
+	assert.NotContains(t, body, "schema_score_http_")
+	assert.NotContains(t, body, "schema_score_db_")
+	assertValidExposition(t, body)
+}
+
+func TestMetricsHandler_ServiceFailure(t *testing.T) {
+	mockRepo := NewMockSchemaReportRepository()
+	mockRepo.ShouldFailGetLatestReports = true
+	handler := NewMetricsHandler(domain.NewSchemaReportService(mockRepo), nil, nil)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMetricsHandler_DBStats(t *testing.T) {
+	stats := sql.DBStats{
+		MaxOpenConnections: 25,
+		OpenConnections:    4,
+		InUse:              1,
+		Idle:               3,
+		WaitCount:          2,
+		WaitDuration:       1500 * time.Millisecond,
+		MaxLifetimeClosed:  6,
+	}
+	handler := NewMetricsHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository()), nil,
+		func() sql.DBStats { return stats })
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE schema_score_db_open_connections gauge\nschema_score_db_open_connections 4\n")
+	assert.Contains(t, body, "schema_score_db_max_open_connections 25\n")
+	assert.Contains(t, body, "schema_score_db_in_use_connections 1\n")
+	assert.Contains(t, body, "schema_score_db_idle_connections 3\n")
+	assert.Contains(t, body, "# TYPE schema_score_db_wait_count_total counter\nschema_score_db_wait_count_total 2\n")
+	assert.Contains(t, body, "schema_score_db_wait_duration_seconds_total 1.5\n")
+	assert.Contains(t, body, "schema_score_db_max_lifetime_closed_total 6\n")
+	assertValidExposition(t, body)
+}
+
+func TestMetricsHandler_IngestionQueue(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+	queue := domain.NewIngestionQueue(service, 1, 3)
+	subgraph := "user-service"
+	for i := 0; i < 2; i++ {
+		_, _ = queue.Enqueue(domain.ReportSubmission{Report: domain.NewSchemaReport("", &subgraph, 90, 10, 1, time.Now(), nil)})
+	}
+	handler := NewMetricsHandler(service, nil, nil, WithIngestionQueueMetrics(queue))
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE schema_score_ingestion_queue_depth gauge\nschema_score_ingestion_queue_depth 1\n")
+	assert.Contains(t, body, "schema_score_ingestion_queue_capacity 1\n")
+	assert.Contains(t, body, "schema_score_ingestion_workers 3\n")
+	assert.Contains(t, body, "# TYPE schema_score_ingestion_rejected_total counter\nschema_score_ingestion_rejected_total 1\n")
+	assertValidExposition(t, body)
+}
+
+func TestMetricsHandler_WebhookEnqueueFailures(t *testing.T) {
+	minScore := 80.0
+	repo := NewMockSchemaReportRepository()
+	webhooks := NewMockWebhookRepository().WithWebhook(domain.Webhook{URL: "https://hooks.example.com", Secret: "s3cret", MinScore: &minScore})
+	webhooks.ShouldFailEnqueue = true
+	webhookService := domain.NewWebhookService(webhooks, repo)
+	service := domain.NewSchemaReportService(repo, domain.WithWebhooks(webhookService))
+	subgraph := "user-service"
+	_, err := service.StoreReport(context.Background(), &subgraph, 60, 50, 20, time.Now(), nil, nil)
+	assert.NoError(t, err)
+	handler := NewMetricsHandler(service, nil, nil, WithWebhookMetrics(webhookService))
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE schema_score_webhook_enqueue_failures_total counter\nschema_score_webhook_enqueue_failures_total 1\n")
+	assertValidExposition(t, body)
+}
+
+func TestHTTPMetrics_Middleware(t *testing.T) {
+	httpMetrics := NewHTTPMetrics()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/report", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") == "" {
+			http.Error(w, "Report ID required", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}).Methods("GET")
+	handler := httpMetrics.Handler(router)
+
+	for _, target := range []string{"/api/report?id=1", "/api/report?id=2", "/api/report", "/missing/1", "/missing/2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/api/report", nil))
+
+	metricsHandler := NewMetricsHandler(domain.NewSchemaReportService(NewMockSchemaReportRepository()), httpMetrics, nil)
+	w := httptest.NewRecorder()
+	metricsHandler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE schema_score_http_requests_total counter\n"+
+		"schema_score_http_requests_total{method=\"GET\",route=\"/api/report\",status=\"200\"} 2\n"+
+		"schema_score_http_requests_total{method=\"GET\",route=\"/api/report\",status=\"400\"} 1\n")
+	// Requests that match no route share one series instead of being dropped
+	assert.Contains(t, body, "schema_score_http_requests_total{method=\"GET\",route=\"unmatched\",status=\"404\"} 2\n")
+	assert.Contains(t, body, "schema_score_http_requests_total{method=\"DELETE\",route=\"unmatched\",status=\"405\"} 1\n")
+	assert.NotContains(t, body, "/missing/")
+	assert.Contains(t, body, "# TYPE schema_score_http_request_duration_seconds histogram\n")
+	assert.Contains(t, body, "schema_score_http_request_duration_seconds_bucket{method=\"GET\",route=\"/api/report\",le=\"0.005\"} ")
+	assert.Contains(t, body, "schema_score_http_request_duration_seconds_bucket{method=\"GET\",route=\"/api/report\",le=\"+Inf\"} 3\n")
+	assert.Contains(t, body, "schema_score_http_request_duration_seconds_count{method=\"GET\",route=\"/api/report\"} 3\n")
+	assert.NotContains(t, body, "id=1", "raw paths must not become labels")
+	assertValidExposition(t, body)
+}
+
+func TestHTTPMetrics_HistogramBuckets(t *testing.T) {
+	httpMetrics := NewHTTPMetrics()
+	httpMetrics.Observe("POST", "/api/reports", http.StatusOK, 30*time.Millisecond)
+	httpMetrics.Observe("POST", "/api/reports", http.StatusOK, 3*time.Second)
+	httpMetrics.Observe("POST", "/api/reports", http.StatusOK, 20*time.Second)
+
+	var buf bytes.Buffer
+	httpMetrics.write(&metricWriter{w: &buf})
+	body := buf.String()
+
+	bucket := func(le string, count string) string {
+		return "schema_score_http_request_duration_seconds_bucket{method=\"POST\",route=\"/api/reports\",le=\"" + le + "\"} " + count + "\n"
+	}
+	assert.Contains(t, body, bucket("0.025", "0"))
+	assert.Contains(t, body, bucket("0.05", "1"))
+	assert.Contains(t, body, bucket("2.5", "1"))
+	assert.Contains(t, body, bucket("5", "2"))
+	assert.Contains(t, body, bucket("10", "2"))
+	assert.Contains(t, body, bucket("+Inf", "3"))
+	assert.Contains(t, body, "schema_score_http_request_duration_seconds_sum{method=\"POST\",route=\"/api/reports\"} 23.03\n")
+}
+
+func TestMetricWriter_Escaping(t *testing.T) {
+	var buf bytes.Buffer
+	mw := &metricWriter{w: &buf}
+	mw.family("test_metric", "Help with \\ and\nnewline.", "gauge")
+	mw.sample("test_metric", 1, "subgraph", "a\"b\\c\nd")
+
+	assert.Equal(t, "# HELP test_metric Help with \\\\ and\\nnewline.\n"+
+		"# TYPE test_metric gauge\n"+
+		"test_metric{subgraph=\"a\\\"b\\\\c\\nd\"} 1\n", buf.String())
+}
+
+func TestFormatMetricValue(t *testing.T) {
+	assert.Equal(t, "0", formatMetricValue(0))
+	assert.Equal(t, "85.5", formatMetricValue(85.5))
+	assert.Equal(t, "1e-06", formatMetricValue(0.000001))
+}
+
+// assertValidExposition checks that every line is a comment or a "name{labels} value" sample
+// and that every sample belongs to a family announced with a TYPE line
+func assertValidExposition(t *testing.T, body string) {
+	t.Helper()
+	assert.True(t, strings.HasSuffix(body, "\n"), "exposition must end with a newline")
+
+	declared := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\n"), "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			fields := strings.Fields(line)
+			if assert.Len(t, fields, 4, "malformed TYPE line %q", line) {
+				declared[fields[2]] = true
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "# HELP ") {
+			continue
+		}
+
+		name := line
+		if i := strings.IndexAny(line, "{ "); i >= 0 {
+			name = line[:i]
+		}
+		family := name
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			if trimmed := strings.TrimSuffix(name, suffix); trimmed != name && declared[trimmed] {
+				family = trimmed
+			}
+		}
+		assert.True(t, declared[family], "sample %q has no TYPE line", line)
+		assert.Greater(t, strings.LastIndex(line, " "), 0, "sample %q has no value", line)
+	}
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"strconv"
+	"time"
+)
+
+// MockAPITokenRepository is a mock implementation for testing
+type MockAPITokenRepository struct {
+	// Control behavior
+	ShouldFailGet bool
+
+	// Storage for test data
+	Tokens map[string]*domain.APIToken
+	nextID int
+}
+
+// NewMockAPITokenRepository creates a new mock API token repository
+func NewMockAPITokenRepository() *MockAPITokenRepository {
+	return &MockAPITokenRepository{
+		Tokens: make(map[string]*domain.APIToken),
+	}
+}
+
+// Store saves a token (mock implementation)
+func (m *MockAPITokenRepository) Store(ctx context.Context, token *domain.APIToken) error {
+	m.nextID++
+	token.ID = strconv.Itoa(m.nextID)
+	m.Tokens[token.ID] = token
+	return nil
+}
+
+// GetByHash retrieves a token by hash (mock implementation)
+func (m *MockAPITokenRepository) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	if m.ShouldFailGet {
+		return nil, errors.New("mock get API token error")
+	}
+
+	for _, token := range m.Tokens {
+		if token.Hash == hash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("API token: %w", domain.ErrAPITokenNotFound)
+}
+
+// List retrieves all tokens (mock implementation)
+func (m *MockAPITokenRepository) List(ctx context.Context) ([]domain.APIToken, error) {
+	tokens := []domain.APIToken{}
+	for i := 1; i <= m.nextID; i++ {
+		if token, exists := m.Tokens[strconv.Itoa(i)]; exists {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+// Revoke marks a token as revoked (mock implementation)
+func (m *MockAPITokenRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	token, exists := m.Tokens[id]
+	if !exists {
+		return fmt.Errorf("API token with ID %s: %w", id, domain.ErrAPITokenNotFound)
+	}
+
+	token.RevokedAt = &at
+	return nil
+}
+
+// TouchLastUsed records token usage (mock implementation)
+func (m *MockAPITokenRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	if token, exists := m.Tokens[id]; exists {
+		token.LastUsedAt = &at
+	}
+	return nil
+}
+
+// WithToken stores a token for the given plaintext value
+func (m *MockAPITokenRepository) WithToken(plaintext string, subgraphs ...string) *MockAPITokenRepository {
+	return m.withToken(plaintext, false, subgraphs)
+}
+
+// WithAdminToken stores an admin token for the given plaintext value
+func (m *MockAPITokenRepository) WithAdminToken(plaintext string) *MockAPITokenRepository {
+	return m.withToken(plaintext, true, nil)
+}
+
+func (m *MockAPITokenRepository) withToken(plaintext string, admin bool, subgraphs []string) *MockAPITokenRepository {
+	_ = m.Store(context.Background(), &domain.APIToken{
+		Name:      "test",
+		Prefix:    plaintext[:len(domain.APITokenPrefix)],
+		Hash:      domain.HashAPIToken(plaintext),
+		Subgraphs: subgraphs,
+		Admin:     admin,
+		CreatedAt: time.Now(),
+	})
+	return m
+}
@@ -1,13 +1,17 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"schema-score-server/internal/domain"
+	"sort"
 	"time"
 )
 
-// MockSchemaReportRepository is a mock implementation for testing
+// MockSchemaReportRepository is a mock implementation for testing. Every method fails
+// with the context error once the context is cancelled, like a database driver would.
 type MockSchemaReportRepository struct {
 	// Control behavior
 	ShouldFailStore                bool
@@ -15,6 +19,9 @@ type MockSchemaReportRepository struct {
 	ShouldFailGetRecentReports     bool
 	ShouldFailGetReportsBySubgraph bool
 	ShouldFailGetSubgraphSummaries bool
+	ShouldFailGetLatestReports     bool
+	ShouldFailGetReportsWithRules  bool
+	ShouldFailGetOpenViolations    bool
 	ShouldFailGetTotalReportCount  bool
 	ShouldFailHealthCheck          bool
 
@@ -26,6 +33,8 @@ type MockSchemaReportRepository struct {
 	RecentReports     []domain.SchemaReport
 	SubgraphReports   []domain.SchemaReport
 	SubgraphSummaries []domain.SubgraphSummary
+	LatestReports     []domain.SchemaReport
+	OpenViolations    []domain.TrackedViolation
 	TotalReportCount  int
 }
 
@@ -37,7 +46,11 @@ func NewMockSchemaReportRepository() *MockSchemaReportRepository {
 }
 
 // Store saves a schema report (mock implementation)
-func (m *MockSchemaReportRepository) Store(report *domain.SchemaReport) error {
+func (m *MockSchemaReportRepository) Store(ctx context.Context, report *domain.SchemaReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if m.ShouldFailStore {
 		return errors.New("mock store error")
 	}
@@ -54,21 +67,29 @@ func (m *MockSchemaReportRepository) Store(report *domain.SchemaReport) error {
 }
 
 // GetByID retrieves a schema report by ID (mock implementation)
-func (m *MockSchemaReportRepository) GetByID(id string) (*domain.SchemaReport, error) {
+func (m *MockSchemaReportRepository) GetByID(ctx context.Context, id string) (*domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if m.ShouldFailGetByID {
 		return nil, errors.New("mock get by id error")
 	}
 
 	report, exists := m.Reports[id]
 	if !exists {
-		return nil, errors.New("report not found")
+		return nil, fmt.Errorf("report with ID %s: %w", id, domain.ErrReportNotFound)
 	}
 
 	return report, nil
 }
 
 // GetRecentReports retrieves recent reports (mock implementation)
-func (m *MockSchemaReportRepository) GetRecentReports(limit int) ([]domain.SchemaReport, error) {
+func (m *MockSchemaReportRepository) GetRecentReports(ctx context.Context, limit int) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if m.ShouldFailGetRecentReports {
 		return nil, errors.New("mock get recent reports error")
 	}
@@ -85,24 +106,78 @@ func (m *MockSchemaReportRepository) GetRecentReports(limit int) ([]domain.Schem
 }
 
 // GetReportsBySubgraph retrieves reports for a subgraph (mock implementation)
-func (m *MockSchemaReportRepository) GetReportsBySubgraph(subgraphName string, limit int) ([]domain.SchemaReport, error) {
+func (m *MockSchemaReportRepository) GetReportsBySubgraph(ctx context.Context, subgraphName string, limit int) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if m.ShouldFailGetReportsBySubgraph {
 		return nil, errors.New("mock get reports by subgraph error")
 	}
 
-	// Return configured test data or empty slice
-	if m.SubgraphReports != nil {
-		if len(m.SubgraphReports) > limit {
-			return m.SubgraphReports[:limit], nil
+	// Return the configured reports of the requested subgraph, like the repositories do
+	reports := []domain.SchemaReport{}
+	for _, report := range m.SubgraphReports {
+		if report.SubgraphName == subgraphName && len(reports) < limit {
+			reports = append(reports, report)
 		}
-		return m.SubgraphReports, nil
 	}
 
-	return []domain.SchemaReport{}, nil
+	return reports, nil
+}
+
+// GetPreviousReport retrieves the latest stored report before a time (mock implementation)
+func (m *MockSchemaReportRepository) GetPreviousReport(ctx context.Context, subgraphName string, before time.Time) (*domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var latest *domain.SchemaReport
+	for _, report := range m.Reports {
+		if report.SubgraphName != subgraphName || !report.Timestamp.Before(before) {
+			continue
+		}
+		if latest == nil || report.Timestamp.After(latest.Timestamp) {
+			latest = report
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no report of %s: %w", subgraphName, domain.ErrReportNotFound)
+	}
+
+	return latest, nil
+}
+
+// GetLatestReportOnBranch retrieves the latest stored report on a branch (mock implementation)
+func (m *MockSchemaReportRepository) GetLatestReportOnBranch(ctx context.Context, subgraphName, branch string, before time.Time) (*domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var latest *domain.SchemaReport
+	for _, report := range m.Reports {
+		if !report.Timestamp.Before(before) || report.SubgraphName != subgraphName || report.Metadata[domain.BranchMetadataKey] != branch {
+			continue
+		}
+		if latest == nil || report.Timestamp.After(latest.Timestamp) {
+			latest = report
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no report on branch %s: %w", branch, domain.ErrReportNotFound)
+	}
+
+	return latest, nil
 }
 
 // GetSubgraphSummaries retrieves subgraph summaries (mock implementation)
-func (m *MockSchemaReportRepository) GetSubgraphSummaries() ([]domain.SubgraphSummary, error) {
+func (m *MockSchemaReportRepository) GetSubgraphSummaries(ctx context.Context) ([]domain.SubgraphSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if m.ShouldFailGetSubgraphSummaries {
 		return nil, errors.New("mock get subgraph summaries error")
 	}
@@ -115,8 +190,71 @@ func (m *MockSchemaReportRepository) GetSubgraphSummaries() ([]domain.SubgraphSu
 	return []domain.SubgraphSummary{}, nil
 }
 
+// GetLatestReports retrieves the latest report of every subgraph (mock implementation)
+func (m *MockSchemaReportRepository) GetLatestReports(ctx context.Context) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if m.ShouldFailGetLatestReports {
+		return nil, errors.New("mock get latest reports error")
+	}
+
+	// Return configured test data or empty slice
+	if m.LatestReports != nil {
+		return m.LatestReports, nil
+	}
+
+	return []domain.SchemaReport{}, nil
+}
+
+// GetReportsWithRuleResults retrieves the stored reports of a subgraph, oldest first (mock implementation)
+func (m *MockSchemaReportRepository) GetReportsWithRuleResults(ctx context.Context, subgraphName string) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if m.ShouldFailGetReportsWithRules {
+		return nil, errors.New("mock get reports with rule results error")
+	}
+
+	reports := []domain.SchemaReport{}
+	for _, report := range m.Reports {
+		if report.SubgraphName == subgraphName {
+			reports = append(reports, *report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Timestamp.Before(reports[j].Timestamp)
+	})
+
+	return reports, nil
+}
+
+// GetOpenViolations retrieves open tracked violations (mock implementation)
+func (m *MockSchemaReportRepository) GetOpenViolations(ctx context.Context, subgraphName string) ([]domain.TrackedViolation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if m.ShouldFailGetOpenViolations {
+		return nil, errors.New("mock get open violations error")
+	}
+
+	// Return configured test data or empty slice
+	if m.OpenViolations != nil {
+		return m.OpenViolations, nil
+	}
+
+	return []domain.TrackedViolation{}, nil
+}
+
 // GetTotalReportCount returns total report count (mock implementation)
-func (m *MockSchemaReportRepository) GetTotalReportCount() (int, error) {
+func (m *MockSchemaReportRepository) GetTotalReportCount(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
 	if m.ShouldFailGetTotalReportCount {
 		return 0, errors.New("mock get total report count error")
 	}
@@ -125,7 +263,11 @@ func (m *MockSchemaReportRepository) GetTotalReportCount() (int, error) {
 }
 
 // HealthCheck performs health check (mock implementation)
-func (m *MockSchemaReportRepository) HealthCheck() error {
+func (m *MockSchemaReportRepository) HealthCheck(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if m.ShouldFailHealthCheck {
 		return errors.New("mock health check error")
 	}
@@ -153,6 +295,18 @@ func (m *MockSchemaReportRepository) WithSubgraphSummaries(summaries []domain.Su
 	return m
 }
 
+// WithLatestReports configures the mock to return specific latest reports
+func (m *MockSchemaReportRepository) WithLatestReports(reports []domain.SchemaReport) *MockSchemaReportRepository {
+	m.LatestReports = reports
+	return m
+}
+
+// WithOpenViolations configures the mock to return specific open violations
+func (m *MockSchemaReportRepository) WithOpenViolations(violations []domain.TrackedViolation) *MockSchemaReportRepository {
+	m.OpenViolations = violations
+	return m
+}
+
 // WithTotalReportCount configures the mock to return a specific total count
 func (m *MockSchemaReportRepository) WithTotalReportCount(count int) *MockSchemaReportRepository {
 	m.TotalReportCount = count
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MockSuppressionRepository is a mock implementation for testing
+type MockSuppressionRepository struct {
+	// Control behavior
+	ShouldFailStore     bool
+	ShouldFailGetActive bool
+
+	// Storage for test data
+	Suppressions map[string]*domain.Suppression
+	nextID       int
+}
+
+// NewMockSuppressionRepository creates a new mock suppression repository
+func NewMockSuppressionRepository() *MockSuppressionRepository {
+	return &MockSuppressionRepository{
+		Suppressions: make(map[string]*domain.Suppression),
+	}
+}
+
+// Store saves a suppression (mock implementation)
+func (m *MockSuppressionRepository) Store(ctx context.Context, suppression *domain.Suppression) error {
+	if m.ShouldFailStore {
+		return errors.New("mock store error")
+	}
+
+	m.nextID++
+	suppression.ID = strconv.Itoa(m.nextID)
+	m.Suppressions[suppression.ID] = suppression
+
+	return nil
+}
+
+// GetByID retrieves a suppression by ID (mock implementation)
+func (m *MockSuppressionRepository) GetByID(ctx context.Context, id string) (*domain.Suppression, error) {
+	suppression, exists := m.Suppressions[id]
+	if !exists {
+		return nil, fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+	}
+
+	copied := *suppression
+	return &copied, nil
+}
+
+// List retrieves suppressions (mock implementation)
+func (m *MockSuppressionRepository) List(ctx context.Context, subgraphName string) ([]domain.Suppression, error) {
+	suppressions := []domain.Suppression{}
+	for _, suppression := range m.Suppressions {
+		if subgraphName == "" || suppression.SubgraphName == subgraphName {
+			suppressions = append(suppressions, *suppression)
+		}
+	}
+
+	sort.Slice(suppressions, func(i, j int) bool {
+		return suppressions[i].ID < suppressions[j].ID
+	})
+
+	return suppressions, nil
+}
+
+// Update saves changes to a suppression (mock implementation)
+func (m *MockSuppressionRepository) Update(ctx context.Context, suppression *domain.Suppression) error {
+	if _, exists := m.Suppressions[suppression.ID]; !exists {
+		return fmt.Errorf("suppression with ID %s: %w", suppression.ID, domain.ErrSuppressionNotFound)
+	}
+
+	m.Suppressions[suppression.ID] = suppression
+	return nil
+}
+
+// Delete removes a suppression (mock implementation)
+func (m *MockSuppressionRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.Suppressions[id]; !exists {
+		return fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+	}
+
+	delete(m.Suppressions, id)
+	return nil
+}
+
+// GetActive retrieves active suppressions (mock implementation)
+func (m *MockSuppressionRepository) GetActive(ctx context.Context, subgraphName string, at time.Time) ([]domain.Suppression, error) {
+	if m.ShouldFailGetActive {
+		return nil, errors.New("mock get active suppressions error")
+	}
+
+	suppressions := []domain.Suppression{}
+	for _, suppression := range m.Suppressions {
+		if suppression.SubgraphName == subgraphName && suppression.IsActive(at) {
+			suppressions = append(suppressions, *suppression)
+		}
+	}
+
+	return suppressions, nil
+}
+
+// WithSuppression adds a suppression to the mock storage
+func (m *MockSuppressionRepository) WithSuppression(suppression domain.Suppression) *MockSuppressionRepository {
+	_ = m.Store(context.Background(), &suppression)
+	return m
+}
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MockWebhookRepository is a mock implementation for testing
+type MockWebhookRepository struct {
+	// Control behavior
+	ShouldFailEnqueue bool
+	ShouldFailClaim   bool
+
+	// Storage for test data
+	Webhooks     map[string]*domain.Webhook
+	Deliveries   map[string]*domain.WebhookDelivery
+	nextID       int
+	nextDelivery int
+}
+
+// NewMockWebhookRepository creates a new mock webhook repository
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		Webhooks:   make(map[string]*domain.Webhook),
+		Deliveries: make(map[string]*domain.WebhookDelivery),
+	}
+}
+
+// StoreWebhook saves a webhook (mock implementation)
+func (m *MockWebhookRepository) StoreWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	m.nextID++
+	webhook.ID = strconv.Itoa(m.nextID)
+	m.Webhooks[webhook.ID] = webhook
+	return nil
+}
+
+// GetWebhook retrieves a webhook by ID (mock implementation)
+func (m *MockWebhookRepository) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	webhook, exists := m.Webhooks[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook with ID %s: %w", id, domain.ErrWebhookNotFound)
+	}
+
+	copied := *webhook
+	return &copied, nil
+}
+
+// ListWebhooks retrieves all webhooks (mock implementation)
+func (m *MockWebhookRepository) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return m.ListWebhooksForSubgraph(ctx, "")
+}
+
+// ListWebhooksForSubgraph retrieves global and subgraph webhooks (mock implementation)
+func (m *MockWebhookRepository) ListWebhooksForSubgraph(ctx context.Context, subgraphName string) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+	for _, webhook := range m.Webhooks {
+		if subgraphName == "" || webhook.AppliesTo(subgraphName) {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries (mock implementation)
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	if _, exists := m.Webhooks[id]; !exists {
+		return fmt.Errorf("webhook with ID %s: %w", id, domain.ErrWebhookNotFound)
+	}
+
+	delete(m.Webhooks, id)
+	for deliveryID, delivery := range m.Deliveries {
+		if delivery.WebhookID == id {
+			delete(m.Deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// EnqueueDeliveries adds deliveries to the outbox (mock implementation)
+func (m *MockWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	if m.ShouldFailEnqueue {
+		return errors.New("mock enqueue error")
+	}
+
+	for i := range deliveries {
+		m.nextDelivery++
+		deliveries[i].ID = strconv.Itoa(m.nextDelivery)
+		delivery := deliveries[i]
+		m.Deliveries[delivery.ID] = &delivery
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns due pending deliveries (mock implementation)
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	if m.ShouldFailClaim {
+		return nil, errors.New("mock claim error")
+	}
+
+	deliveries := []domain.WebhookDelivery{}
+	for _, delivery := range m.Deliveries {
+		if delivery.Status == domain.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = now.Add(lease)
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// SaveDeliveryAttempt records an attempt (mock implementation)
+func (m *MockWebhookRepository) SaveDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery, attempt domain.DeliveryAttempt) error {
+	if _, exists := m.Deliveries[delivery.ID]; !exists {
+		return fmt.Errorf("webhook delivery with ID %s: %w", delivery.ID, domain.ErrWebhookDeliveryNotFound)
+	}
+
+	copied := *delivery
+	m.Deliveries[delivery.ID] = &copied
+	return nil
+}
+
+// GetDelivery retrieves a delivery by ID (mock implementation)
+func (m *MockWebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	delivery, exists := m.Deliveries[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook delivery with ID %s: %w", id, domain.ErrWebhookDeliveryNotFound)
+	}
+
+	copied := *delivery
+	return &copied, nil
+}
+
+// ListDeliveries retrieves deliveries (mock implementation)
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+	for _, delivery := range m.Deliveries {
+		if (webhookID == "" || delivery.WebhookID == webhookID) && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// RetryDelivery makes a delivery pending again (mock implementation)
+func (m *MockWebhookRepository) RetryDelivery(ctx context.Context, id string, at time.Time) error {
+	delivery, exists := m.Deliveries[id]
+	if !exists {
+		return fmt.Errorf("webhook delivery with ID %s: %w", id, domain.ErrWebhookDeliveryNotFound)
+	}
+
+	delivery.Status = domain.DeliveryStatusPending
+	delivery.NextAttemptAt = at
+	return nil
+}
+
+// WithWebhook adds a webhook to the mock storage
+func (m *MockWebhookRepository) WithWebhook(webhook domain.Webhook) *MockWebhookRepository {
+	_ = m.StoreWebhook(context.Background(), &webhook)
+	return m
+}
+
+// WithDelivery adds a delivery to the mock outbox
+func (m *MockWebhookRepository) WithDelivery(delivery domain.WebhookDelivery) *MockWebhookRepository {
+	_ = m.EnqueueDeliveries(context.Background(), []domain.WebhookDelivery{delivery})
+	return m
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix starts the type URIs of the problems this API defines. Problems that
+// need no explanation beyond their status code have the type "about:blank".
+const problemTypePrefix = "urn:schema-score:problem:"
+
+// Problem is an RFC 7807 problem details response body
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// InvalidParams lists the fields of the request body that failed validation
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam is a field of the request body that failed validation
+type InvalidParam struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// errorProblem describes the problem returned for a domain error
+type errorProblem struct {
+	err    error
+	status int
+	name   string
+	title  string
+}
+
+// errorProblems maps domain errors to HTTP status codes, first match wins. Errors that
+// match none of them are internal server errors, whose details are only logged.
+var errorProblems = []errorProblem{
+	{domain.ErrInvalidReport, http.StatusBadRequest, "invalid-report", "Invalid report"},
+	{domain.ErrInvalidSuppression, http.StatusBadRequest, "invalid-suppression", "Invalid suppression"},
+	{domain.ErrInvalidGateConfig, http.StatusBadRequest, "invalid-gate-config", "Invalid gate config"},
+	{domain.ErrInvalidWebhook, http.StatusBadRequest, "invalid-webhook", "Invalid webhook"},
+	{domain.ErrInvalidRule, http.StatusBadRequest, "invalid-rule", "Invalid rule"},
+	{domain.ErrInvalidAPIToken, http.StatusBadRequest, "invalid-api-token", "Invalid API token"},
+	{domain.ErrUnauthorized, http.StatusUnauthorized, "unauthorized", "Unauthorized"},
+	{domain.ErrReportNotFound, http.StatusNotFound, "report-not-found", "Report not found"},
+	{domain.ErrSuppressionNotFound, http.StatusNotFound, "suppression-not-found", "Suppression not found"},
+	{domain.ErrGateConfigNotFound, http.StatusNotFound, "gate-config-not-found", "Gate config not found"},
+	{domain.ErrWebhookNotFound, http.StatusNotFound, "webhook-not-found", "Webhook not found"},
+	{domain.ErrWebhookDeliveryNotFound, http.StatusNotFound, "webhook-delivery-not-found", "Webhook delivery not found"},
+	{domain.ErrRuleNotFound, http.StatusNotFound, "rule-not-found", "Rule not found"},
+	{domain.ErrRulesetVersionNotFound, http.StatusNotFound, "ruleset-version-not-found", "Ruleset version not found"},
+	{domain.ErrAPITokenNotFound, http.StatusNotFound, "api-token-not-found", "API token not found"},
+	{domain.ErrIngestionTicketNotFound, http.StatusNotFound, "ticket-not-found", "Ticket not found"},
+	{domain.ErrRuleExists, http.StatusConflict, "rule-exists", "Rule already exists"},
+	{domain.ErrIngestionQueueFull, http.StatusServiceUnavailable, "ingestion-queue-full", "Ingestion queue is full"},
+	{domain.ErrIngestionQueueClosed, http.StatusServiceUnavailable, "ingestion-stopped", "Not accepting reports"},
+}
+
+// writeError logs err and writes the problem of the domain error it wraps
+func writeError(w http.ResponseWriter, r *http.Request, logMessage string, err error) {
+	log.Printf("%s: %v (request %s)", logMessage, err, RequestIDFromContext(r.Context()))
+
+	for _, mapping := range errorProblems {
+		if !errors.Is(err, mapping.err) {
+			continue
+		}
+
+		problem := Problem{
+			Type:   problemTypePrefix + mapping.name,
+			Title:  mapping.title,
+			Status: mapping.status,
+			Detail: problemDetail(err, mapping.err),
+		}
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			for _, field := range validationErr.Fields {
+				problem.InvalidParams = append(problem.InvalidParams, InvalidParam{Path: field.Path, Reason: field.Reason})
+			}
+		}
+		writeProblemResponse(w, r, problem)
+		return
+	}
+
+	writeProblem(w, r, http.StatusInternalServerError, "")
+}
+
+// writeProblem writes a problem that needs no explanation beyond its status code and detail
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	writeProblemResponse(w, r, Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+// writeProblemResponse completes a problem with the request and writes it
+func writeProblemResponse(w http.ResponseWriter, r *http.Request, problem Problem) {
+	problem.Instance = r.URL.RequestURI()
+	problem.RequestID = RequestIDFromContext(r.Context())
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// problemDetail returns the message of the error that wraps target directly. It describes
+// the problem without the context added by the layers above, which only matters in logs.
+func problemDetail(err, target error) string {
+	for err != nil {
+		next := errors.Unwrap(err)
+		if next == target {
+			return err.Error()
+		}
+		err = next
+	}
+	return ""
+}
+
+// decodeReport reads an incoming report of any supported schema version and key style,
+// writing a problem and returning false when the body cannot be read or decoded. Fields
+// the report format does not define are rejected when the validator is strict.
+func decodeReport(w http.ResponseWriter, r *http.Request, validator *domain.ReportValidator) (*domain.IncomingReport, bool) {
+	var payload json.RawMessage
+	if !decodeJSON(w, r, &payload) {
+		return nil, false
+	}
+
+	incoming, err := validator.Decode(payload)
+	if err != nil {
+		writeError(w, r, "Rejected invalid report", err)
+		return nil, false
+	}
+	return incoming, true
+}
+
+// decodeJSON decodes the request body into v. When that fails it writes a problem and
+// returns false: 413 for bodies over the http.MaxBytesReader limit, and 400 otherwise,
+// naming the field when a value has the wrong type.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+
+	log.Printf("Error decoding request body: %v (request %s)", err, RequestIDFromContext(r.Context()))
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeProblem(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body is larger than %d bytes", tooLarge.Limit))
+	} else if field, ok := domain.JSONFieldError(err); ok {
+		writeProblemResponse(w, r, Problem{
+			Type:          "about:blank",
+			Title:         http.StatusText(http.StatusBadRequest),
+			Status:        http.StatusBadRequest,
+			Detail:        "Invalid JSON",
+			InvalidParams: []InvalidParam{{Path: field.Path, Reason: field.Reason}},
+		})
+	} else {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid JSON")
+	}
+	return false
+}
+
+// NotFound answers requests for unknown API routes with a problem. The router also sends
+// requests with a method that a route does not support here.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusNotFound, "")
+}
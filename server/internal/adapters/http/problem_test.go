@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedType   string
+		expectedDetail string
+		expectedParams []InvalidParam
+	}{
+		{
+			name:           "not found",
+			err:            fmt.Errorf("failed to get webhook: %w", fmt.Errorf("webhook with ID 7: %w", domain.ErrWebhookNotFound)),
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "urn:schema-score:problem:webhook-not-found",
+			expectedDetail: "webhook with ID 7: webhook not found",
+		},
+		{
+			name:           "invalid input",
+			err:            domain.Suppression{}.Validate(),
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "urn:schema-score:problem:invalid-suppression",
+			expectedDetail: "invalid suppression: subgraph name is required, rule name is required, reason is required, author is required",
+		},
+		{
+			name: "validation error",
+			err: domain.NewValidationError(domain.ErrInvalidReport,
+				domain.FieldError{Path: "ruleResults[1].rule", Reason: "is required"}),
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "urn:schema-score:problem:invalid-report",
+			expectedDetail: "invalid report: ruleResults[1].rule is required",
+			expectedParams: []InvalidParam{{Path: "ruleResults[1].rule", Reason: "is required"}},
+		},
+		{
+			name:           "bare sentinel",
+			err:            domain.ErrIngestionQueueFull,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedType:   "urn:schema-score:problem:ingestion-queue-full",
+		},
+		{
+			name:           "internal error",
+			err:            fmt.Errorf("failed to store schema report: %w", errors.New("connection refused")),
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   "about:blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/test", nil)
+			w := httptest.NewRecorder()
+			writeError(w, req, "Error testing", tt.err)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+			var problem Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, tt.expectedType, problem.Type)
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.NotEmpty(t, problem.Title)
+			assert.Equal(t, tt.expectedDetail, problem.Detail)
+			assert.Equal(t, tt.expectedParams, problem.InvalidParams)
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedParams []InvalidParam
+	}{
+		{
+			name:           "syntax error",
+			body:           `{"url": `,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong type",
+			body:           `{"minScore": "high"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedParams: []InvalidParam{{Path: "minScore", Reason: "must be a number"}},
+		},
+		{
+			name:           "too large",
+			body:           `{"url": "https://example.com/a/very/long/path"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/webhooks", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			req.Body = http.MaxBytesReader(w, req.Body, 32)
+
+			var incoming domain.IncomingWebhook
+			assert.False(t, decodeJSON(w, req, &incoming))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var problem Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, tt.expectedParams, problem.InvalidParams)
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/unknown", nil)
+	w := httptest.NewRecorder()
+	NotFound(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// prometheusContentType is the content type of the Prometheus text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricWriter writes metric families in the Prometheus text exposition format.
+// The first write error is kept and all later writes are skipped.
+type metricWriter struct {
+	w   io.Writer
+	err error
+}
+
+// family writes the HELP and TYPE lines that introduce a metric family
+func (m *metricWriter) family(name, help, metricType string) {
+	m.printf("# HELP %s %s\n", name, escapeHelp(help))
+	m.printf("# TYPE %s %s\n", name, metricType)
+}
+
+// sample writes one sample line. Labels are given as alternating name and value pairs.
+func (m *metricWriter) sample(name string, value float64, labels ...string) {
+	if len(labels) == 0 {
+		m.printf("%s %s\n", name, formatMetricValue(value))
+		return
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], escapeLabelValue(labels[i+1])))
+	}
+	m.printf("%s{%s} %s\n", name, strings.Join(pairs, ","), formatMetricValue(value))
+}
+
+func (m *metricWriter) printf(format string, args ...interface{}) {
+	if m.err != nil {
+		return
+	}
+	_, m.err = fmt.Fprintf(m.w, format, args...)
+}
+
+// formatMetricValue formats a sample value, spelling out infinities and NaN as Prometheus expects
+func formatMetricValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeHelp escapes backslashes and line feeds in HELP text
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+// escapeLabelValue escapes backslashes, double quotes and line feeds in label values
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
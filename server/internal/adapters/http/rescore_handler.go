@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"schema-score-server/internal/domain"
+)
+
+// RescoreHandler handles HTTP admin requests for rescoring stored reports
+type RescoreHandler struct {
+	rescoreService *domain.RescoreService
+}
+
+// NewRescoreHandler creates a new rescore handler
+func NewRescoreHandler(rescoreService *domain.RescoreService) *RescoreHandler {
+	return &RescoreHandler{
+		rescoreService: rescoreService,
+	}
+}
+
+// Rescore recomputes the scores of stored reports with a ruleset version. The request body
+// is optional and rescores every subgraph with the current rule catalog when left out.
+func (h *RescoreHandler) Rescore(w http.ResponseWriter, r *http.Request) {
+	var incoming domain.IncomingRescore
+	if r.ContentLength != 0 && !decodeJSON(w, r, &incoming) {
+		return
+	}
+
+	result, err := h.rescoreService.Rescore(r.Context(), incoming.Version, incoming.Subgraph)
+	if err != nil {
+		writeError(w, r, "Error rescoring reports", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// ListRulesetVersions returns the ruleset versions reports were rescored with
+func (h *RescoreHandler) ListRulesetVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.rescoreService.ListRulesetVersions(r.Context())
+	if err != nil {
+		writeError(w, r, "Error listing ruleset versions", err)
+		return
+	}
+
+	if versions == nil {
+		versions = []domain.RulesetVersion{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(versions)
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/adapters/memory"
+	"schema-score-server/internal/domain"
+	"schema-score-server/internal/web"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRescoreService(t *testing.T) (*domain.RescoreService, domain.RescoreRepository) {
+	reports := NewMockSchemaReportRepository()
+	reports.SubgraphSummaries = []domain.SubgraphSummary{{Name: "user-service"}}
+	reports.Reports["report-1"] = &domain.SchemaReport{
+		ID:           "report-1",
+		SubgraphName: "user-service",
+		Score:        90,
+		TotalFields:  100,
+		Timestamp:    time.Now(),
+		RuleResults:  []domain.RuleResult{{RuleName: "PII", ViolationCount: 1, Weight: 10}},
+	}
+	reports.SubgraphReports = []domain.SchemaReport{*reports.Reports["report-1"]}
+
+	rules := memory.NewMemoryRuleRepository()
+	if err := rules.CreateRule(context.Background(), &domain.Rule{Name: "PII", Weight: 25, Category: "important"}); err != nil {
+		t.Fatalf("failed to create rule: %v", err)
+	}
+	rescores := memory.NewMemoryRescoreRepository()
+	return domain.NewRescoreService(reports, rules, rescores), rescores
+}
+
+func TestRescoreHandler_Rescore(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedScores int
+	}{
+		{
+			name:           "current weights without a body",
+			expectedStatus: http.StatusOK,
+			expectedScores: 1,
+		},
+		{
+			name:           "one subgraph",
+			body:           `{"version": "current", "subgraph": "user-service"}`,
+			expectedStatus: http.StatusOK,
+			expectedScores: 1,
+		},
+		{
+			name:           "unknown version",
+			body:           `{"version": "0123456789ab"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, rescores := newTestRescoreService(t)
+			handler := NewRescoreHandler(service)
+
+			req := httptest.NewRequest("POST", "/api/admin/rescore", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			handler.Rescore(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var result domain.RescoreResult
+				if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Equal(t, 1, result.Rescored)
+				assert.NotEmpty(t, result.RulesetVersion)
+
+				scores, err := rescores.GetRescoredScores(context.Background(), "user-service", result.RulesetVersion)
+				assert.NoError(t, err)
+				if assert.Len(t, scores, tt.expectedScores) {
+					assert.InDelta(t, 75, scores[0].Score, 0.001)
+				}
+			}
+		})
+	}
+}
+
+func TestRescoreHandler_ListRulesetVersions(t *testing.T) {
+	service, _ := newTestRescoreService(t)
+	handler := NewRescoreHandler(service)
+
+	w := httptest.NewRecorder()
+	handler.ListRulesetVersions(w, httptest.NewRequest("GET", "/api/admin/ruleset-versions", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+
+	handler.Rescore(httptest.NewRecorder(), httptest.NewRequest("POST", "/api/admin/rescore", nil))
+
+	w = httptest.NewRecorder()
+	handler.ListRulesetVersions(w, httptest.NewRequest("GET", "/api/admin/ruleset-versions", nil))
+	var versions []domain.RulesetVersion
+	if err := json.NewDecoder(w.Body).Decode(&versions); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, 25.0, versions[0].Weights["PII"])
+	}
+}
+
+func TestRequireAdminToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		allowAnonymous bool
+		expectedStatus int
+	}{
+		{
+			name:           "admin token",
+			authorization:  "Bearer ss_admin",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "token for all subgraphs",
+			authorization:  "Bearer ss_unscoped",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "token scoped to a subgraph",
+			authorization:  "Bearer ss_scoped",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "anonymous allowed",
+			allowAnonymous: true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenService := domain.NewAPITokenService(NewMockAPITokenRepository().
+				WithAdminToken("ss_admin").
+				WithToken("ss_unscoped").
+				WithToken("ss_scoped", "user-service"))
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+			req := httptest.NewRequest("POST", "/api/admin/rescore", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			RequireAdminToken(tokenService, tt.allowAnonymous)(next).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestWebHandler_SubgraphHistory_Rescored(t *testing.T) {
+	rescoreService, rescores := newTestRescoreService(t)
+	reports := NewMockSchemaReportRepository()
+	reports.SubgraphReports = []domain.SchemaReport{{ID: "report-1", SubgraphName: "user-service", Score: 90, TotalFields: 100, Timestamp: time.Now()}}
+	service := domain.NewSchemaReportService(reports)
+
+	handler, err := NewWebHandler(service, web.Templates(false), WithRescoredHistory(rescoreService))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.SubgraphHistory(w, httptest.NewRequest("GET", "/subgraph?name=user-service", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Rescored with current weights")
+	assert.Contains(t, w.Body.String(), "0 of 1 reports are rescored")
+
+	// Until the current weights are rescored the chart shows an earlier version
+	earlier := domain.NewRulesetVersion([]domain.Rule{{Name: "PII", Weight: 5}}, time.Now())
+	assert.NoError(t, rescores.SaveRulesetVersion(context.Background(), earlier))
+	_, err = rescoreService.Rescore(context.Background(), earlier.Version, "")
+	assert.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	handler.SubgraphHistory(w, httptest.NewRequest("GET", "/subgraph?name=user-service", nil))
+	assert.Contains(t, w.Body.String(), "Rescored with earlier weights")
+	assert.Contains(t, w.Body.String(), earlier.Version)
+	assert.Contains(t, w.Body.String(), `"report-1":95`)
+
+	_, err = rescoreService.Rescore(context.Background(), "", "")
+	assert.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	handler.SubgraphHistory(w, httptest.NewRequest("GET", "/subgraph?name=user-service", nil))
+	assert.Contains(t, w.Body.String(), "Rescored with current weights")
+	assert.Contains(t, w.Body.String(), "1 of 1 reports are rescored")
+	assert.Contains(t, w.Body.String(), `"report-1":75`)
+}
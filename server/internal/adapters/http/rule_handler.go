@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"schema-score-server/internal/domain"
+
+	"github.com/gorilla/mux"
+)
+
+// RuleHandler handles HTTP API requests for the rule catalog
+type RuleHandler struct {
+	ruleService *domain.RuleCatalogService
+}
+
+// NewRuleHandler creates a new rule catalog handler
+func NewRuleHandler(ruleService *domain.RuleCatalogService) *RuleHandler {
+	return &RuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+// ListRules returns all rules of the catalog
+func (h *RuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.ruleService.ListRules(r.Context())
+	if err != nil {
+		writeError(w, r, "Error listing rules", err)
+		return
+	}
+
+	if rules == nil {
+		rules = []domain.Rule{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(rules)
+}
+
+// CreateRule adds a rule to the catalog
+func (h *RuleHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var incoming domain.IncomingRule
+	if !decodeJSON(w, r, &incoming) {
+		return
+	}
+
+	created, err := h.ruleService.CreateRule(r.Context(), incoming.ToDomainEntity(incoming.Name))
+	if err != nil {
+		writeError(w, r, "Error creating rule", err)
+		return
+	}
+
+	log.Printf("Created rule: %s, weight: %.2f, category: %s", created.Name, created.Weight, created.Category)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/rules/"+url.PathEscape(created.Name))
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(created)
+}
+
+// GetRule returns a single rule
+func (h *RuleHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.ruleService.GetRule(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		writeError(w, r, "Error getting rule", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(rule)
+}
+
+// UpdateRule replaces a rule of the catalog, which marks it as reviewed
+func (h *RuleHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	var incoming domain.IncomingRule
+	if !decodeJSON(w, r, &incoming) {
+		return
+	}
+
+	updated, err := h.ruleService.UpdateRule(r.Context(), name, incoming.ToDomainEntity(name))
+	if err != nil {
+		writeError(w, r, "Error updating rule", err)
+		return
+	}
+
+	log.Printf("Updated rule: %s, weight: %.2f, category: %s", updated.Name, updated.Weight, updated.Category)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+// DeleteRule removes a rule from the catalog
+func (h *RuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.ruleService.DeleteRule(r.Context(), mux.Vars(r)["name"]); err != nil {
+		writeError(w, r, "Error deleting rule", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
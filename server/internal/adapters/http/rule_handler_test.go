@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/adapters/memory"
+	"schema-score-server/internal/domain"
+	"schema-score-server/internal/web"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRuleHandler(t *testing.T) (*RuleHandler, domain.RuleRepository) {
+	repo := memory.NewMemoryRuleRepository()
+	for _, rule := range []domain.Rule{
+		{
+			Name:        "PII",
+			Weight:      10,
+			Category:    "critical",
+			Description: "Fields exposing personal data must be marked",
+			FixExamples: []domain.RuleExample{},
+		},
+		{
+			Name:        "Custom Rule",
+			Weight:      2,
+			Category:    "uncategorized",
+			NeedsReview: true,
+			FixExamples: []domain.RuleExample{},
+		},
+	} {
+		rule := rule
+		if err := repo.CreateRule(context.Background(), &rule); err != nil {
+			t.Fatalf("failed to create rule: %v", err)
+		}
+	}
+	return NewRuleHandler(domain.NewRuleCatalogService(repo)), repo
+}
+
+func TestRuleHandler_ListRules(t *testing.T) {
+	handler, _ := newTestRuleHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.ListRules(w, httptest.NewRequest("GET", "/api/rules", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var rules []domain.Rule
+	if err := json.NewDecoder(w.Body).Decode(&rules); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "Custom Rule", rules[0].Name)
+	assert.True(t, rules[0].NeedsReview)
+}
+
+func TestRuleHandler_CreateRule(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		invalidParam   string
+	}{
+		{
+			name:           "successful create",
+			body:           `{"name": "Deprecation Reason", "weight": 3, "category": "Important", "description": "Deprecated fields need a reason", "fixExamples": [{"before": "old: String @deprecated", "after": "old: String @deprecated(reason: \"Use new\")"}]}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown category",
+			body:           `{"name": "Deprecation Reason", "weight": 3, "category": "cosmetic"}`,
+			expectedStatus: http.StatusBadRequest,
+			invalidParam:   "category",
+		},
+		{
+			name:           "negative weight",
+			body:           `{"name": "Deprecation Reason", "weight": -1, "category": "style"}`,
+			expectedStatus: http.StatusBadRequest,
+			invalidParam:   "weight",
+		},
+		{
+			name:           "already exists",
+			body:           `{"name": "PII", "weight": 10, "category": "critical"}`,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestRuleHandler(t)
+
+			req := httptest.NewRequest("POST", "/api/rules", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.CreateRule(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusCreated {
+				var response domain.Rule
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Equal(t, "important", response.Category)
+				assert.Len(t, response.FixExamples, 1)
+				assert.Equal(t, "/api/rules/Deprecation%20Reason", w.Header().Get("Location"))
+				rules, err := repo.ListRules(context.Background())
+				assert.NoError(t, err)
+				assert.Len(t, rules, 3)
+			}
+
+			if tt.invalidParam != "" {
+				var problem Problem
+				if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+					t.Fatalf("Failed to decode problem: %v", err)
+				}
+				assert.Len(t, problem.InvalidParams, 1)
+				assert.Equal(t, tt.invalidParam, problem.InvalidParams[0].Path)
+			}
+		})
+	}
+}
+
+func TestRuleHandler_GetRule(t *testing.T) {
+	handler, _ := newTestRuleHandler(t)
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/api/rules/PII", nil), map[string]string{"name": "PII"})
+	w := httptest.NewRecorder()
+	handler.GetRule(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var rule domain.Rule
+	if err := json.NewDecoder(w.Body).Decode(&rule); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, 10.0, rule.Weight)
+
+	req = mux.SetURLVars(httptest.NewRequest("GET", "/api/rules/missing", nil), map[string]string{"name": "missing"})
+	w = httptest.NewRecorder()
+	handler.GetRule(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRuleHandler_UpdateRule(t *testing.T) {
+	handler, repo := newTestRuleHandler(t)
+
+	body := `{"weight": 4, "category": "style", "description": "A team convention"}`
+	req := mux.SetURLVars(httptest.NewRequest("PUT", "/api/rules/Custom%20Rule", bytes.NewBufferString(body)),
+		map[string]string{"name": "Custom Rule"})
+	w := httptest.NewRecorder()
+	handler.UpdateRule(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	stored, err := repo.GetRule(context.Background(), "Custom Rule")
+	if err != nil {
+		t.Fatalf("failed to get rule: %v", err)
+	}
+	assert.Equal(t, 4.0, stored.Weight)
+	assert.Equal(t, "style", stored.Category)
+	assert.False(t, stored.NeedsReview)
+
+	req = mux.SetURLVars(httptest.NewRequest("PUT", "/api/rules/missing", bytes.NewBufferString(body)),
+		map[string]string{"name": "missing"})
+	w = httptest.NewRecorder()
+	handler.UpdateRule(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRuleHandler_DeleteRule(t *testing.T) {
+	handler, repo := newTestRuleHandler(t)
+
+	req := mux.SetURLVars(httptest.NewRequest("DELETE", "/api/rules/PII", nil), map[string]string{"name": "PII"})
+	w := httptest.NewRecorder()
+	handler.DeleteRule(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	_, err := repo.GetRule(context.Background(), "PII")
+	assert.ErrorIs(t, err, domain.ErrRuleNotFound)
+
+	w = httptest.NewRecorder()
+	handler.DeleteRule(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebHandler_RulePages(t *testing.T) {
+	_, repo := newTestRuleHandler(t)
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+
+	handler, err := NewWebHandler(service, web.Templates(false), WithRulePages(domain.NewRuleCatalogService(repo)))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.RuleCatalog(w, httptest.NewRequest("GET", "/rules", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `href="/rules/PII"`)
+	assert.Contains(t, w.Body.String(), "needs review")
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/rules/PII", nil), map[string]string{"name": "PII"})
+	w = httptest.NewRecorder()
+	handler.RuleDetail(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Fields exposing personal data must be marked")
+
+	req = mux.SetURLVars(httptest.NewRequest("GET", "/rules/missing", nil), map[string]string{"name": "missing"})
+	w = httptest.NewRecorder()
+	handler.RuleDetail(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
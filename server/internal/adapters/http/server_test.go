@@ -0,0 +1,194 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startTestServer serves handler on a random local port until the returned context is cancelled
+func startTestServer(t *testing.T, handler http.Handler, shutdownTimeout time.Duration) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	config := DefaultServerConfig(listener.Addr().String())
+	config.ShutdownTimeout = shutdownTimeout
+	server := NewServer(handler, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(ctx, listener)
+	}()
+
+	return "http://" + listener.Addr().String(), cancel, done
+}
+
+func TestServer_InFlightPostCompletesDuringShutdown(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	apiHandler := NewAPIHandler(domain.NewSchemaReportService(repo))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		apiHandler.ReceiveReport(w, r)
+	})
+
+	url, stop, done := startTestServer(t, handler, 5*time.Second)
+
+	body, _ := json.Marshal(domain.IncomingReport{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		SubgraphName: stringPtr("user-service"),
+		Score:        85.5,
+		TotalFields:  42,
+	})
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := http.Post(url+"/api/reports", "application/json", bytes.NewReader(body))
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		responseBody, _ := io.ReadAll(resp.Body)
+		results <- result{status: resp.StatusCode, body: string(responseBody)}
+	}()
+
+	<-started
+	stop()
+
+	// The server must stop accepting new connections while the request is still running
+	assert.Eventually(t, func() bool {
+		_, err := net.DialTimeout("tcp", strings.TrimPrefix(url, "http://"), 100*time.Millisecond)
+		return err != nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	select {
+	case err := <-done:
+		t.Fatalf("server stopped before the in-flight request finished: %v", err)
+	default:
+	}
+
+	close(release)
+
+	res := <-results
+	assert.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.status)
+	assert.Contains(t, res.body, `"success":true`)
+	assert.Len(t, repo.Reports, 1, "the report must be stored")
+	assert.NoError(t, <-done)
+}
+
+func TestServer_ShutdownDeadline(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	url, stop, done := startTestServer(t, handler, 50*time.Millisecond)
+
+	go func() {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	stop()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not give up after the shutdown deadline")
+	}
+}
+
+func TestAPIHandler_ReceiveReport_TooLarge(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	handler := NewAPIHandler(domain.NewSchemaReportService(repo), WithMaxReportBytes(64))
+
+	body, _ := json.Marshal(domain.IncomingReport{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		SubgraphName: stringPtr(strings.Repeat("x", 100)),
+	})
+	req := httptest.NewRequest("POST", "/api/reports", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ReceiveReport(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Empty(t, repo.Reports)
+}
+
+func TestQueryTimeout(t *testing.T) {
+	var handlerErr error
+	handler := QueryTimeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline := r.Context().Deadline()
+		assert.True(t, hasDeadline)
+		<-r.Context().Done()
+		handlerErr = r.Context().Err()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	assert.ErrorIs(t, handlerErr, context.DeadlineExceeded)
+}
+
+func TestAPIHandler_CancelledRequest(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	handler := NewAPIHandler(domain.NewSchemaReportService(repo))
+
+	body, _ := json.Marshal(domain.IncomingReport{
+		Timestamp:    time.Now().Format(time.RFC3339),
+		SubgraphName: stringPtr("user-service"),
+		Score:        85.5,
+	})
+
+	// A client that disconnects cancels the request context before the report is stored
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("POST", "/api/reports", bytes.NewReader(body)).WithContext(ctx)
+	w := httptest.NewRecorder()
+	handler.ReceiveReport(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, repo.Reports)
+
+	// The same applies to reads that hit a deadline
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	req = httptest.NewRequest("GET", "/api/reports", nil).WithContext(ctx)
+	w = httptest.NewRecorder()
+	handler.GetReports(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+)
+
+// SuppressionHandler handles HTTP API requests for suppressions
+type SuppressionHandler struct {
+	suppressionService *domain.SuppressionService
+}
+
+// NewSuppressionHandler creates a new suppression handler
+func NewSuppressionHandler(suppressionService *domain.SuppressionService) *SuppressionHandler {
+	return &SuppressionHandler{
+		suppressionService: suppressionService,
+	}
+}
+
+// ListSuppressions returns all suppressions, optionally filtered by subgraph
+func (h *SuppressionHandler) ListSuppressions(w http.ResponseWriter, r *http.Request) {
+	suppressions, err := h.suppressionService.ListSuppressions(r.Context(), r.URL.Query().Get("subgraph"))
+	if err != nil {
+		writeError(w, r, "Error listing suppressions", err)
+		return
+	}
+
+	if suppressions == nil {
+		suppressions = []domain.Suppression{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(suppressions)
+}
+
+// CreateSuppression stores a new suppression. Scoped tokens may only create suppressions
+// for their own subgraphs.
+func (h *SuppressionHandler) CreateSuppression(w http.ResponseWriter, r *http.Request) {
+	suppression, ok := decodeSuppression(w, r)
+	if !ok || !allowSubgraph(w, r, suppression.SubgraphName) {
+		return
+	}
+
+	created, err := h.suppressionService.CreateSuppression(r.Context(), suppression)
+	if err != nil {
+		writeError(w, r, "Error creating suppression", err)
+		return
+	}
+
+	log.Printf("Created suppression %s for subgraph: %s, rule: %s", created.ID, created.SubgraphName, created.RuleName)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(created)
+}
+
+// GetSuppression returns a single suppression
+func (h *SuppressionHandler) GetSuppression(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Suppression ID required")
+		return
+	}
+
+	suppression, err := h.suppressionService.GetSuppression(r.Context(), id)
+	if err != nil {
+		writeError(w, r, "Error getting suppression", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(suppression)
+}
+
+// UpdateSuppression replaces an existing suppression. Scoped tokens may neither change
+// suppressions of other subgraphs nor move a suppression to one.
+func (h *SuppressionHandler) UpdateSuppression(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Suppression ID required")
+		return
+	}
+
+	suppression, ok := decodeSuppression(w, r)
+	if !ok || !h.allowSuppression(w, r, id) || !allowSubgraph(w, r, suppression.SubgraphName) {
+		return
+	}
+
+	updated, err := h.suppressionService.UpdateSuppression(r.Context(), id, suppression)
+	if err != nil {
+		writeError(w, r, "Error updating suppression", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+// DeleteSuppression removes a suppression. Scoped tokens may only delete suppressions of
+// their own subgraphs.
+func (h *SuppressionHandler) DeleteSuppression(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Suppression ID required")
+		return
+	}
+	if !h.allowSuppression(w, r, id) {
+		return
+	}
+
+	if err := h.suppressionService.DeleteSuppression(r.Context(), id); err != nil {
+		writeError(w, r, "Error deleting suppression", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowSuppression checks that the token of the request may change the stored suppression,
+// writing an error response when it may not or the suppression does not exist
+func (h *SuppressionHandler) allowSuppression(w http.ResponseWriter, r *http.Request, id string) bool {
+	existing, err := h.suppressionService.GetSuppression(r.Context(), id)
+	if err != nil {
+		writeError(w, r, "Error getting suppression", err)
+		return false
+	}
+	return allowSubgraph(w, r, existing.SubgraphName)
+}
+
+// decodeSuppression reads a suppression from the request body, writing an error response on failure
+func decodeSuppression(w http.ResponseWriter, r *http.Request) (*domain.Suppression, bool) {
+	var incoming domain.IncomingSuppression
+	if !decodeJSON(w, r, &incoming) {
+		return nil, false
+	}
+
+	suppression, err := incoming.ToDomainEntity()
+	if err != nil {
+		writeError(w, r, "Error converting suppression", err)
+		return nil, false
+	}
+
+	return suppression, true
+}
@@ -0,0 +1,241 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"testing"
+)
+
+func newTestSuppressionHandler() (*SuppressionHandler, *MockSuppressionRepository) {
+	repo := NewMockSuppressionRepository().
+		WithSuppression(domain.Suppression{
+			SubgraphName:      "user-service",
+			RuleName:          "Boolean Prefix",
+			CoordinatePattern: "User.*",
+			Reason:            "Legacy",
+			Author:            "jane",
+		})
+	return NewSuppressionHandler(domain.NewSuppressionService(repo)), repo
+}
+
+func TestSuppressionHandler_CreateSuppression(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "successful create",
+			body:           `{"subgraphName": "user-service", "rule": "PII", "coordinate": "User.email", "reason": "Public contact address", "author": "jane", "expiresAt": "2030-01-01T00:00:00Z"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing reason",
+			body:           `{"subgraphName": "user-service", "rule": "PII", "author": "jane"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid expiry",
+			body:           `{"subgraphName": "user-service", "rule": "PII", "reason": "x", "author": "jane", "expiresAt": "soon"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestSuppressionHandler()
+
+			req := httptest.NewRequest("POST", "/api/suppressions", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.CreateSuppression(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusCreated {
+				var response domain.Suppression
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Equal(t, "PII", response.RuleName)
+				assert.Len(t, repo.Suppressions, 2)
+			}
+		})
+	}
+}
+
+func TestSuppressionHandler_GetSuppression(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+	}{
+		{
+			name:           "existing suppression",
+			queryParams:    "id=1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing ID",
+			queryParams:    "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown suppression",
+			queryParams:    "id=999",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestSuppressionHandler()
+
+			req := httptest.NewRequest("GET", "/api/suppression?"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetSuppression(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestSuppressionHandler_ListSuppressions(t *testing.T) {
+	handler, _ := newTestSuppressionHandler()
+
+	req := httptest.NewRequest("GET", "/api/suppressions?subgraph=order-service", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListSuppressions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "[]", w.Body.String())
+}
+
+func TestSuppressionHandler_UpdateSuppression(t *testing.T) {
+	handler, repo := newTestSuppressionHandler()
+
+	body := `{"subgraphName": "user-service", "rule": "Boolean Prefix", "coordinate": "User.is*", "reason": "Narrowed", "author": "jane"}`
+	req := httptest.NewRequest("PUT", "/api/suppression?id=1", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	handler.UpdateSuppression(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "User.is*", repo.Suppressions["1"].CoordinatePattern)
+}
+
+func TestSuppressionHandler_DeleteSuppression(t *testing.T) {
+	handler, repo := newTestSuppressionHandler()
+
+	req := httptest.NewRequest("DELETE", "/api/suppression?id=1", nil)
+	w := httptest.NewRecorder()
+	handler.DeleteSuppression(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, repo.Suppressions)
+
+	req = httptest.NewRequest("DELETE", "/api/suppression?id=1", nil)
+	w = httptest.NewRecorder()
+	handler.DeleteSuppression(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSuppressionHandler_WithAPIToken(t *testing.T) {
+	ownBody := `{"subgraphName": "user-service", "rule": "PII", "reason": "Public contact address", "author": "jane"}`
+	otherBody := `{"subgraphName": "billing-service", "rule": "PII", "reason": "Public contact address", "author": "jane"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		token          string
+		expectedStatus int
+	}{
+		{
+			name:           "create for own subgraph",
+			method:         "POST",
+			target:         "/api/suppressions",
+			body:           ownBody,
+			token:          "ss_scoped",
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create for other subgraph",
+			method:         "POST",
+			target:         "/api/suppressions",
+			body:           otherBody,
+			token:          "ss_scoped",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "move to other subgraph",
+			method:         "PUT",
+			target:         "/api/suppression?id=1",
+			body:           otherBody,
+			token:          "ss_scoped",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "update of other subgraph",
+			method:         "PUT",
+			target:         "/api/suppression?id=1",
+			body:           ownBody,
+			token:          "ss_other",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "delete of other subgraph",
+			method:         "DELETE",
+			target:         "/api/suppression?id=1",
+			token:          "ss_other",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "delete with token for all subgraphs",
+			method:         "DELETE",
+			target:         "/api/suppression?id=1",
+			token:          "ss_admin",
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestSuppressionHandler()
+			tokenService := domain.NewAPITokenService(NewMockAPITokenRepository().
+				WithToken("ss_admin").
+				WithToken("ss_scoped", "user-service").
+				WithToken("ss_other", "billing-service"))
+			routes := map[string]http.HandlerFunc{
+				"POST":   handler.CreateSuppression,
+				"PUT":    handler.UpdateSuppression,
+				"DELETE": handler.DeleteSuppression,
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+
+			RequireAPIToken(tokenService, false)(routes[tt.method]).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				assert.Len(t, repo.Suppressions, 1)
+				assert.Equal(t, "user-service", repo.Suppressions["1"].SubgraphName)
+			}
+		})
+	}
+}
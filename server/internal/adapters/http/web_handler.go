@@ -1,59 +1,118 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
+	"io/fs"
 	"log"
 	"net/http"
-	"os"
 	"schema-score-server/internal/domain"
+
+	"github.com/gorilla/mux"
 )
 
+// webPages are the page templates; each is rendered through base.html
+var webPages = []string{"dashboard.html", "about.html", "report.html", "compare.html", "history.html", "rules.html", "rule.html"}
+
 // WebHandler handles HTTP web requests
 type WebHandler struct {
 	schemaReportService *domain.SchemaReportService
+	ruleService         *domain.RuleCatalogService
+	rescoreService      *domain.RescoreService
+	templates           fs.FS
+	reload              bool
+	pages               map[string]*template.Template
 }
 
-// NewWebHandler creates a new web handler
-func NewWebHandler(schemaReportService *domain.SchemaReportService) *WebHandler {
-	// Check if template directory exists
-	if _, err := os.Stat("internal/templates"); os.IsNotExist(err) {
-		log.Printf("Template directory 'internal/templates' does not exist")
-		log.Printf("Current working directory: %s", getCurrentDir())
-		log.Fatal("Template directory not found")
+// WebHandlerOption configures optional behaviour of the WebHandler
+type WebHandlerOption func(*WebHandler)
+
+// WithTemplateReload re-parses the templates on every request so template edits
+// show up without a restart. Meant for development only.
+func WithTemplateReload() WebHandlerOption {
+	return func(h *WebHandler) {
+		h.reload = true
 	}
+}
 
-	return &WebHandler{
-		schemaReportService: schemaReportService,
+// WithRulePages renders the rule catalog pages and the rule weights on the about page
+// from the given catalog
+func WithRulePages(ruleService *domain.RuleCatalogService) WebHandlerOption {
+	return func(h *WebHandler) {
+		h.ruleService = ruleService
 	}
 }
 
-func getCurrentDir() string {
-	if wd, err := os.Getwd(); err == nil {
-		return wd
+// WithRescoredHistory lets the history chart switch to the scores rescored with the
+// current rule weights
+func WithRescoredHistory(rescoreService *domain.RescoreService) WebHandlerOption {
+	return func(h *WebHandler) {
+		h.rescoreService = rescoreService
 	}
-	return "unknown"
 }
 
-func (h *WebHandler) loadTemplates(templateNames ...string) (*template.Template, error) {
-	// Build full paths
-	var templatePaths []string
-	for _, name := range templateNames {
-		templatePaths = append(templatePaths, "internal/templates/"+name)
+// NewWebHandler creates a new web handler. All pages are parsed once up front, so a
+// broken template fails at startup rather than on the first request.
+func NewWebHandler(schemaReportService *domain.SchemaReportService, templates fs.FS, opts ...WebHandlerOption) (*WebHandler, error) {
+	handler := &WebHandler{
+		schemaReportService: schemaReportService,
+		templates:           templates,
+		pages:               make(map[string]*template.Template),
+	}
+	for _, opt := range opts {
+		opt(handler)
+	}
+
+	for _, page := range webPages {
+		parsed, err := handler.parsePage(page)
+		if err != nil {
+			return nil, err
+		}
+		handler.pages[page] = parsed
 	}
 
-	// Parse all templates together
-	templates, err := template.ParseFiles(templatePaths...)
+	return handler, nil
+}
+
+// templateFuncs are the functions available to the page templates
+var templateFuncs = template.FuncMap{
+	// shortID abbreviates report IDs in headings and lists
+	"shortID": domain.ShortID,
+	// derefFloat reads optional numbers such as the recomputed score of a report
+	"derefFloat": func(value *float64) float64 {
+		if value == nil {
+			return 0
+		}
+		return *value
+	},
+}
+
+// parsePage parses a page template together with the base layout
+func (h *WebHandler) parsePage(page string) (*template.Template, error) {
+	templates, err := template.New("base.html").Funcs(templateFuncs).ParseFS(h.templates, "base.html", page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse templates %v: %w", templatePaths, err)
+		return nil, fmt.Errorf("failed to parse templates for %s: %w", page, err)
+	}
+	return templates, nil
+}
+
+// loadTemplates returns the parsed templates of a page, re-parsing them in reload mode
+func (h *WebHandler) loadTemplates(page string) (*template.Template, error) {
+	if h.reload {
+		return h.parsePage(page)
 	}
 
+	templates, ok := h.pages[page]
+	if !ok {
+		return nil, fmt.Errorf("unknown page template %s", page)
+	}
 	return templates, nil
 }
 
 // Dashboard renders the main dashboard page
 func (h *WebHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
-	dashboardData, err := h.schemaReportService.GetDashboardData()
+	dashboardData, err := h.schemaReportService.GetDashboardData(r.Context())
 	if err != nil {
 		log.Printf("Error getting dashboard data: %v", err)
 		http.Error(w, "Failed to load dashboard data", http.StatusInternalServerError)
@@ -64,7 +123,7 @@ func (h *WebHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 		len(dashboardData.Subgraphs), len(dashboardData.RecentReports), dashboardData.TotalReports)
 
 	// Load only dashboard-specific templates
-	templates, err := h.loadTemplates("base.html", "dashboard.html")
+	templates, err := h.loadTemplates("dashboard.html")
 	if err != nil {
 		log.Printf("Error loading dashboard templates: %v", err)
 		http.Error(w, "Template loading error", http.StatusInternalServerError)
@@ -80,15 +139,27 @@ func (h *WebHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 
 // About renders the about page explaining the project
 func (h *WebHandler) About(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Rules []domain.Rule
+	}{}
+	if h.ruleService != nil {
+		rules, err := h.ruleService.ListRules(r.Context())
+		if err != nil {
+			log.Printf("Error getting rules for the about page: %v", err)
+			// Continue without the rule weights rather than failing
+		}
+		data.Rules = rules
+	}
+
 	// Load only about-specific templates
-	templates, err := h.loadTemplates("base.html", "about.html")
+	templates, err := h.loadTemplates("about.html")
 	if err != nil {
 		log.Printf("Error loading about templates: %v", err)
 		http.Error(w, "Template loading error", http.StatusInternalServerError)
 		return
 	}
 
-	if err := templates.ExecuteTemplate(w, "base.html", nil); err != nil {
+	if err := templates.ExecuteTemplate(w, "base.html", data); err != nil {
 		log.Printf("Error executing about template: %v", err)
 		http.Error(w, "Template execution error", http.StatusInternalServerError)
 		return
@@ -104,10 +175,10 @@ func (h *WebHandler) ReportDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the report with all details
-	report, err := h.schemaReportService.GetReportByID(reportID)
+	report, err := h.schemaReportService.GetReportByID(r.Context(), reportID)
 	if err != nil {
 		log.Printf("Error getting report: %v", err)
-		if err.Error() == "report with ID "+reportID+" not found" {
+		if errors.Is(err, domain.ErrReportNotFound) {
 			http.Error(w, "Report not found", http.StatusNotFound)
 		} else {
 			http.Error(w, "Failed to get report", http.StatusInternalServerError)
@@ -116,7 +187,7 @@ func (h *WebHandler) ReportDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get dashboard data for subgraph list in navigation
-	dashboardData, err := h.schemaReportService.GetDashboardData()
+	dashboardData, err := h.schemaReportService.GetDashboardData(r.Context())
 	if err != nil {
 		log.Printf("Error getting dashboard data for navigation: %v", err)
 		// Continue without navigation data rather than failing
@@ -138,7 +209,7 @@ func (h *WebHandler) ReportDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Load only report-specific templates
-	templates, err := h.loadTemplates("base.html", "report.html")
+	templates, err := h.loadTemplates("report.html")
 	if err != nil {
 		log.Printf("Error loading report templates: %v", err)
 		http.Error(w, "Template loading error", http.StatusInternalServerError)
@@ -152,6 +223,41 @@ func (h *WebHandler) ReportDetail(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// CompareReports renders the violation diff between a base and a head report
+func (h *WebHandler) CompareReports(w http.ResponseWriter, r *http.Request) {
+	baseID := r.URL.Query().Get("base")
+	headID := r.URL.Query().Get("head")
+	if baseID == "" || headID == "" {
+		http.Error(w, "Base and head report IDs required", http.StatusBadRequest)
+		return
+	}
+
+	diff, err := h.schemaReportService.CompareReports(r.Context(), baseID, headID)
+	if err != nil {
+		log.Printf("Error comparing reports: %v", err)
+		if errors.Is(err, domain.ErrReportNotFound) {
+			http.Error(w, "Report not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to compare reports", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Load only compare-specific templates
+	templates, err := h.loadTemplates("compare.html")
+	if err != nil {
+		log.Printf("Error loading compare templates: %v", err)
+		http.Error(w, "Template loading error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := templates.ExecuteTemplate(w, "base.html", diff); err != nil {
+		log.Printf("Error executing compare template: %v", err)
+		http.Error(w, "Template execution error", http.StatusInternalServerError)
+		return
+	}
+}
+
 // SubgraphHistory shows the score history for a specific subgraph
 func (h *WebHandler) SubgraphHistory(w http.ResponseWriter, r *http.Request) {
 	subgraph := r.URL.Query().Get("name")
@@ -160,7 +266,7 @@ func (h *WebHandler) SubgraphHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reports, err := h.schemaReportService.GetSubgraphHistory(subgraph, 100)
+	reports, err := h.schemaReportService.GetSubgraphHistory(r.Context(), subgraph, 100)
 	if err != nil {
 		log.Printf("Error getting subgraph history: %v", err)
 		http.Error(w, "Failed to get subgraph history", http.StatusInternalServerError)
@@ -168,7 +274,7 @@ func (h *WebHandler) SubgraphHistory(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get dashboard data for subgraph list in navigation
-	dashboardData, err := h.schemaReportService.GetDashboardData()
+	dashboardData, err := h.schemaReportService.GetDashboardData(r.Context())
 	if err != nil {
 		log.Printf("Error getting dashboard data for navigation: %v", err)
 		// Continue without navigation data rather than failing
@@ -185,14 +291,33 @@ func (h *WebHandler) SubgraphHistory(w http.ResponseWriter, r *http.Request) {
 		SubgraphName string      `json:"subgraph_name"`
 		Reports      interface{} `json:"reports"`
 		Subgraphs    []string    `json:"subgraphs"`
+		// RulesetVersion is the version the rescored scores belong to, empty when rescored
+		// scores are not available. RulesetCurrent is false when the current weights have no
+		// rescored scores yet and an older version is shown. RescoredScores are keyed by
+		// report ID.
+		RulesetVersion string             `json:"ruleset_version"`
+		RulesetCurrent bool               `json:"ruleset_current"`
+		RescoredScores map[string]float64 `json:"rescored_scores"`
 	}{
 		SubgraphName: subgraph,
 		Reports:      reports,
 		Subgraphs:    subgraphs,
 	}
 
+	if h.rescoreService != nil {
+		series, err := h.rescoreService.GetCurrentScores(r.Context(), subgraph)
+		if err != nil {
+			log.Printf("Error getting rescored scores: %v", err)
+			// Continue with the reported scores only rather than failing
+		} else {
+			data.RulesetVersion = series.Ruleset.Version
+			data.RulesetCurrent = series.Current
+			data.RescoredScores = series.Scores
+		}
+	}
+
 	// Load only history-specific templates
-	templates, err := h.loadTemplates("base.html", "history.html")
+	templates, err := h.loadTemplates("history.html")
 	if err != nil {
 		log.Printf("Error loading history templates: %v", err)
 		http.Error(w, "Template loading error", http.StatusInternalServerError)
@@ -205,3 +330,80 @@ func (h *WebHandler) SubgraphHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// RuleCatalog lists the rules of the catalog
+func (h *WebHandler) RuleCatalog(w http.ResponseWriter, r *http.Request) {
+	if h.ruleService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	rules, err := h.ruleService.ListRules(r.Context())
+	if err != nil {
+		log.Printf("Error getting rules: %v", err)
+		http.Error(w, "Failed to get rules", http.StatusInternalServerError)
+		return
+	}
+
+	needsReview := 0
+	for _, rule := range rules {
+		if rule.NeedsReview {
+			needsReview++
+		}
+	}
+
+	data := struct {
+		Rules       []domain.Rule
+		NeedsReview int
+	}{
+		Rules:       rules,
+		NeedsReview: needsReview,
+	}
+
+	// Load only rule catalog templates
+	templates, err := h.loadTemplates("rules.html")
+	if err != nil {
+		log.Printf("Error loading rule catalog templates: %v", err)
+		http.Error(w, "Template loading error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := templates.ExecuteTemplate(w, "base.html", data); err != nil {
+		log.Printf("Error executing rule catalog template: %v", err)
+		http.Error(w, "Template execution error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RuleDetail renders the documentation of a single rule
+func (h *WebHandler) RuleDetail(w http.ResponseWriter, r *http.Request) {
+	if h.ruleService == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	rule, err := h.ruleService.GetRule(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		log.Printf("Error getting rule: %v", err)
+		if errors.Is(err, domain.ErrRuleNotFound) {
+			http.Error(w, "Rule not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get rule", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Load only rule-specific templates
+	templates, err := h.loadTemplates("rule.html")
+	if err != nil {
+		log.Printf("Error loading rule templates: %v", err)
+		http.Error(w, "Template loading error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := templates.ExecuteTemplate(w, "base.html", rule); err != nil {
+		log.Printf("Error executing rule template: %v", err)
+		http.Error(w, "Template execution error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"schema-score-server/internal/web"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWebHandler_ParsesEmbeddedTemplates(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+
+	handler, err := NewWebHandler(service, web.Templates(false))
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/about", nil)
+	w := httptest.NewRecorder()
+	handler.About(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<html")
+}
+
+func TestNewWebHandler_MissingTemplate(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+	templates := fstest.MapFS{
+		"base.html": {Data: []byte(`{{block "content" .}}{{end}}`)},
+	}
+
+	_, err := NewWebHandler(service, templates)
+	assert.Error(t, err)
+}
+
+func TestWebHandler_TemplateReload(t *testing.T) {
+	service := domain.NewSchemaReportService(NewMockSchemaReportRepository())
+	templates := fstest.MapFS{"base.html": {Data: []byte(`{{block "content" .}}{{end}}`)}}
+	for _, page := range webPages {
+		templates[page] = &fstest.MapFile{Data: []byte(`{{define "content"}}v1{{end}}`)}
+	}
+
+	cached, err := NewWebHandler(service, templates)
+	assert.NoError(t, err)
+	reloading, err := NewWebHandler(service, templates, WithTemplateReload())
+	assert.NoError(t, err)
+
+	templates["about.html"] = &fstest.MapFile{Data: []byte(`{{define "content"}}v2{{end}}`)}
+
+	w := httptest.NewRecorder()
+	cached.About(w, httptest.NewRequest("GET", "/about", nil))
+	assert.Equal(t, "v1", w.Body.String())
+
+	w = httptest.NewRecorder()
+	reloading.About(w, httptest.NewRequest("GET", "/about", nil))
+	assert.Equal(t, "v2", w.Body.String())
+}
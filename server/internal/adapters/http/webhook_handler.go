@@ -0,0 +1,202 @@
+package http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+	"strconv"
+)
+
+// WebhookHandler handles HTTP API requests for webhooks and their deliveries
+type WebhookHandler struct {
+	webhookService *domain.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *domain.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// ListWebhooks returns all registered webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		writeError(w, r, "Error listing webhooks", err)
+		return
+	}
+
+	response := make([]map[string]interface{}, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, webhookResponse(webhook, false))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// CreateWebhook registers a new webhook. The signing secret is only returned here.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var incoming domain.IncomingWebhook
+	if !decodeJSON(w, r, &incoming) {
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(r.Context(), incoming.ToDomainEntity())
+	if err != nil {
+		writeError(w, r, "Error creating webhook", err)
+		return
+	}
+
+	log.Printf("Created webhook %s for %s", webhook.ID, webhook.URL)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(webhookResponse(*webhook, true))
+}
+
+// GetWebhook returns a single webhook
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Webhook ID required")
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(r.Context(), id)
+	if err != nil {
+		writeError(w, r, "Error getting webhook", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(webhookResponse(*webhook, false))
+}
+
+// DeleteWebhook removes a webhook and its deliveries
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Webhook ID required")
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), id); err != nil {
+		writeError(w, r, "Error deleting webhook", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries returns recent deliveries, optionally filtered by webhook and status
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limitStr := query.Get("limit")
+	if limitStr == "" {
+		limitStr = "50"
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid limit parameter")
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), query.Get("webhook"), query.Get("status"), limit)
+	if err != nil {
+		writeError(w, r, "Error listing webhook deliveries", err)
+		return
+	}
+
+	response := make([]map[string]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, deliveryResponse(delivery))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// GetDelivery returns a single delivery with all of its attempts
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Delivery ID required")
+		return
+	}
+
+	delivery, err := h.webhookService.GetDelivery(r.Context(), id)
+	if err != nil {
+		writeError(w, r, "Error getting webhook delivery", err)
+		return
+	}
+
+	attempts := make([]map[string]interface{}, 0, len(delivery.AttemptLog))
+	for _, attempt := range delivery.AttemptLog {
+		attempts = append(attempts, map[string]interface{}{
+			"number":       attempt.Number,
+			"status_code":  attempt.StatusCode,
+			"error":        attempt.Error,
+			"duration_ms":  attempt.Duration.Milliseconds(),
+			"attempted_at": attempt.AttemptedAt,
+		})
+	}
+
+	response := deliveryResponse(*delivery)
+	response["attempt_log"] = attempts
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// RetryDelivery schedules a delivery to be sent again
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Delivery ID required")
+		return
+	}
+
+	if err := h.webhookService.RetryDelivery(r.Context(), id); err != nil {
+		writeError(w, r, "Error retrying webhook delivery", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// webhookResponse converts a webhook into its JSON response representation
+func webhookResponse(webhook domain.Webhook, includeSecret bool) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":             webhook.ID,
+		"url":            webhook.URL,
+		"subgraph_name":  webhook.SubgraphName,
+		"min_score":      webhook.MinScore,
+		"max_score_drop": webhook.MaxScoreDrop,
+		"created_at":     webhook.CreatedAt,
+	}
+	if includeSecret {
+		response["secret"] = webhook.Secret
+	}
+	return response
+}
+
+// deliveryResponse converts a delivery into its JSON response representation
+func deliveryResponse(delivery domain.WebhookDelivery) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               delivery.ID,
+		"webhook_id":       delivery.WebhookID,
+		"event_type":       delivery.EventType,
+		"payload":          json.RawMessage(delivery.Payload),
+		"status":           delivery.Status,
+		"attempts":         delivery.Attempts,
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"last_error":       delivery.LastError,
+		"last_status_code": delivery.LastStatusCode,
+		"created_at":       delivery.CreatedAt,
+		"delivered_at":     delivery.DeliveredAt,
+	}
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+)
+
+func newTestWebhookHandler() (*WebhookHandler, *MockWebhookRepository) {
+	minScore := 80.0
+	statusCode := 500
+	repo := NewMockWebhookRepository().
+		WithWebhook(domain.Webhook{URL: "https://hooks.example.com", Secret: "s3cret", MinScore: &minScore}).
+		WithDelivery(domain.WebhookDelivery{
+			WebhookID:      "1",
+			EventType:      domain.WebhookEventScoreBelowThreshold,
+			Payload:        []byte(`{"type":"score.below_threshold"}`),
+			Status:         domain.DeliveryStatusFailed,
+			Attempts:       domain.MaxDeliveryAttempts,
+			LastStatusCode: &statusCode,
+			AttemptLog: []domain.DeliveryAttempt{
+				{Number: 1, StatusCode: &statusCode, Error: "unexpected status 500", AttemptedAt: time.Now()},
+			},
+		})
+
+	return NewWebhookHandler(domain.NewWebhookService(repo, NewMockSchemaReportRepository())), repo
+}
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "successful create",
+			body:           `{"url": "https://hooks.example.com/scores", "subgraphName": "user-service", "maxScoreDrop": 5}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing threshold",
+			body:           `{"url": "https://hooks.example.com/scores"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid url",
+			body:           `{"url": "hooks.example.com", "minScore": 80}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestWebhookHandler()
+
+			req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.CreateWebhook(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusCreated {
+				var response map[string]interface{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.NotEmpty(t, response["secret"], "the secret is returned once on creation")
+				assert.Equal(t, "user-service", response["subgraph_name"])
+				assert.Len(t, repo.Webhooks, 2)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_GetWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+	}{
+		{name: "existing webhook", queryParams: "?id=1", expectedStatus: http.StatusOK},
+		{name: "unknown webhook", queryParams: "?id=999", expectedStatus: http.StatusNotFound},
+		{name: "missing ID", queryParams: "", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestWebhookHandler()
+
+			req := httptest.NewRequest("GET", "/api/webhook"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.GetWebhook(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.NotContains(t, response, "secret")
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	handler, repo := newTestWebhookHandler()
+
+	req := httptest.NewRequest("DELETE", "/api/webhook?id=1", nil)
+	w := httptest.NewRecorder()
+	handler.DeleteWebhook(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, repo.Webhooks)
+	assert.Empty(t, repo.Deliveries)
+}
+
+func TestWebhookHandler_ListDeliveries(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "all deliveries", queryParams: "", expectedStatus: http.StatusOK, expectedCount: 1},
+		{name: "by status", queryParams: "?status=pending", expectedStatus: http.StatusOK, expectedCount: 0},
+		{name: "by webhook", queryParams: "?webhook=1&status=failed", expectedStatus: http.StatusOK, expectedCount: 1},
+		{name: "invalid limit", queryParams: "?limit=none", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestWebhookHandler()
+
+			req := httptest.NewRequest("GET", "/api/webhook-deliveries"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListDeliveries(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []map[string]interface{}
+				if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				assert.Len(t, response, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_GetDelivery(t *testing.T) {
+	handler, _ := newTestWebhookHandler()
+
+	req := httptest.NewRequest("GET", "/api/webhook-delivery?id=1", nil)
+	w := httptest.NewRecorder()
+	handler.GetDelivery(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, "failed", response["status"])
+	assert.Equal(t, "score.below_threshold", response["payload"].(map[string]interface{})["type"])
+	assert.Len(t, response["attempt_log"], 1)
+
+	req = httptest.NewRequest("GET", "/api/webhook-delivery?id=999", nil)
+	w = httptest.NewRecorder()
+	handler.GetDelivery(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebhookHandler_RetryDelivery(t *testing.T) {
+	handler, repo := newTestWebhookHandler()
+
+	req := httptest.NewRequest("POST", "/api/webhook-delivery/retry?id=1", nil)
+	w := httptest.NewRecorder()
+	handler.RetryDelivery(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, domain.DeliveryStatusPending, repo.Deliveries["1"].Status)
+}
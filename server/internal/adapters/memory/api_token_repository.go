@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryAPITokenRepository implements the APITokenRepository interface in memory
+type MemoryAPITokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]*domain.APIToken
+	ids    sequence
+}
+
+// NewMemoryAPITokenRepository creates a new in-memory implementation of APITokenRepository
+func NewMemoryAPITokenRepository() domain.APITokenRepository {
+	return &MemoryAPITokenRepository{
+		tokens: make(map[string]*domain.APIToken),
+	}
+}
+
+// Store saves a new token
+func (r *MemoryAPITokenRepository) Store(ctx context.Context, token *domain.APIToken) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.tokens {
+		if stored.Hash == token.Hash {
+			return fmt.Errorf("failed to insert API token: duplicate token hash")
+		}
+	}
+
+	token.ID = r.ids.next()
+	r.tokens[token.ID] = copyAPIToken(token)
+	return nil
+}
+
+// GetByHash retrieves a token by the hash of its plaintext value
+func (r *MemoryAPITokenRepository) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.Hash == hash {
+			return copyAPIToken(token), nil
+		}
+	}
+	return nil, fmt.Errorf("API token: %w", domain.ErrAPITokenNotFound)
+}
+
+// List retrieves all tokens
+func (r *MemoryAPITokenRepository) List(ctx context.Context) ([]domain.APIToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tokens []domain.APIToken
+	for _, token := range r.tokens {
+		tokens = append(tokens, *copyAPIToken(token))
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return lessID(tokens[i].ID, tokens[j].ID)
+	})
+	return tokens, nil
+}
+
+// Revoke marks a token as revoked, keeping the original revocation time of revoked tokens
+func (r *MemoryAPITokenRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok {
+		return fmt.Errorf("API token with ID %s: %w", id, domain.ErrAPITokenNotFound)
+	}
+	if token.RevokedAt == nil {
+		token.RevokedAt = &at
+	}
+	return nil
+}
+
+// TouchLastUsed records when a token was last used
+func (r *MemoryAPITokenRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if token, ok := r.tokens[id]; ok {
+		token.LastUsedAt = &at
+	}
+	return nil
+}
+
+func copyAPIToken(token *domain.APIToken) *domain.APIToken {
+	c := *token
+	c.Subgraphs = append([]string(nil), token.Subgraphs...)
+	c.LastUsedAt = copyTime(token.LastUsedAt)
+	c.RevokedAt = copyTime(token.RevokedAt)
+	return &c
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+)
+
+// MemoryGateRepository implements the GateRepository interface in memory
+type MemoryGateRepository struct {
+	mu      sync.RWMutex
+	configs map[string]*domain.GateConfig
+}
+
+// NewMemoryGateRepository creates a new in-memory implementation of GateRepository
+func NewMemoryGateRepository() domain.GateRepository {
+	return &MemoryGateRepository{
+		configs: make(map[string]*domain.GateConfig),
+	}
+}
+
+// GetGateConfig retrieves the gate configuration of a subgraph
+func (r *MemoryGateRepository) GetGateConfig(ctx context.Context, subgraphName string) (*domain.GateConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	config, ok := r.configs[subgraphName]
+	if !ok {
+		return nil, fmt.Errorf("gate config for subgraph %s: %w", subgraphName, domain.ErrGateConfigNotFound)
+	}
+	return copyGateConfig(config), nil
+}
+
+// ListGateConfigs retrieves the gate configurations of all subgraphs
+func (r *MemoryGateRepository) ListGateConfigs(ctx context.Context) ([]domain.GateConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var configs []domain.GateConfig
+	for _, config := range r.configs {
+		configs = append(configs, *copyGateConfig(config))
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].SubgraphName < configs[j].SubgraphName
+	})
+	return configs, nil
+}
+
+// SaveGateConfig creates or replaces the gate configuration of a subgraph
+func (r *MemoryGateRepository) SaveGateConfig(ctx context.Context, config *domain.GateConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.configs[config.SubgraphName] = copyGateConfig(config)
+	return nil
+}
+
+// DeleteGateConfig removes the gate configuration of a subgraph
+func (r *MemoryGateRepository) DeleteGateConfig(ctx context.Context, subgraphName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.configs[subgraphName]; !ok {
+		return fmt.Errorf("gate config for subgraph %s: %w", subgraphName, domain.ErrGateConfigNotFound)
+	}
+	delete(r.configs, subgraphName)
+	return nil
+}
+
+// copyGateConfig copies a configuration; like the database it never returns a nil map
+func copyGateConfig(config *domain.GateConfig) *domain.GateConfig {
+	c := *config
+	c.MinScore = copyFloat(config.MinScore)
+	c.MaxScoreDrop = copyFloat(config.MaxScoreDrop)
+	c.RuleMaxViolations = make(map[string]int, len(config.RuleMaxViolations))
+	for rule, max := range config.RuleMaxViolations {
+		c.RuleMaxViolations[rule] = max
+	}
+	return &c
+}
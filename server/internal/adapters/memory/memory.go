@@ -0,0 +1,65 @@
+// Package memory implements the domain repositories in process memory.
+//
+// The data is lost when the process stops, so the adapter is meant for demos, local
+// development and integration tests rather than production data. The repositories are
+// safe for concurrent use and follow the ordering, limit and "not found" behaviour of the
+// SQL adapters, which the repositorytest contract suite verifies.
+package memory
+
+import (
+	"strconv"
+	"time"
+)
+
+// sequence hands out increasing numeric IDs like a serial database column. It is not
+// safe for concurrent use; the repository lock guards it.
+type sequence struct {
+	last int64
+}
+
+func (s *sequence) next() string {
+	s.last++
+	return strconv.FormatInt(s.last, 10)
+}
+
+// copyTime returns a copy of an optional time so stored values cannot be changed through
+// the caller's pointer
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func copyInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
+
+func copyFloat(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	return &c
+}
+
+// lessID orders IDs handed out by a sequence numerically
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
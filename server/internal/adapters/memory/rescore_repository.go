@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+)
+
+// rescoredScoreKey identifies the score of a report for a ruleset version
+type rescoredScoreKey struct {
+	reportID string
+	version  string
+}
+
+// MemoryRescoreRepository implements the RescoreRepository interface in memory
+type MemoryRescoreRepository struct {
+	mu       sync.RWMutex
+	versions map[string]*domain.RulesetVersion
+	scores   map[rescoredScoreKey]domain.RescoredScore
+}
+
+// NewMemoryRescoreRepository creates a new in-memory implementation of RescoreRepository
+func NewMemoryRescoreRepository() domain.RescoreRepository {
+	return &MemoryRescoreRepository{
+		versions: make(map[string]*domain.RulesetVersion),
+		scores:   make(map[rescoredScoreKey]domain.RescoredScore),
+	}
+}
+
+// SaveRulesetVersion saves a copy of a ruleset version unless it is stored already
+func (r *MemoryRescoreRepository) SaveRulesetVersion(ctx context.Context, version *domain.RulesetVersion) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.versions[version.Version]; !exists {
+		r.versions[version.Version] = copyRulesetVersion(version)
+	}
+	return nil
+}
+
+// GetRulesetVersion retrieves a ruleset version
+func (r *MemoryRescoreRepository) GetRulesetVersion(ctx context.Context, version string) (*domain.RulesetVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.versions[version]
+	if !ok {
+		return nil, fmt.Errorf("ruleset version %s: %w", version, domain.ErrRulesetVersionNotFound)
+	}
+	return copyRulesetVersion(stored), nil
+}
+
+// ListRulesetVersions retrieves all ruleset versions, newest first
+func (r *MemoryRescoreRepository) ListRulesetVersions(ctx context.Context) ([]domain.RulesetVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var versions []domain.RulesetVersion
+	for _, version := range r.versions {
+		versions = append(versions, *copyRulesetVersion(version))
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+	return versions, nil
+}
+
+// SaveRescoredScores creates or replaces the scores of reports for their ruleset version
+func (r *MemoryRescoreRepository) SaveRescoredScores(ctx context.Context, scores []domain.RescoredScore) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, score := range scores {
+		r.scores[rescoredScoreKey{reportID: score.ReportID, version: score.RulesetVersion}] = score
+	}
+	return nil
+}
+
+// GetRescoredScores retrieves the scores of the reports of a subgraph for a ruleset version
+func (r *MemoryRescoreRepository) GetRescoredScores(ctx context.Context, subgraphName, version string) ([]domain.RescoredScore, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var scores []domain.RescoredScore
+	for key, score := range r.scores {
+		if key.version == version && score.SubgraphName == subgraphName {
+			scores = append(scores, score)
+		}
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].ReportID < scores[j].ReportID
+	})
+	return scores, nil
+}
+
+func copyRulesetVersion(version *domain.RulesetVersion) *domain.RulesetVersion {
+	c := *version
+	c.Weights = make(map[string]float64, len(version.Weights))
+	for name, weight := range version.Weights {
+		c.Weights[name] = weight
+	}
+	return &c
+}
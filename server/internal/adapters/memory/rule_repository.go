@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRuleRepository implements the RuleRepository interface in memory
+type MemoryRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[string]*domain.Rule
+}
+
+// NewMemoryRuleRepository creates a new in-memory implementation of RuleRepository.
+// Unlike the databases, it starts with an empty catalog.
+func NewMemoryRuleRepository() domain.RuleRepository {
+	return &MemoryRuleRepository{
+		rules: make(map[string]*domain.Rule),
+	}
+}
+
+// NewSeededMemoryRuleRepository creates an in-memory RuleRepository whose catalog starts
+// with the rules of DefaultRuleCatalog, like the catalog the migrations seed
+func NewSeededMemoryRuleRepository() domain.RuleRepository {
+	repo := &MemoryRuleRepository{
+		rules: make(map[string]*domain.Rule, len(domain.DefaultRuleCatalog)),
+	}
+
+	now := time.Now()
+	for name, definition := range domain.DefaultRuleCatalog {
+		repo.rules[name] = &domain.Rule{
+			Name:        name,
+			Weight:      definition.Weight,
+			Category:    definition.Category,
+			FixExamples: []domain.RuleExample{},
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	}
+	return repo
+}
+
+// GetRule retrieves a rule by its name
+func (r *MemoryRuleRepository) GetRule(ctx context.Context, name string) (*domain.Rule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[name]
+	if !ok {
+		return nil, fmt.Errorf("rule %s: %w", name, domain.ErrRuleNotFound)
+	}
+	return copyRule(rule), nil
+}
+
+// ListRules retrieves all rules ordered by name
+func (r *MemoryRuleRepository) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rules []domain.Rule
+	for _, rule := range r.rules {
+		rules = append(rules, *copyRule(rule))
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules, nil
+}
+
+// CreateRule saves a new rule, failing when the catalog already has a rule of that name
+func (r *MemoryRuleRepository) CreateRule(ctx context.Context, rule *domain.Rule) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[rule.Name]; ok {
+		return fmt.Errorf("rule %s: %w", rule.Name, domain.ErrRuleExists)
+	}
+	r.rules[rule.Name] = copyRule(rule)
+	return nil
+}
+
+// UpdateRule saves the changes to an existing rule, keeping its creation time
+func (r *MemoryRuleRepository) UpdateRule(ctx context.Context, rule *domain.Rule) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.rules[rule.Name]
+	if !ok {
+		return fmt.Errorf("rule %s: %w", rule.Name, domain.ErrRuleNotFound)
+	}
+	updated := copyRule(rule)
+	updated.CreatedAt = existing.CreatedAt
+	r.rules[rule.Name] = updated
+	return nil
+}
+
+// DeleteRule removes a rule
+func (r *MemoryRuleRepository) DeleteRule(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[name]; !ok {
+		return fmt.Errorf("rule %s: %w", name, domain.ErrRuleNotFound)
+	}
+	delete(r.rules, name)
+	return nil
+}
+
+// RegisterRules adds the rules the catalog does not have yet
+func (r *MemoryRuleRepository) RegisterRules(ctx context.Context, rules []domain.Rule) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var registered []string
+	for i := range rules {
+		if _, ok := r.rules[rules[i].Name]; ok {
+			continue
+		}
+		r.rules[rules[i].Name] = copyRule(&rules[i])
+		registered = append(registered, rules[i].Name)
+	}
+	return registered, nil
+}
+
+// copyRule copies a rule; like the database it never returns nil fix examples
+func copyRule(rule *domain.Rule) *domain.Rule {
+	c := *rule
+	c.FixExamples = append([]domain.RuleExample{}, rule.FixExamples...)
+	return &c
+}
@@ -0,0 +1,472 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemorySchemaReportRepository implements the SchemaReportRepository interface in memory
+type MemorySchemaReportRepository struct {
+	mu sync.RWMutex
+
+	// reports holds the stored reports in insertion order, which breaks timestamp ties
+	// the way the time-ordered UUIDs do in the database
+	reports []*domain.SchemaReport
+	byID    map[string]*domain.SchemaReport
+	tracked map[string]*domain.TrackedViolation
+}
+
+// NewMemorySchemaReportRepository creates a new in-memory implementation of SchemaReportRepository
+func NewMemorySchemaReportRepository() domain.SchemaReportRepository {
+	return &MemorySchemaReportRepository{
+		byID:    make(map[string]*domain.SchemaReport),
+		tracked: make(map[string]*domain.TrackedViolation),
+	}
+}
+
+// Store saves a copy of a new schema report. Everything keeps the UUID the domain
+// assigned, and rows without one get a new ID first.
+func (r *MemorySchemaReportRepository) Store(ctx context.Context, report *domain.SchemaReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report.AssignIDs()
+	report.CreatedAt = time.Now()
+
+	for i := range report.RuleResults {
+		ruleResult := &report.RuleResults[i]
+		ruleResult.CreatedAt = report.CreatedAt
+
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			violation.CreatedAt = report.CreatedAt
+
+			if violation.Fingerprint != "" {
+				r.trackViolation(report, ruleResult.RuleName, violation)
+			}
+		}
+	}
+
+	// Resolve tracked violations of this subgraph that no longer occur. A report that
+	// arrives late does not resolve violations seen after it.
+	for _, tracked := range r.tracked {
+		if tracked.SubgraphName == report.SubgraphName && tracked.ResolvedAt == nil &&
+			tracked.LastSeenReportID != report.ID && !tracked.LastSeenAt.After(report.Timestamp) {
+			resolvedAt := report.Timestamp
+			tracked.ResolvedAt = &resolvedAt
+		}
+	}
+
+	stored, err := copyReport(report)
+	if err != nil {
+		return fmt.Errorf("failed to store schema report: %w", err)
+	}
+	r.reports = append(r.reports, stored)
+	r.byID[stored.ID] = stored
+
+	return nil
+}
+
+// trackViolation records a sighting of a violation and copies the start of its lifecycle
+// onto the violation. A violation that reappears after being resolved starts a new lifecycle.
+// A report that arrives late neither moves the last sighting back nor reopens a violation
+// resolved after it.
+func (r *MemorySchemaReportRepository) trackViolation(report *domain.SchemaReport, ruleName string, violation *domain.Violation) {
+	tracked, ok := r.tracked[violation.Fingerprint]
+	if !ok || (tracked.ResolvedAt != nil && !report.Timestamp.Before(*tracked.ResolvedAt)) {
+		tracked = &domain.TrackedViolation{
+			Fingerprint:       violation.Fingerprint,
+			SubgraphName:      report.SubgraphName,
+			RuleName:          ruleName,
+			FirstSeenReportID: report.ID,
+			FirstSeenAt:       report.Timestamp,
+		}
+		r.tracked[violation.Fingerprint] = tracked
+	}
+	if !report.Timestamp.Before(tracked.LastSeenAt) {
+		tracked.LocationCoordinate = copyString(violation.LocationCoordinate)
+		tracked.Message = violation.Message
+		tracked.LastSeenReportID = report.ID
+		tracked.LastSeenAt = report.Timestamp
+	}
+
+	firstSeenReportID := tracked.FirstSeenReportID
+	firstSeenAt := tracked.FirstSeenAt
+	violation.FirstSeenReportID = &firstSeenReportID
+	violation.FirstSeenAt = &firstSeenAt
+}
+
+// GetByID retrieves a schema report by its ID, or by the integer ID of a report stored
+// before IDs became UUIDs
+func (r *MemorySchemaReportRepository) GetByID(ctx context.Context, rawID string) (*domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, _ := domain.ParseReportID(rawID)
+	stored, ok := r.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("report with ID %s: %w", rawID, domain.ErrReportNotFound)
+	}
+
+	report, err := copyReport(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy report: %w", err)
+	}
+
+	sort.SliceStable(report.RuleResults, func(i, j int) bool {
+		a, b := report.RuleResults[i], report.RuleResults[j]
+		if a.ViolationCount != b.ViolationCount {
+			return a.ViolationCount > b.ViolationCount
+		}
+		return a.RuleName < b.RuleName
+	})
+	for i := range report.RuleResults {
+		violations := report.RuleResults[i].Violations
+		sort.SliceStable(violations, func(i, j int) bool {
+			if c := compareNullsLast(violations[i].LocationLine, violations[j].LocationLine); c != 0 {
+				return c < 0
+			}
+			return compareNullsLast(violations[i].LocationColumn, violations[j].LocationColumn) < 0
+		})
+	}
+
+	return report, nil
+}
+
+// GetRecentReports retrieves the most recent reports
+func (r *MemorySchemaReportRepository) GetRecentReports(ctx context.Context, limit int) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return listReports(r.newestFirst(nil), limit), nil
+}
+
+// GetReportsBySubgraph retrieves reports for a specific subgraph
+func (r *MemorySchemaReportRepository) GetReportsBySubgraph(ctx context.Context, subgraphName string, limit int) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reports := r.newestFirst(func(report *domain.SchemaReport) bool {
+		return report.SubgraphName == subgraphName
+	})
+	return listReports(reports, limit), nil
+}
+
+// GetPreviousReport retrieves the most recent report of a subgraph from before the given time
+func (r *MemorySchemaReportRepository) GetPreviousReport(ctx context.Context, subgraphName string, before time.Time) (*domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reports := r.newestFirst(func(report *domain.SchemaReport) bool {
+		return report.SubgraphName == subgraphName && report.Timestamp.Before(before)
+	})
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no report of %s before %s: %w", subgraphName, before.Format(time.RFC3339), domain.ErrReportNotFound)
+	}
+
+	report := listReport(reports[0])
+	return &report, nil
+}
+
+// GetLatestReportOnBranch retrieves the most recent report of a subgraph created on the given branch
+// before the given time
+func (r *MemorySchemaReportRepository) GetLatestReportOnBranch(ctx context.Context, subgraphName, branch string, before time.Time) (*domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reports := r.newestFirst(func(report *domain.SchemaReport) bool {
+		return report.SubgraphName == subgraphName && report.Timestamp.Before(before) &&
+			report.Metadata[domain.BranchMetadataKey] == branch
+	})
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no report on branch %s: %w", branch, domain.ErrReportNotFound)
+	}
+
+	report := listReport(reports[0])
+	return &report, nil
+}
+
+// GetSubgraphSummaries retrieves aggregated data for all subgraphs. The latest score and
+// the trend come from the most recent report and the one before it.
+func (r *MemorySchemaReportRepository) GetSubgraphSummaries(ctx context.Context) ([]domain.SubgraphSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var summaries []domain.SubgraphSummary
+	positions := make(map[string]int)
+	var previousScores []*float64
+
+	for _, report := range r.newestFirst(nil) {
+		position, ok := positions[report.SubgraphName]
+		if !ok {
+			positions[report.SubgraphName] = len(summaries)
+			summaries = append(summaries, domain.SubgraphSummary{
+				Name:         report.SubgraphName,
+				LatestScore:  report.Score,
+				LatestReport: report.Timestamp,
+			})
+			previousScores = append(previousScores, nil)
+			position = len(summaries) - 1
+		} else if previousScores[position] == nil {
+			score := report.Score
+			previousScores[position] = &score
+		}
+		summaries[position].ReportCount++
+	}
+
+	for i := range summaries {
+		summary := &summaries[i]
+		switch previous := previousScores[i]; {
+		case previous != nil && summary.LatestScore > *previous:
+			summary.Trend = "up"
+		case previous != nil && summary.LatestScore < *previous:
+			summary.Trend = "down"
+		default:
+			summary.Trend = "stable"
+		}
+	}
+
+	return summaries, nil
+}
+
+// GetLatestReports retrieves the most recent report of every subgraph with its rule results
+func (r *MemorySchemaReportRepository) GetLatestReports(ctx context.Context) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var reports []domain.SchemaReport
+	for _, stored := range r.newestFirst(nil) {
+		if seen[stored.SubgraphName] {
+			continue
+		}
+		seen[stored.SubgraphName] = true
+		reports = append(reports, reportWithRuleResults(stored))
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].SubgraphName < reports[j].SubgraphName
+	})
+	return reports, nil
+}
+
+// GetReportsWithRuleResults retrieves every report of a subgraph, oldest first, with its rule results
+func (r *MemorySchemaReportRepository) GetReportsWithRuleResults(ctx context.Context, subgraphName string) ([]domain.SchemaReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.newestFirst(func(report *domain.SchemaReport) bool {
+		return report.SubgraphName == subgraphName
+	})
+
+	var reports []domain.SchemaReport
+	for i := len(stored) - 1; i >= 0; i-- {
+		reports = append(reports, reportWithRuleResults(stored[i]))
+	}
+	return reports, nil
+}
+
+// GetOpenViolations retrieves the unresolved tracked violations of a subgraph, oldest first
+func (r *MemorySchemaReportRepository) GetOpenViolations(ctx context.Context, subgraphName string) ([]domain.TrackedViolation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var violations []domain.TrackedViolation
+	for _, tracked := range r.tracked {
+		if tracked.SubgraphName != subgraphName || tracked.ResolvedAt != nil {
+			continue
+		}
+		violation := *tracked
+		violation.LocationCoordinate = copyString(tracked.LocationCoordinate)
+		violations = append(violations, violation)
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if !a.FirstSeenAt.Equal(b.FirstSeenAt) {
+			return a.FirstSeenAt.Before(b.FirstSeenAt)
+		}
+		if a.RuleName != b.RuleName {
+			return a.RuleName < b.RuleName
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return violations, nil
+}
+
+// GetTotalReportCount returns the total number of reports
+func (r *MemorySchemaReportRepository) GetTotalReportCount(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.reports), nil
+}
+
+// HealthCheck verifies the repository is accessible, which memory always is
+func (r *MemorySchemaReportRepository) HealthCheck(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// newestFirst returns the stored reports that match the filter, most recent first. Reports
+// with the same timestamp are ordered by the time they were stored.
+func (r *MemorySchemaReportRepository) newestFirst(match func(report *domain.SchemaReport) bool) []*domain.SchemaReport {
+	var reports []*domain.SchemaReport
+	for i := len(r.reports) - 1; i >= 0; i-- {
+		if match == nil || match(r.reports[i]) {
+			reports = append(reports, r.reports[i])
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Timestamp.After(reports[j].Timestamp)
+	})
+	return reports
+}
+
+// listReports copies at most limit reports the way list queries return them
+func listReports(reports []*domain.SchemaReport, limit int) []domain.SchemaReport {
+	if limit >= 0 && len(reports) > limit {
+		reports = reports[:limit]
+	}
+
+	var list []domain.SchemaReport
+	for _, report := range reports {
+		list = append(list, listReport(report))
+	}
+	return list
+}
+
+// listReport copies the columns that list queries select, without metadata and rule results
+func listReport(report *domain.SchemaReport) domain.SchemaReport {
+	return domain.SchemaReport{
+		ID:                      report.ID,
+		SubgraphName:            report.SubgraphName,
+		Score:                   report.Score,
+		EffectiveScore:          report.EffectiveScore,
+		SuppressedCount:         report.SuppressedCount,
+		TotalFields:             report.TotalFields,
+		TotalWeightedViolations: report.TotalWeightedViolations,
+		RecomputedScore:         copyFloat(report.RecomputedScore),
+		ScoreMismatch:           report.ScoreMismatch,
+		Timestamp:               report.Timestamp,
+		CreatedAt:               report.CreatedAt,
+	}
+}
+
+// reportWithRuleResults copies a report with its rule results ordered by rule name, but
+// without their violations
+func reportWithRuleResults(stored *domain.SchemaReport) domain.SchemaReport {
+	report := listReport(stored)
+	for _, ruleResult := range stored.RuleResults {
+		ruleResult.Violations = nil
+		report.RuleResults = append(report.RuleResults, ruleResult)
+	}
+	sort.SliceStable(report.RuleResults, func(i, j int) bool {
+		return report.RuleResults[i].RuleName < report.RuleResults[j].RuleName
+	})
+	return report
+}
+
+// copyReport deep copies a report. The metadata goes through JSON like it does in the
+// database, so numbers come back as float64.
+func copyReport(report *domain.SchemaReport) (*domain.SchemaReport, error) {
+	c := listReport(report)
+	c.APITokenID = copyString(report.APITokenID)
+
+	if report.Metadata != nil {
+		metadataJSON, err := json.Marshal(report.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		if err := json.Unmarshal(metadataJSON, &c.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	for _, ruleResult := range report.RuleResults {
+		violations := ruleResult.Violations
+		ruleResult.ViolationCount = len(violations)
+		ruleResult.Violations = nil
+		for _, violation := range violations {
+			violation.LocationLine = copyInt(violation.LocationLine)
+			violation.LocationColumn = copyInt(violation.LocationColumn)
+			violation.LocationField = copyString(violation.LocationField)
+			violation.LocationType = copyString(violation.LocationType)
+			violation.LocationCoordinate = copyString(violation.LocationCoordinate)
+			violation.FirstSeenReportID = copyString(violation.FirstSeenReportID)
+			violation.FirstSeenAt = copyTime(violation.FirstSeenAt)
+			violation.SuppressionID = copyString(violation.SuppressionID)
+			violation.AgeDays = 0
+			ruleResult.Violations = append(ruleResult.Violations, violation)
+		}
+		c.RuleResults = append(c.RuleResults, ruleResult)
+	}
+
+	return &c, nil
+}
+
+// compareNullsLast orders optional numbers ascending with missing values last
+func compareNullsLast(a, b *int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case *a < *b:
+		return -1
+	case *a > *b:
+		return 1
+	default:
+		return 0
+	}
+}
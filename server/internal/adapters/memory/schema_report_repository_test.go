@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/adapters/repositorytest"
+	"schema-score-server/internal/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemorySchemaReportRepository_Contract(t *testing.T) {
+	repositorytest.TestSchemaReportRepository(t, func(t *testing.T) domain.SchemaReportRepository {
+		return NewMemorySchemaReportRepository()
+	})
+}
+
+func TestMemorySchemaReportRepository_ReturnsCopies(t *testing.T) {
+	repo := NewMemorySchemaReportRepository()
+	ctx := context.Background()
+
+	subgraph := "user-service"
+	report := domain.NewSchemaReport("", &subgraph, 80, 100, 10, time.Now(), map[string]interface{}{"branch": "main"})
+	report.AddRuleResult(domain.RuleResult{RuleName: "PII", Violations: []domain.Violation{{Message: "Field exposes an email"}}})
+	assert.NoError(t, repo.Store(ctx, report))
+
+	// Changing the stored or the returned report must not change the repository
+	report.Score = 0
+	report.RuleResults[0].Violations[0].Message = "changed"
+	stored, err := repo.GetByID(ctx, report.ID)
+	assert.NoError(t, err)
+	stored.Metadata["branch"] = "changed"
+
+	stored, err = repo.GetByID(ctx, report.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 80.0, stored.Score)
+	assert.Equal(t, "main", stored.Metadata["branch"])
+	assert.Equal(t, "Field exposes an email", stored.RuleResults[0].Violations[0].Message)
+}
+
+func TestMemorySchemaReportRepository_ConcurrentAccess(t *testing.T) {
+	repo := NewMemorySchemaReportRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			subgraph := fmt.Sprintf("service-%d", i%3)
+			for j := 0; j < 25; j++ {
+				coordinate := fmt.Sprintf("Type.field%d", j%5)
+				report := domain.NewSchemaReport("", &subgraph, float64(j), 100, 10, time.Now(), nil)
+				report.AddRuleResult(domain.RuleResult{RuleName: "Naming", Violations: []domain.Violation{
+					{Message: "Field names must be camelCase", LocationCoordinate: &coordinate},
+				}})
+				report.AssignFingerprints()
+				assert.NoError(t, repo.Store(ctx, report))
+
+				_, err := repo.GetSubgraphSummaries(ctx)
+				assert.NoError(t, err)
+				_, err = repo.GetOpenViolations(ctx, subgraph)
+				assert.NoError(t, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	count, err := repo.GetTotalReportCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, count)
+
+	reports, err := repo.GetRecentReports(ctx, 1000)
+	assert.NoError(t, err)
+	ids := make(map[string]bool)
+	for _, report := range reports {
+		ids[report.ID] = true
+	}
+	assert.Len(t, ids, 200)
+}
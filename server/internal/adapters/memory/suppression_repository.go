@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemorySuppressionRepository implements the SuppressionRepository interface in memory
+type MemorySuppressionRepository struct {
+	mu           sync.RWMutex
+	suppressions map[string]*domain.Suppression
+	ids          sequence
+}
+
+// NewMemorySuppressionRepository creates a new in-memory implementation of SuppressionRepository
+func NewMemorySuppressionRepository() domain.SuppressionRepository {
+	return &MemorySuppressionRepository{
+		suppressions: make(map[string]*domain.Suppression),
+	}
+}
+
+// Store saves a new suppression
+func (r *MemorySuppressionRepository) Store(ctx context.Context, suppression *domain.Suppression) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	suppression.ID = r.ids.next()
+	suppression.CreatedAt = time.Now()
+	suppression.UpdatedAt = suppression.CreatedAt
+
+	r.suppressions[suppression.ID] = copySuppression(suppression)
+	return nil
+}
+
+// GetByID retrieves a suppression by its ID
+func (r *MemorySuppressionRepository) GetByID(ctx context.Context, id string) (*domain.Suppression, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	suppression, ok := r.suppressions[id]
+	if !ok {
+		return nil, fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+	}
+	return copySuppression(suppression), nil
+}
+
+// List retrieves all suppressions, or those of one subgraph when a name is given
+func (r *MemorySuppressionRepository) List(ctx context.Context, subgraphName string) ([]domain.Suppression, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	suppressions := r.filter(func(suppression *domain.Suppression) bool {
+		return subgraphName == "" || suppression.SubgraphName == subgraphName
+	})
+	sort.SliceStable(suppressions, func(i, j int) bool {
+		a, b := suppressions[i], suppressions[j]
+		if a.SubgraphName != b.SubgraphName {
+			return a.SubgraphName < b.SubgraphName
+		}
+		if a.RuleName != b.RuleName {
+			return a.RuleName < b.RuleName
+		}
+		return a.CoordinatePattern < b.CoordinatePattern
+	})
+	return suppressions, nil
+}
+
+// Update saves the changes to an existing suppression
+func (r *MemorySuppressionRepository) Update(ctx context.Context, suppression *domain.Suppression) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.suppressions[suppression.ID]
+	if !ok {
+		return fmt.Errorf("suppression with ID %s: %w", suppression.ID, domain.ErrSuppressionNotFound)
+	}
+
+	updated := copySuppression(suppression)
+	updated.CreatedAt = stored.CreatedAt
+	r.suppressions[suppression.ID] = updated
+	return nil
+}
+
+// Delete removes a suppression
+func (r *MemorySuppressionRepository) Delete(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.suppressions[id]; !ok {
+		return fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+	}
+	delete(r.suppressions, id)
+	return nil
+}
+
+// GetActive retrieves the suppressions of a subgraph that have not expired at the given time
+func (r *MemorySuppressionRepository) GetActive(ctx context.Context, subgraphName string, at time.Time) ([]domain.Suppression, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.filter(func(suppression *domain.Suppression) bool {
+		return suppression.SubgraphName == subgraphName && suppression.IsActive(at)
+	}), nil
+}
+
+// filter copies the matching suppressions in ID order
+func (r *MemorySuppressionRepository) filter(match func(suppression *domain.Suppression) bool) []domain.Suppression {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var suppressions []domain.Suppression
+	for _, suppression := range r.suppressions {
+		if match(suppression) {
+			suppressions = append(suppressions, *copySuppression(suppression))
+		}
+	}
+	sort.Slice(suppressions, func(i, j int) bool {
+		return lessID(suppressions[i].ID, suppressions[j].ID)
+	})
+	return suppressions
+}
+
+func copySuppression(suppression *domain.Suppression) *domain.Suppression {
+	c := *suppression
+	c.ExpiresAt = copyTime(suppression.ExpiresAt)
+	return &c
+}
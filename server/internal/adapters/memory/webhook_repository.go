@@ -0,0 +1,287 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryWebhookRepository implements the WebhookRepository interface in memory
+type MemoryWebhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   map[string]*domain.Webhook
+	deliveries map[string]*domain.WebhookDelivery
+
+	webhookIDs  sequence
+	deliveryIDs sequence
+}
+
+// NewMemoryWebhookRepository creates a new in-memory implementation of WebhookRepository
+func NewMemoryWebhookRepository() domain.WebhookRepository {
+	return &MemoryWebhookRepository{
+		webhooks:   make(map[string]*domain.Webhook),
+		deliveries: make(map[string]*domain.WebhookDelivery),
+	}
+}
+
+// StoreWebhook saves a new webhook
+func (r *MemoryWebhookRepository) StoreWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook.ID = r.webhookIDs.next()
+	r.webhooks[webhook.ID] = copyWebhook(webhook)
+	return nil
+}
+
+// GetWebhook retrieves a webhook by its ID
+func (r *MemoryWebhookRepository) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("webhook with ID %s: %w", id, domain.ErrWebhookNotFound)
+	}
+	return copyWebhook(webhook), nil
+}
+
+// ListWebhooks retrieves all webhooks
+func (r *MemoryWebhookRepository) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.filterWebhooks(func(webhook *domain.Webhook) bool { return true }), nil
+}
+
+// ListWebhooksForSubgraph retrieves the global webhooks and those of one subgraph
+func (r *MemoryWebhookRepository) ListWebhooksForSubgraph(ctx context.Context, subgraphName string) ([]domain.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.filterWebhooks(func(webhook *domain.Webhook) bool {
+		return webhook.SubgraphName == "" || webhook.SubgraphName == subgraphName
+	}), nil
+}
+
+// DeleteWebhook removes a webhook together with its deliveries
+func (r *MemoryWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return fmt.Errorf("webhook with ID %s: %w", id, domain.ErrWebhookNotFound)
+	}
+	delete(r.webhooks, id)
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.WebhookID == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// EnqueueDeliveries adds pending deliveries to the outbox
+func (r *MemoryWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check every delivery first so that either all or none are queued
+	for _, delivery := range deliveries {
+		if _, ok := r.webhooks[delivery.WebhookID]; !ok {
+			return fmt.Errorf("failed to insert webhook delivery: webhook with ID %s: %w",
+				delivery.WebhookID, domain.ErrWebhookNotFound)
+		}
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		delivery.ID = r.deliveryIDs.next()
+		r.deliveries[delivery.ID] = copyDelivery(delivery)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns due pending deliveries and postpones them by the lease.
+// The repository lock makes the claim exclusive.
+func (r *MemoryWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == domain.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return lessID(due[i].ID, due[j].ID)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	var claimed []domain.WebhookDelivery
+	for _, delivery := range due {
+		delivery.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, *copyDelivery(delivery))
+	}
+	return claimed, nil
+}
+
+// SaveDeliveryAttempt records an attempt and the resulting delivery state
+func (r *MemoryWebhookRepository) SaveDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery, attempt domain.DeliveryAttempt) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.deliveries[delivery.ID]
+	if !ok {
+		return fmt.Errorf("webhook delivery with ID %s: %w", delivery.ID, domain.ErrWebhookDeliveryNotFound)
+	}
+
+	attempt.StatusCode = copyInt(attempt.StatusCode)
+	stored.AttemptLog = append(stored.AttemptLog, attempt)
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.LastError = delivery.LastError
+	stored.LastStatusCode = copyInt(delivery.LastStatusCode)
+	stored.DeliveredAt = copyTime(delivery.DeliveredAt)
+	return nil
+}
+
+// GetDelivery retrieves a delivery with its attempts
+func (r *MemoryWebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, fmt.Errorf("webhook delivery with ID %s: %w", id, domain.ErrWebhookDeliveryNotFound)
+	}
+
+	c := copyDelivery(delivery)
+	for _, attempt := range delivery.AttemptLog {
+		attempt.StatusCode = copyInt(attempt.StatusCode)
+		c.AttemptLog = append(c.AttemptLog, attempt)
+	}
+	sort.SliceStable(c.AttemptLog, func(i, j int) bool {
+		return c.AttemptLog[i].Number < c.AttemptLog[j].Number
+	})
+	return c, nil
+}
+
+// ListDeliveries retrieves the most recent deliveries, optionally filtered by webhook and status
+func (r *MemoryWebhookRepository) ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]domain.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if (webhookID == "" || delivery.WebhookID == webhookID) && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, *copyDelivery(delivery))
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+		}
+		return lessID(deliveries[j].ID, deliveries[i].ID)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery makes a delivery pending again, due at the given time
+func (r *MemoryWebhookRepository) RetryDelivery(ctx context.Context, id string, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return fmt.Errorf("webhook delivery with ID %s: %w", id, domain.ErrWebhookDeliveryNotFound)
+	}
+	delivery.Status = domain.DeliveryStatusPending
+	delivery.NextAttemptAt = at
+	return nil
+}
+
+// filterWebhooks copies the matching webhooks in ID order
+func (r *MemoryWebhookRepository) filterWebhooks(match func(webhook *domain.Webhook) bool) []domain.Webhook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var webhooks []domain.Webhook
+	for _, webhook := range r.webhooks {
+		if match(webhook) {
+			webhooks = append(webhooks, *copyWebhook(webhook))
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return lessID(webhooks[i].ID, webhooks[j].ID)
+	})
+	return webhooks
+}
+
+func copyWebhook(webhook *domain.Webhook) *domain.Webhook {
+	c := *webhook
+	c.MinScore = copyFloat(webhook.MinScore)
+	c.MaxScoreDrop = copyFloat(webhook.MaxScoreDrop)
+	return &c
+}
+
+// copyDelivery copies a delivery without its attempts, like the list queries return it
+func copyDelivery(delivery *domain.WebhookDelivery) *domain.WebhookDelivery {
+	c := *delivery
+	c.Payload = append([]byte(nil), delivery.Payload...)
+	c.LastStatusCode = copyInt(delivery.LastStatusCode)
+	c.DeliveredAt = copyTime(delivery.DeliveredAt)
+	c.AttemptLog = nil
+	return &c
+}
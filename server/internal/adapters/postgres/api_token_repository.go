@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresAPITokenRepository implements the APITokenRepository interface using PostgreSQL
+type PostgresAPITokenRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAPITokenRepository creates a new PostgreSQL implementation of APITokenRepository
+func NewPostgresAPITokenRepository(db *sql.DB) domain.APITokenRepository {
+	return &PostgresAPITokenRepository{
+		db: db,
+	}
+}
+
+const apiTokenColumns = `id, name, prefix, token_hash, subgraphs, admin, created_at, last_used_at, revoked_at`
+
+// Store saves a new token to the database
+func (r *PostgresAPITokenRepository) Store(ctx context.Context, token *domain.APIToken) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO api_tokens (name, prefix, token_hash, subgraphs, admin, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		token.Name, token.Prefix, token.Hash, pq.Array(token.Subgraphs), token.Admin, token.CreatedAt,
+	).Scan(&token.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert API token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash retrieves a token by the hash of its plaintext value
+func (r *PostgresAPITokenRepository) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens WHERE token_hash = $1`, hash)
+
+	token, err := scanAPIToken(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("API token: %w", domain.ErrAPITokenNotFound)
+		}
+		return nil, fmt.Errorf("failed to query API token: %w", err)
+	}
+	return token, nil
+}
+
+// List retrieves all tokens
+func (r *PostgresAPITokenRepository) List(ctx context.Context) ([]domain.APIToken, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []domain.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, rows.Err()
+}
+
+// Revoke marks a token as revoked, keeping the original revocation time of revoked tokens
+func (r *PostgresAPITokenRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_tokens SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE id = $1`, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("API token with ID %s: %w", id, domain.ErrAPITokenNotFound))
+}
+
+// TouchLastUsed records when a token was last used
+func (r *PostgresAPITokenRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE api_tokens SET last_used_at = $2 WHERE id = $1`, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to update API token usage: %w", err)
+	}
+	return nil
+}
+
+func scanAPIToken(row rowScanner) (*domain.APIToken, error) {
+	var token domain.APIToken
+	err := row.Scan(&token.ID, &token.Name, &token.Prefix, &token.Hash, pq.Array(&token.Subgraphs), &token.Admin,
+		&token.CreatedAt, &token.LastUsedAt, &token.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -1,16 +1,19 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"io/fs"
 	"log"
-	"os"
-	"path/filepath"
-	"sort"
-	"strings"
+	"schema-score-server/migrations"
+	"time"
 )
 
+// migrationLockKey is the pg_advisory_lock key held while migrating, so that replicas
+// starting together apply each migration exactly once
+const migrationLockKey int64 = 5_829_174_310_442_077_311
+
 // Migrator handles database migrations
 type Migrator struct {
 	db *sql.DB
@@ -21,131 +24,257 @@ func NewMigrator(db *sql.DB) *Migrator {
 	return &Migrator{db: db}
 }
 
-// RunMigrations executes all pending migrations from the migrations directory
-func (m *Migrator) RunMigrations(migrationsPath string) error {
-	// Create migrations table if it doesn't exist
-	if err := m.createMigrationsTable(); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+// queryer is implemented by *sql.DB, *sql.Conn and *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// RunMigrations executes all pending migrations found in the migrations filesystem. It
+// refuses to run when a migration that was already applied has been modified since.
+func (m *Migrator) RunMigrations(fsys fs.FS) error {
+	ctx := context.Background()
+
+	files, err := migrations.Load(fsys)
+	if err != nil {
+		return fmt.Errorf("failed to get migration files: %w", err)
 	}
 
-	// Get list of applied migrations
-	applied, err := m.getAppliedMigrations()
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.statuses(ctx, conn, files)
+		if err != nil {
+			return err
+		}
+		if err := migrations.Verify(statuses); err != nil {
+			return err
+		}
+
+		applied := make(map[string]migrations.Status, len(statuses))
+		for _, status := range statuses {
+			if status.Applied {
+				applied[status.Name] = status
+			}
+			if status.Applied && status.Checksum == "" {
+				log.Printf("Warning: migration %s is applied but its file no longer exists", status.Name)
+			}
+		}
+
+		// Run pending migrations
+		for _, migration := range files {
+			if status, exists := applied[migration.Name]; exists {
+				// Migrations applied before checksums were stored adopt the current file
+				if status.AppliedChecksum == "" {
+					if err := m.recordChecksum(ctx, conn, migration); err != nil {
+						return fmt.Errorf("failed to record checksum of migration %s: %w", migration.Name, err)
+					}
+				}
+				log.Printf("Migration %s already applied, skipping", migration.Name)
+				continue
+			}
+
+			log.Printf("Running migration: %s", migration.Name)
+			if err := m.runMigration(ctx, conn, migration); err != nil {
+				return fmt.Errorf("failed to run migration %s: %w", migration.Name, err)
+			}
+			log.Printf("Migration %s completed successfully", migration.Name)
+		}
+
+		return nil
+	})
+}
+
+// Status lists every migration file and applied migration, in name order
+func (m *Migrator) Status(ctx context.Context, fsys fs.FS) ([]migrations.Status, error) {
+	files, err := migrations.Load(fsys)
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return nil, fmt.Errorf("failed to get migration files: %w", err)
 	}
+	return m.statuses(ctx, m.db, files)
+}
 
-	// Get migration files
-	files, err := m.getMigrationFiles(migrationsPath)
+// Rollback reverts the latest steps applied migrations with their down files, newest
+// first, and returns the names of the reverted migrations. Nothing is reverted when one
+// of them has no down file or was modified after it was applied.
+func (m *Migrator) Rollback(ctx context.Context, fsys fs.FS, steps int) ([]string, error) {
+	files, err := migrations.Load(fsys)
 	if err != nil {
-		return fmt.Errorf("failed to get migration files: %w", err)
+		return nil, fmt.Errorf("failed to get migration files: %w", err)
 	}
+	byName := make(map[string]migrations.Migration, len(files))
+	for _, migration := range files {
+		byName[migration.Name] = migration
+	}
+
+	var reverted []string
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.statuses(ctx, conn, files)
+		if err != nil {
+			return err
+		}
+		if err := migrations.Verify(statuses); err != nil {
+			return err
+		}
 
-	// Run pending migrations
-	for _, file := range files {
-		migrationName := m.getMigrationName(file)
-		if _, exists := applied[migrationName]; exists {
-			log.Printf("Migration %s already applied, skipping", migrationName)
-			continue
+		var pending []migrations.Migration
+		for i := len(statuses) - 1; i >= 0 && len(pending) < steps; i-- {
+			if !statuses[i].Applied {
+				continue
+			}
+			migration, ok := byName[statuses[i].Name]
+			if !ok || !migration.HasDown {
+				return fmt.Errorf("cannot roll back %s: %w", statuses[i].Name, migrations.ErrNoDownMigration)
+			}
+			pending = append(pending, migration)
 		}
 
-		log.Printf("Running migration: %s", migrationName)
-		if err := m.runMigration(file, migrationName); err != nil {
-			return fmt.Errorf("failed to run migration %s: %w", migrationName, err)
+		for _, migration := range pending {
+			log.Printf("Rolling back migration: %s", migration.Name)
+			if err := m.revertMigration(ctx, conn, migration); err != nil {
+				return fmt.Errorf("failed to roll back migration %s: %w", migration.Name, err)
+			}
+			reverted = append(reverted, migration.Name)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// AppliedVersion returns the name of the latest applied migration, or an empty string
+// when no migration has been applied
+func (m *Migrator) AppliedVersion(ctx context.Context) (string, error) {
+	var name string
+	err := m.db.QueryRowContext(ctx, `
+		SELECT migration_name FROM schema_migrations
+		ORDER BY migration_name DESC
+		LIMIT 1`).Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to query applied migration version: %w", err)
+	}
+	return name, nil
+}
+
+// withLock runs fn on a single connection that holds the migration advisory lock. The
+// lock is tied to the session, so it is released even when the process dies.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			log.Printf("Failed to release migration lock: %v", err)
 		}
-		log.Printf("Migration %s completed successfully", migrationName)
+	}()
+
+	return fn(conn)
+}
+
+// statuses merges the migration files with the schema_migrations table
+func (m *Migrator) statuses(ctx context.Context, q queryer, files []migrations.Migration) ([]migrations.Status, error) {
+	if err := m.createMigrationsTable(ctx, q); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	return nil
+	applied, err := m.getAppliedMigrations(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	return migrations.Statuses(files, applied), nil
 }
 
-// createMigrationsTable creates the migrations tracking table
-func (m *Migrator) createMigrationsTable() error {
+// createMigrationsTable creates the migrations tracking table, and adds the checksum
+// column to tables created by earlier versions
+func (m *Migrator) createMigrationsTable(ctx context.Context, q queryer) error {
 	query := `
 	CREATE TABLE IF NOT EXISTS schema_migrations (
 		id SERIAL PRIMARY KEY,
 		migration_name VARCHAR(255) UNIQUE NOT NULL,
 		applied_at TIMESTAMPTZ DEFAULT NOW()
-	)`
+	);
+	ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64)`
 
-	_, err := m.db.Exec(query)
+	_, err := q.ExecContext(ctx, query)
 	return err
 }
 
-// getAppliedMigrations returns a map of applied migration names
-func (m *Migrator) getAppliedMigrations() (map[string]bool, error) {
-	rows, err := m.db.Query("SELECT migration_name FROM schema_migrations")
+// getAppliedMigrations returns the applied migrations
+func (m *Migrator) getAppliedMigrations(ctx context.Context, q queryer) ([]migrations.AppliedMigration, error) {
+	rows, err := q.QueryContext(ctx, "SELECT migration_name, applied_at, COALESCE(checksum, '') FROM schema_migrations")
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	applied := make(map[string]bool)
+	var applied []migrations.AppliedMigration
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var migration migrations.AppliedMigration
+		var appliedAt sql.NullTime
+		if err := rows.Scan(&migration.Name, &appliedAt, &migration.Checksum); err != nil {
 			return nil, err
 		}
-		applied[name] = true
+		migration.AppliedAt = appliedAt.Time
+		applied = append(applied, migration)
 	}
 
 	return applied, rows.Err()
 }
 
-// getMigrationFiles returns sorted list of migration files
-func (m *Migrator) getMigrationFiles(migrationsPath string) ([]string, error) {
-	var files []string
-
-	err := filepath.WalkDir(migrationsPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !d.IsDir() && strings.HasSuffix(path, ".sql") {
-			files = append(files, path)
-		}
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Sort files to ensure consistent execution order
-	sort.Strings(files)
-	return files, nil
-}
-
-// getMigrationName extracts migration name from file path
-func (m *Migrator) getMigrationName(filePath string) string {
-	base := filepath.Base(filePath)
-	return strings.TrimSuffix(base, ".sql")
+// recordChecksum stores the checksum of a migration applied without one
+func (m *Migrator) recordChecksum(ctx context.Context, conn *sql.Conn, migration migrations.Migration) error {
+	_, err := conn.ExecContext(ctx,
+		"UPDATE schema_migrations SET checksum = $1 WHERE migration_name = $2 AND checksum IS NULL",
+		migration.Checksum, migration.Name)
+	return err
 }
 
 // runMigration executes a single migration file
-func (m *Migrator) runMigration(filePath, migrationName string) error {
-	// Read migration file
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to read migration file: %w", err)
-	}
-
+func (m *Migrator) runMigration(ctx context.Context, conn *sql.Conn, migration migrations.Migration) error {
 	// Start transaction
-	tx, err := m.db.Begin()
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Execute migration SQL
-	if _, err := tx.Exec(string(content)); err != nil {
+	if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
 		return fmt.Errorf("failed to execute migration SQL: %w", err)
 	}
 
 	// Record migration as applied
-	if _, err := tx.Exec("INSERT INTO schema_migrations (migration_name) VALUES ($1)", migrationName); err != nil {
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO schema_migrations (migration_name, applied_at, checksum) VALUES ($1, $2, $3)",
+		migration.Name, time.Now(), migration.Checksum); err != nil {
 		return fmt.Errorf("failed to record migration: %w", err)
 	}
 
 	// Commit transaction
 	return tx.Commit()
 }
+
+// revertMigration executes the down file of a migration and forgets that it was applied
+func (m *Migrator) revertMigration(ctx context.Context, conn *sql.Conn, migration migrations.Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("failed to execute down migration SQL: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE migration_name = $1", migration.Name); err != nil {
+		return fmt.Errorf("failed to remove migration record: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"schema-score-server/internal/domain"
+)
+
+// PostgresRescoreRepository implements the RescoreRepository interface using PostgreSQL
+type PostgresRescoreRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRescoreRepository creates a new PostgreSQL implementation of RescoreRepository
+func NewPostgresRescoreRepository(db *sql.DB) domain.RescoreRepository {
+	return &PostgresRescoreRepository{
+		db: db,
+	}
+}
+
+// SaveRulesetVersion saves a ruleset version unless it is stored already
+func (r *PostgresRescoreRepository) SaveRulesetVersion(ctx context.Context, version *domain.RulesetVersion) error {
+	weightsJSON, err := json.Marshal(version.Weights)
+	if err != nil {
+		return fmt.Errorf("failed to marshal weights: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO ruleset_versions (version, weights, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (version) DO NOTHING`,
+		version.Version, weightsJSON, version.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert ruleset version: %w", err)
+	}
+	return nil
+}
+
+// GetRulesetVersion retrieves a ruleset version
+func (r *PostgresRescoreRepository) GetRulesetVersion(ctx context.Context, version string) (*domain.RulesetVersion, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT version, weights, created_at
+		FROM ruleset_versions WHERE version = $1`, version)
+
+	ruleset, err := scanRulesetVersion(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ruleset version %s: %w", version, domain.ErrRulesetVersionNotFound)
+		}
+		return nil, fmt.Errorf("failed to query ruleset version: %w", err)
+	}
+	return ruleset, nil
+}
+
+// ListRulesetVersions retrieves all ruleset versions, newest first
+func (r *PostgresRescoreRepository) ListRulesetVersions(ctx context.Context) ([]domain.RulesetVersion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT version, weights, created_at
+		FROM ruleset_versions ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ruleset versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []domain.RulesetVersion
+	for rows.Next() {
+		ruleset, err := scanRulesetVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ruleset version: %w", err)
+		}
+		versions = append(versions, *ruleset)
+	}
+
+	return versions, rows.Err()
+}
+
+// SaveRescoredScores creates or replaces the scores of reports in one transaction
+func (r *PostgresRescoreRepository) SaveRescoredScores(ctx context.Context, scores []domain.RescoredScore) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO rescored_scores (report_id, ruleset_version, score, rescored_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (report_id, ruleset_version) DO UPDATE
+		SET score = EXCLUDED.score, rescored_at = EXCLUDED.rescored_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare rescored score insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, score := range scores {
+		if _, err := stmt.ExecContext(ctx, score.ReportID, score.RulesetVersion, score.Score, score.RescoredAt); err != nil {
+			return fmt.Errorf("failed to insert rescored score: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetRescoredScores retrieves the scores of the reports of a subgraph for a ruleset version
+func (r *PostgresRescoreRepository) GetRescoredScores(ctx context.Context, subgraphName, version string) ([]domain.RescoredScore, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT rs.report_id, sr.subgraph_name, rs.ruleset_version, rs.score, rs.rescored_at
+		FROM rescored_scores rs
+		JOIN schema_reports sr ON sr.id = rs.report_id
+		WHERE sr.subgraph_name = $1 AND rs.ruleset_version = $2
+		ORDER BY sr.timestamp, rs.report_id`, subgraphName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rescored scores: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []domain.RescoredScore
+	for rows.Next() {
+		var score domain.RescoredScore
+		if err := rows.Scan(&score.ReportID, &score.SubgraphName, &score.RulesetVersion, &score.Score, &score.RescoredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rescored score: %w", err)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, rows.Err()
+}
+
+func scanRulesetVersion(row rowScanner) (*domain.RulesetVersion, error) {
+	var ruleset domain.RulesetVersion
+	var weights []byte
+
+	if err := row.Scan(&ruleset.Version, &weights, &ruleset.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	ruleset.Weights = make(map[string]float64)
+	if len(weights) > 0 {
+		if err := json.Unmarshal(weights, &ruleset.Weights); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal weights: %w", err)
+		}
+	}
+
+	return &ruleset, nil
+}
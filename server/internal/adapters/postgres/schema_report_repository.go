@@ -1,10 +1,14 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"schema-score-server/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 // PostgresSchemaReportRepository implements the SchemaReportRepository interface using PostgreSQL
@@ -19,10 +23,15 @@ func NewPostgresSchemaReportRepository(db *sql.DB) domain.SchemaReportRepository
 	}
 }
 
-// Store saves a new schema report to the database
-func (r *PostgresSchemaReportRepository) Store(report *domain.SchemaReport) error {
+// Store saves a new schema report to the database. Rule results and violations are
+// written in bulk with COPY, so the number of round trips does not grow with the size
+// of the report. Everything keeps the UUID the domain assigned, and rows without one
+// get a new ID first.
+func (r *PostgresSchemaReportRepository) Store(ctx context.Context, report *domain.SchemaReport) error {
+	report.AssignIDs()
+
 	// Start transaction
-	tx, err := r.db.Begin()
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
@@ -31,79 +40,250 @@ func (r *PostgresSchemaReportRepository) Store(report *domain.SchemaReport) erro
 	// Insert schema report
 	metadataJSON, _ := json.Marshal(report.Metadata)
 
-	err = tx.QueryRow(`
-		INSERT INTO schema_reports (subgraph_name, score, total_fields, total_weighted_violations, timestamp, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at`,
-		report.SubgraphName, report.Score, report.TotalFields,
-		report.TotalWeightedViolations, report.Timestamp, metadataJSON,
-	).Scan(&report.ID, &report.CreatedAt)
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO schema_reports (id, subgraph_name, score, effective_score, suppressed_count,
+			total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			timestamp, metadata, api_token_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING created_at`,
+		report.ID, report.SubgraphName, report.Score, report.EffectiveScore, report.SuppressedCount,
+		report.TotalFields, report.TotalWeightedViolations, report.RecomputedScore, report.ScoreMismatch,
+		report.Timestamp, metadataJSON, report.APITokenID,
+	).Scan(&report.CreatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to insert schema report: %w", err)
 	}
 
-	// Insert rule results and violations
+	if err := r.insertRuleResults(ctx, tx, report); err != nil {
+		return err
+	}
+
+	if err := r.trackViolations(ctx, tx, report); err != nil {
+		return err
+	}
+
+	if err := r.insertViolations(ctx, tx, report); err != nil {
+		return err
+	}
+
+	// Resolve tracked violations of this subgraph that no longer occur. A report that
+	// arrives late does not resolve violations seen after it.
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tracked_violations
+		SET resolved_report_id = $2, resolved_at = $3
+		WHERE subgraph_name = $1 AND resolved_at IS NULL
+			AND last_seen_report_id IS DISTINCT FROM $2 AND last_seen_at <= $3`,
+		report.SubgraphName, report.ID, report.Timestamp)
+
+	if err != nil {
+		return fmt.Errorf("failed to resolve tracked violations: %w", err)
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertRuleResults copies the rule results of the report into rule_results. The
+// created_at column is left to its NOW() default, which is the transaction start and
+// therefore the same as the creation time of the report.
+func (r *PostgresSchemaReportRepository) insertRuleResults(ctx context.Context, tx *sql.Tx, report *domain.SchemaReport) error {
+	rows := make([][]interface{}, len(report.RuleResults))
 	for i := range report.RuleResults {
 		ruleResult := &report.RuleResults[i]
-		ruleResult.ReportID = report.ID
+		ruleResult.CreatedAt = report.CreatedAt
 
-		err = tx.QueryRow(`
-			INSERT INTO rule_results (report_id, rule_name, violation_count, message)
-			VALUES ($1, $2, $3, $4)
-			RETURNING id, created_at`,
-			ruleResult.ReportID, ruleResult.RuleName, len(ruleResult.Violations), ruleResult.Message,
-		).Scan(&ruleResult.ID, &ruleResult.CreatedAt)
-
-		if err != nil {
-			return fmt.Errorf("failed to insert rule result: %w", err)
+		rows[i] = []interface{}{
+			ruleResult.ID, ruleResult.ReportID, ruleResult.RuleName, len(ruleResult.Violations),
+			ruleResult.SuppressedCount, ruleResult.Message,
+			ruleResult.Weight, ruleResult.Category, ruleResult.Contribution,
 		}
+	}
 
-		// Insert violations
+	if err := copyRows(ctx, tx, "rule_results", []string{
+		"id", "report_id", "rule_name", "violation_count", "suppressed_count", "message",
+		"weight", "category", "contribution",
+	}, rows); err != nil {
+		return fmt.Errorf("failed to insert rule results: %w", err)
+	}
+
+	return nil
+}
+
+// insertViolations copies the violations of every rule result into violations. It
+// runs after trackViolations, which fills in the start of every violation's lifecycle.
+func (r *PostgresSchemaReportRepository) insertViolations(ctx context.Context, tx *sql.Tx, report *domain.SchemaReport) error {
+	count := 0
+	for _, ruleResult := range report.RuleResults {
+		count += len(ruleResult.Violations)
+	}
+
+	rows := make([][]interface{}, 0, count)
+	for i := range report.RuleResults {
+		ruleResult := &report.RuleResults[i]
 		for j := range ruleResult.Violations {
 			violation := &ruleResult.Violations[j]
-			violation.RuleResultID = ruleResult.ID
-
-			err = tx.QueryRow(`
-				INSERT INTO violations (rule_result_id, message, location_line, location_column, 
-					location_field, location_type, location_coordinate)
-				VALUES ($1, $2, $3, $4, $5, $6, $7)
-				RETURNING id, created_at`,
-				violation.RuleResultID, violation.Message, violation.LocationLine, violation.LocationColumn,
-				violation.LocationField, violation.LocationType, violation.LocationCoordinate,
-			).Scan(&violation.ID, &violation.CreatedAt)
-
-			if err != nil {
-				return fmt.Errorf("failed to insert violation: %w", err)
+			violation.CreatedAt = report.CreatedAt
+
+			var fingerprint *string
+			if violation.Fingerprint != "" {
+				fingerprint = &violation.Fingerprint
 			}
+
+			rows = append(rows, []interface{}{
+				violation.ID, violation.RuleResultID, violation.Message, violation.LocationLine,
+				violation.LocationColumn, violation.LocationField, violation.LocationType,
+				violation.LocationCoordinate, fingerprint, violation.FirstSeenReportID,
+				violation.FirstSeenAt, violation.Suppressed, violation.SuppressionID,
+			})
 		}
 	}
 
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	if err := copyRows(ctx, tx, "violations", []string{
+		"id", "rule_result_id", "message", "location_line", "location_column",
+		"location_field", "location_type", "location_coordinate",
+		"fingerprint", "first_seen_report_id", "first_seen_at", "suppressed", "suppression_id",
+	}, rows); err != nil {
+		return fmt.Errorf("failed to insert violations: %w", err)
+	}
+
+	return nil
+}
+
+// trackViolations records a sighting of every fingerprinted violation of the report in
+// tracked_violations with a single upsert and copies the start of each lifecycle onto
+// the violations. A violation that reappears after being resolved starts a new lifecycle.
+func (r *PostgresSchemaReportRepository) trackViolations(ctx context.Context, tx *sql.Tx, report *domain.SchemaReport) error {
+	// An upsert may touch every row only once, so violations that share a fingerprint
+	// are sent once. The last one wins, as it would with one upsert per violation.
+	var fingerprints, ruleNames, messages []string
+	var coordinates []sql.NullString
+	positions := make(map[string]int)
+
+	for _, ruleResult := range report.RuleResults {
+		for _, violation := range ruleResult.Violations {
+			if violation.Fingerprint == "" {
+				continue
+			}
+
+			var coordinate sql.NullString
+			if violation.LocationCoordinate != nil {
+				coordinate = sql.NullString{String: *violation.LocationCoordinate, Valid: true}
+			}
+
+			if i, ok := positions[violation.Fingerprint]; ok {
+				ruleNames[i], coordinates[i], messages[i] = ruleResult.RuleName, coordinate, violation.Message
+				continue
+			}
+			positions[violation.Fingerprint] = len(fingerprints)
+			fingerprints = append(fingerprints, violation.Fingerprint)
+			ruleNames = append(ruleNames, ruleResult.RuleName)
+			coordinates = append(coordinates, coordinate)
+			messages = append(messages, violation.Message)
+		}
+	}
+
+	if len(fingerprints) == 0 {
+		return nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO tracked_violations (fingerprint, subgraph_name, rule_name, location_coordinate, message,
+			first_seen_report_id, first_seen_at, last_seen_report_id, last_seen_at)
+		SELECT sighting.fingerprint, $1, sighting.rule_name, sighting.location_coordinate, sighting.message,
+			$2::uuid, $3::timestamptz, $2::uuid, $3::timestamptz
+		FROM unnest($4::text[], $5::text[], $6::text[], $7::text[])
+			AS sighting(fingerprint, rule_name, location_coordinate, message)
+		ON CONFLICT (fingerprint) DO UPDATE SET
+			first_seen_report_id = CASE WHEN EXCLUDED.first_seen_at >= tracked_violations.resolved_at
+				THEN EXCLUDED.first_seen_report_id ELSE tracked_violations.first_seen_report_id END,
+			first_seen_at = CASE WHEN EXCLUDED.first_seen_at >= tracked_violations.resolved_at
+				THEN EXCLUDED.first_seen_at ELSE tracked_violations.first_seen_at END,
+			last_seen_report_id = CASE WHEN EXCLUDED.last_seen_at >= tracked_violations.last_seen_at
+				THEN EXCLUDED.last_seen_report_id ELSE tracked_violations.last_seen_report_id END,
+			last_seen_at = GREATEST(EXCLUDED.last_seen_at, tracked_violations.last_seen_at),
+			message = CASE WHEN EXCLUDED.last_seen_at >= tracked_violations.last_seen_at
+				THEN EXCLUDED.message ELSE tracked_violations.message END,
+			resolved_report_id = CASE WHEN EXCLUDED.last_seen_at >= tracked_violations.resolved_at
+				THEN NULL ELSE tracked_violations.resolved_report_id END,
+			resolved_at = CASE WHEN EXCLUDED.last_seen_at >= tracked_violations.resolved_at
+				THEN NULL ELSE tracked_violations.resolved_at END
+		RETURNING fingerprint, first_seen_report_id, first_seen_at`,
+		report.SubgraphName, report.ID, report.Timestamp,
+		pq.Array(fingerprints), pq.Array(ruleNames), pq.Array(coordinates), pq.Array(messages),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to track violations: %w", err)
+	}
+	defer rows.Close()
+
+	type lifecycle struct {
+		reportID *string
+		at       time.Time
+	}
+	lifecycles := make(map[string]lifecycle, len(fingerprints))
+
+	for rows.Next() {
+		var fingerprint string
+		var firstSeenReportID sql.NullString
+		var firstSeenAt time.Time
+		if err := rows.Scan(&fingerprint, &firstSeenReportID, &firstSeenAt); err != nil {
+			return fmt.Errorf("failed to scan tracked violation: %w", err)
+		}
+
+		var reportID *string
+		if firstSeenReportID.Valid {
+			reportID = &firstSeenReportID.String
+		}
+		lifecycles[fingerprint] = lifecycle{reportID: reportID, at: firstSeenAt}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to track violations: %w", err)
+	}
+
+	for i := range report.RuleResults {
+		ruleResult := &report.RuleResults[i]
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			if found, ok := lifecycles[violation.Fingerprint]; ok {
+				violation.FirstSeenReportID = found.reportID
+				firstSeenAt := found.at
+				violation.FirstSeenAt = &firstSeenAt
+			}
+		}
 	}
 
 	return nil
 }
 
-// GetByID retrieves a schema report by its ID
-func (r *PostgresSchemaReportRepository) GetByID(id string) (*domain.SchemaReport, error) {
+// GetByID retrieves a schema report by its ID, or by the integer ID of a report stored
+// before IDs became UUIDs
+func (r *PostgresSchemaReportRepository) GetByID(ctx context.Context, rawID string) (*domain.SchemaReport, error) {
+	id, ok := domain.ParseReportID(rawID)
+	if !ok {
+		return nil, fmt.Errorf("report with ID %s: %w", rawID, domain.ErrReportNotFound)
+	}
+
 	// Get the report
 	var report domain.SchemaReport
 	var metadataBytes []byte
 
-	err := r.db.QueryRow(`
-		SELECT id, subgraph_name, score, total_fields, total_weighted_violations, 
-			   timestamp, metadata, created_at
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, metadata, api_token_id, created_at
 		FROM schema_reports WHERE id = $1`, id).Scan(
 		&report.ID, &report.SubgraphName, &report.Score,
-		&report.TotalFields, &report.TotalWeightedViolations,
-		&report.Timestamp, &metadataBytes, &report.CreatedAt)
+		&report.EffectiveScore, &report.SuppressedCount, &report.TotalFields, &report.TotalWeightedViolations,
+		&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &metadataBytes, &report.APITokenID, &report.CreatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("report with ID %s not found", id)
+			return nil, fmt.Errorf("report with ID %s: %w", rawID, domain.ErrReportNotFound)
 		}
 		return nil, fmt.Errorf("failed to query report: %w", err)
 	}
@@ -112,9 +292,10 @@ func (r *PostgresSchemaReportRepository) GetByID(id string) (*domain.SchemaRepor
 		json.Unmarshal(metadataBytes, &report.Metadata)
 	}
 
-	// Get rule results with violations
-	ruleRows, err := r.db.Query(`
-		SELECT id, rule_name, violation_count, message, created_at
+	// Get rule results
+	ruleRows, err := r.db.QueryContext(ctx, `
+		SELECT id, rule_name, violation_count, suppressed_count, message,
+			   weight, category, contribution, created_at
 		FROM rule_results WHERE report_id = $1
 		ORDER BY violation_count DESC, rule_name`, id)
 
@@ -123,51 +304,67 @@ func (r *PostgresSchemaReportRepository) GetByID(id string) (*domain.SchemaRepor
 	}
 	defer ruleRows.Close()
 
+	positions := make(map[string]int)
 	for ruleRows.Next() {
 		var ruleResult domain.RuleResult
 		err := ruleRows.Scan(&ruleResult.ID, &ruleResult.RuleName,
-			&ruleResult.ViolationCount, &ruleResult.Message, &ruleResult.CreatedAt)
+			&ruleResult.ViolationCount, &ruleResult.SuppressedCount, &ruleResult.Message,
+			&ruleResult.Weight, &ruleResult.Category, &ruleResult.Contribution, &ruleResult.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan rule result: %w", err)
 		}
 		ruleResult.ReportID = report.ID
 
-		// Get violations for this rule result
-		violationRows, err := r.db.Query(`
-			SELECT id, message, location_line, location_column, 
-				   location_field, location_type, location_coordinate, created_at
-			FROM violations WHERE rule_result_id = $1 
-			ORDER BY location_line, location_column`, ruleResult.ID)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to query violations: %w", err)
-		}
+		positions[ruleResult.ID] = len(report.RuleResults)
+		report.RuleResults = append(report.RuleResults, ruleResult)
+	}
+	if err := ruleRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rule results: %w", err)
+	}
 
-		for violationRows.Next() {
-			var violation domain.Violation
-			err := violationRows.Scan(&violation.ID, &violation.Message,
-				&violation.LocationLine, &violation.LocationColumn,
-				&violation.LocationField, &violation.LocationType,
-				&violation.LocationCoordinate, &violation.CreatedAt)
-			if err != nil {
-				return nil, fmt.Errorf("failed to scan violation: %w", err)
-			}
-			violation.RuleResultID = ruleResult.ID
+	// Get the violations of all rule results at once
+	violationRows, err := r.db.QueryContext(ctx, `
+		SELECT v.id, v.rule_result_id, v.message, v.location_line, v.location_column,
+			   v.location_field, v.location_type, v.location_coordinate,
+			   COALESCE(v.fingerprint, ''), v.first_seen_report_id, v.first_seen_at,
+			   v.suppressed, v.suppression_id, v.created_at
+		FROM violations v
+		JOIN rule_results rr ON rr.id = v.rule_result_id
+		WHERE rr.report_id = $1
+		ORDER BY v.rule_result_id, v.location_line, v.location_column`, id)
 
-			ruleResult.Violations = append(ruleResult.Violations, violation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query violations: %w", err)
+	}
+	defer violationRows.Close()
+
+	for violationRows.Next() {
+		var violation domain.Violation
+		err := violationRows.Scan(&violation.ID, &violation.RuleResultID, &violation.Message,
+			&violation.LocationLine, &violation.LocationColumn,
+			&violation.LocationField, &violation.LocationType,
+			&violation.LocationCoordinate, &violation.Fingerprint,
+			&violation.FirstSeenReportID, &violation.FirstSeenAt,
+			&violation.Suppressed, &violation.SuppressionID, &violation.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan violation: %w", err)
 		}
-		violationRows.Close()
 
-		report.RuleResults = append(report.RuleResults, ruleResult)
+		ruleResult := &report.RuleResults[positions[violation.RuleResultID]]
+		ruleResult.Violations = append(ruleResult.Violations, violation)
+	}
+	if err := violationRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read violations: %w", err)
 	}
 
 	return &report, nil
 }
 
 // GetRecentReports retrieves the most recent reports
-func (r *PostgresSchemaReportRepository) GetRecentReports(limit int) ([]domain.SchemaReport, error) {
-	rows, err := r.db.Query(`
-		SELECT id, subgraph_name, score, total_fields, total_weighted_violations, 
+func (r *PostgresSchemaReportRepository) GetRecentReports(ctx context.Context, limit int) ([]domain.SchemaReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
 			   timestamp, created_at
 		FROM schema_reports 
 		ORDER BY timestamp DESC 
@@ -182,8 +379,9 @@ func (r *PostgresSchemaReportRepository) GetRecentReports(limit int) ([]domain.S
 	for rows.Next() {
 		var report domain.SchemaReport
 		err := rows.Scan(&report.ID, &report.SubgraphName, &report.Score,
+			&report.EffectiveScore, &report.SuppressedCount,
 			&report.TotalFields, &report.TotalWeightedViolations,
-			&report.Timestamp, &report.CreatedAt)
+			&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan report: %w", err)
 		}
@@ -195,27 +393,16 @@ func (r *PostgresSchemaReportRepository) GetRecentReports(limit int) ([]domain.S
 }
 
 // GetReportsBySubgraph retrieves reports for a specific subgraph
-func (r *PostgresSchemaReportRepository) GetReportsBySubgraph(subgraphName string, limit int) ([]domain.SchemaReport, error) {
-	var rows *sql.Rows
-	var err error
-
-	if subgraphName == "Unknown" {
-		rows, err = r.db.Query(`
-			SELECT id, subgraph_name, score, total_fields, total_weighted_violations, 
-				   timestamp, created_at
-			FROM schema_reports 
-			WHERE subgraph_name IS NULL
-			ORDER BY timestamp DESC 
-			LIMIT $1`, limit)
-	} else {
-		rows, err = r.db.Query(`
-			SELECT id, subgraph_name, score, total_fields, total_weighted_violations, 
-				   timestamp, created_at
-			FROM schema_reports 
-			WHERE subgraph_name = $1 
-			ORDER BY timestamp DESC 
-			LIMIT $2`, subgraphName, limit)
-	}
+func (r *PostgresSchemaReportRepository) GetReportsBySubgraph(ctx context.Context, subgraphName string, limit int) ([]domain.SchemaReport, error) {
+	// "Unknown" needs no special case, reports sent without a subgraph name are stored under it
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, created_at
+		FROM schema_reports 
+		WHERE subgraph_name = $1 
+		ORDER BY timestamp DESC 
+		LIMIT $2`, subgraphName, limit)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query subgraph reports: %w", err)
@@ -226,8 +413,9 @@ func (r *PostgresSchemaReportRepository) GetReportsBySubgraph(subgraphName strin
 	for rows.Next() {
 		var report domain.SchemaReport
 		err := rows.Scan(&report.ID, &report.SubgraphName, &report.Score,
+			&report.EffectiveScore, &report.SuppressedCount,
 			&report.TotalFields, &report.TotalWeightedViolations,
-			&report.Timestamp, &report.CreatedAt)
+			&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan report: %w", err)
 		}
@@ -238,17 +426,80 @@ func (r *PostgresSchemaReportRepository) GetReportsBySubgraph(subgraphName strin
 	return reports, nil
 }
 
-// GetSubgraphSummaries retrieves aggregated data for all subgraphs
-func (r *PostgresSchemaReportRepository) GetSubgraphSummaries() ([]domain.SubgraphSummary, error) {
-	rows, err := r.db.Query(`
-		SELECT 
-			COALESCE(subgraph_name, 'Unknown') as name,
-			COUNT(*) as report_count,
-			MAX(score) as latest_score,
-			MAX(timestamp) as latest_report
-		FROM schema_reports 
-		GROUP BY subgraph_name 
-		ORDER BY latest_report DESC NULLS LAST`)
+// GetPreviousReport retrieves the most recent report of a subgraph from before the given time
+func (r *PostgresSchemaReportRepository) GetPreviousReport(ctx context.Context, subgraphName string, before time.Time) (*domain.SchemaReport, error) {
+	var report domain.SchemaReport
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, created_at
+		FROM schema_reports
+		WHERE subgraph_name = $1 AND timestamp < $2
+		ORDER BY timestamp DESC
+		LIMIT 1`, subgraphName, before).Scan(
+		&report.ID, &report.SubgraphName, &report.Score,
+		&report.EffectiveScore, &report.SuppressedCount,
+		&report.TotalFields, &report.TotalWeightedViolations,
+		&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no report of %s before %s: %w", subgraphName, before.Format(time.RFC3339), domain.ErrReportNotFound)
+		}
+		return nil, fmt.Errorf("failed to query previous report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// GetLatestReportOnBranch retrieves the most recent report of a subgraph created on the given branch
+// before the given time
+func (r *PostgresSchemaReportRepository) GetLatestReportOnBranch(ctx context.Context, subgraphName, branch string, before time.Time) (*domain.SchemaReport, error) {
+	var report domain.SchemaReport
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, created_at
+		FROM schema_reports
+		WHERE subgraph_name = $1 AND metadata->>'branch' = $2 AND timestamp < $3
+		ORDER BY timestamp DESC
+		LIMIT 1`, subgraphName, branch, before).Scan(
+		&report.ID, &report.SubgraphName, &report.Score,
+		&report.EffectiveScore, &report.SuppressedCount,
+		&report.TotalFields, &report.TotalWeightedViolations,
+		&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no report on branch %s: %w", branch, domain.ErrReportNotFound)
+		}
+		return nil, fmt.Errorf("failed to query latest report on branch: %w", err)
+	}
+
+	return &report, nil
+}
+
+// GetSubgraphSummaries retrieves aggregated data for all subgraphs in a single query. The
+// latest score and the trend come from the most recent report and the one before it.
+func (r *PostgresSchemaReportRepository) GetSubgraphSummaries(ctx context.Context) ([]domain.SubgraphSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH ranked AS (
+			SELECT
+				COALESCE(subgraph_name, 'Unknown') AS name,
+				score,
+				timestamp,
+				COUNT(*) OVER subgraph AS report_count,
+				LAG(score) OVER (subgraph ORDER BY timestamp, id) AS previous_score,
+				ROW_NUMBER() OVER (subgraph ORDER BY timestamp DESC, id DESC) AS recency
+			FROM schema_reports
+			WINDOW subgraph AS (PARTITION BY COALESCE(subgraph_name, 'Unknown'))
+		)
+		SELECT name, report_count, score, timestamp, previous_score
+		FROM ranked
+		WHERE recency = 1
+		ORDER BY timestamp DESC NULLS LAST`)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query subgraph summaries: %w", err)
@@ -258,22 +509,16 @@ func (r *PostgresSchemaReportRepository) GetSubgraphSummaries() ([]domain.Subgra
 	var summaries []domain.SubgraphSummary
 	for rows.Next() {
 		var summary domain.SubgraphSummary
+		var prevScore sql.NullFloat64
 
 		err := rows.Scan(&summary.Name, &summary.ReportCount,
-			&summary.LatestScore, &summary.LatestReport)
+			&summary.LatestScore, &summary.LatestReport, &prevScore)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan subgraph summary: %w", err)
 		}
 
-		// Calculate trend (simplified - just compare with previous report)
-		var prevScore sql.NullFloat64
-		err = r.db.QueryRow(`
-			SELECT score FROM schema_reports 
-			WHERE COALESCE(subgraph_name, 'Unknown') = $1 
-			ORDER BY timestamp DESC 
-			LIMIT 1 OFFSET 1`, summary.Name).Scan(&prevScore)
-
-		if err == nil && prevScore.Valid {
+		// Compare with the previous report
+		if prevScore.Valid {
 			if summary.LatestScore > prevScore.Float64 {
 				summary.Trend = "up"
 			} else if summary.LatestScore < prevScore.Float64 {
@@ -287,14 +532,190 @@ func (r *PostgresSchemaReportRepository) GetSubgraphSummaries() ([]domain.Subgra
 
 		summaries = append(summaries, summary)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read subgraph summaries: %w", err)
+	}
 
 	return summaries, nil
 }
 
+// latestReportIDsQuery selects the ID of the most recent report of every subgraph
+const latestReportIDsQuery = `
+	SELECT DISTINCT ON (COALESCE(subgraph_name, 'Unknown')) id
+	FROM schema_reports
+	ORDER BY COALESCE(subgraph_name, 'Unknown'), timestamp DESC, id DESC`
+
+// GetLatestReports retrieves the most recent report of every subgraph with its rule results
+func (r *PostgresSchemaReportRepository) GetLatestReports(ctx context.Context) ([]domain.SchemaReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, COALESCE(subgraph_name, 'Unknown'), score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, created_at
+		FROM schema_reports
+		WHERE id IN (`+latestReportIDsQuery+`)
+		ORDER BY COALESCE(subgraph_name, 'Unknown')`)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []domain.SchemaReport
+	for rows.Next() {
+		var report domain.SchemaReport
+		err := rows.Scan(&report.ID, &report.SubgraphName, &report.Score,
+			&report.EffectiveScore, &report.SuppressedCount,
+			&report.TotalFields, &report.TotalWeightedViolations,
+			&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read latest reports: %w", err)
+	}
+
+	ruleRows, err := r.db.QueryContext(ctx, `
+		SELECT id, report_id, rule_name, violation_count, suppressed_count, message,
+			   weight, category, contribution, created_at
+		FROM rule_results
+		WHERE report_id IN (`+latestReportIDsQuery+`)
+		ORDER BY report_id, rule_name`)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule results: %w", err)
+	}
+	defer ruleRows.Close()
+
+	if err := attachRuleResults(ruleRows, reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// GetReportsWithRuleResults retrieves every report of a subgraph, oldest first, with its rule results
+func (r *PostgresSchemaReportRepository) GetReportsWithRuleResults(ctx context.Context, subgraphName string) ([]domain.SchemaReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, created_at
+		FROM schema_reports
+		WHERE subgraph_name = $1
+		ORDER BY timestamp, id`, subgraphName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subgraph reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []domain.SchemaReport
+	for rows.Next() {
+		var report domain.SchemaReport
+		err := rows.Scan(&report.ID, &report.SubgraphName, &report.Score,
+			&report.EffectiveScore, &report.SuppressedCount,
+			&report.TotalFields, &report.TotalWeightedViolations,
+			&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read subgraph reports: %w", err)
+	}
+
+	ruleRows, err := r.db.QueryContext(ctx, `
+		SELECT rr.id, rr.report_id, rr.rule_name, rr.violation_count, rr.suppressed_count, rr.message,
+			   rr.weight, rr.category, rr.contribution, rr.created_at
+		FROM rule_results rr
+		JOIN schema_reports sr ON sr.id = rr.report_id
+		WHERE sr.subgraph_name = $1
+		ORDER BY rr.report_id, rr.rule_name`, subgraphName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule results: %w", err)
+	}
+	defer ruleRows.Close()
+
+	if err := attachRuleResults(ruleRows, reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// attachRuleResults adds the rule results of the rows to the reports they belong to
+func attachRuleResults(ruleRows *sql.Rows, reports []domain.SchemaReport) error {
+	positions := make(map[string]int, len(reports))
+	for i, report := range reports {
+		positions[report.ID] = i
+	}
+
+	for ruleRows.Next() {
+		var ruleResult domain.RuleResult
+		err := ruleRows.Scan(&ruleResult.ID, &ruleResult.ReportID, &ruleResult.RuleName,
+			&ruleResult.ViolationCount, &ruleResult.SuppressedCount, &ruleResult.Message,
+			&ruleResult.Weight, &ruleResult.Category, &ruleResult.Contribution, &ruleResult.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan rule result: %w", err)
+		}
+
+		// A report stored between the two queries can show up here without its report row
+		position, ok := positions[ruleResult.ReportID]
+		if !ok {
+			continue
+		}
+		reports[position].RuleResults = append(reports[position].RuleResults, ruleResult)
+	}
+	if err := ruleRows.Err(); err != nil {
+		return fmt.Errorf("failed to read rule results: %w", err)
+	}
+
+	return nil
+}
+
+// GetOpenViolations retrieves the unresolved tracked violations of a subgraph, oldest first
+func (r *PostgresSchemaReportRepository) GetOpenViolations(ctx context.Context, subgraphName string) ([]domain.TrackedViolation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT fingerprint, subgraph_name, rule_name, location_coordinate, message,
+			   COALESCE(first_seen_report_id::text, ''), first_seen_at,
+			   COALESCE(last_seen_report_id::text, ''), last_seen_at, resolved_at
+		FROM tracked_violations
+		WHERE subgraph_name = $1 AND resolved_at IS NULL
+		ORDER BY first_seen_at, rule_name`, subgraphName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open violations: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []domain.TrackedViolation
+	for rows.Next() {
+		var violation domain.TrackedViolation
+		err := rows.Scan(&violation.Fingerprint, &violation.SubgraphName, &violation.RuleName,
+			&violation.LocationCoordinate, &violation.Message,
+			&violation.FirstSeenReportID, &violation.FirstSeenAt,
+			&violation.LastSeenReportID, &violation.LastSeenAt, &violation.ResolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tracked violation: %w", err)
+		}
+
+		violations = append(violations, violation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read open violations: %w", err)
+	}
+
+	return violations, nil
+}
+
 // GetTotalReportCount returns the total number of reports
-func (r *PostgresSchemaReportRepository) GetTotalReportCount() (int, error) {
+func (r *PostgresSchemaReportRepository) GetTotalReportCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow("SELECT COUNT(*) FROM schema_reports").Scan(&count)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_reports").Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total report count: %w", err)
 	}
@@ -302,6 +723,6 @@ func (r *PostgresSchemaReportRepository) GetTotalReportCount() (int, error) {
 }
 
 // HealthCheck verifies the repository is accessible
-func (r *PostgresSchemaReportRepository) HealthCheck() error {
-	return r.db.Ping()
+func (r *PostgresSchemaReportRepository) HealthCheck(ctx context.Context) error {
+	return r.db.PingContext(ctx)
 }
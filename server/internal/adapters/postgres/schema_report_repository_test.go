@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"database/sql"
+	"os"
+	"schema-score-server/internal/adapters/repositorytest"
+	"schema-score-server/internal/domain"
+	"schema-score-server/migrations"
+	"testing"
+)
+
+// openTestDB opens the throwaway database of TEST_DATABASE_URL with empty report tables,
+// or skips the test when it is not set
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := NewMigrator(db).RunMigrations(migrations.FS); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	if _, err := db.Exec(`TRUNCATE schema_reports, rule_results, violations, tracked_violations RESTART IDENTITY CASCADE`); err != nil {
+		t.Fatalf("failed to empty report tables: %v", err)
+	}
+	return db
+}
+
+func TestPostgresSchemaReportRepository_Contract(t *testing.T) {
+	repositorytest.TestSchemaReportRepository(t, func(t *testing.T) domain.SchemaReportRepository {
+		return NewPostgresSchemaReportRepository(openTestDB(t))
+	})
+}
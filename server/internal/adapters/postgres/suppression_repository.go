@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"strconv"
+	"time"
+)
+
+// PostgresSuppressionRepository implements the SuppressionRepository interface using PostgreSQL
+type PostgresSuppressionRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSuppressionRepository creates a new PostgreSQL implementation of SuppressionRepository
+func NewPostgresSuppressionRepository(db *sql.DB) domain.SuppressionRepository {
+	return &PostgresSuppressionRepository{
+		db: db,
+	}
+}
+
+const suppressionColumns = `id, subgraph_name, rule_name, coordinate_pattern, reason, author,
+	expires_at, created_at, updated_at`
+
+// Store saves a new suppression to the database
+func (r *PostgresSuppressionRepository) Store(ctx context.Context, suppression *domain.Suppression) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO suppressions (subgraph_name, rule_name, coordinate_pattern, reason, author, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`,
+		suppression.SubgraphName, suppression.RuleName, suppression.CoordinatePattern,
+		suppression.Reason, suppression.Author, suppression.ExpiresAt,
+	).Scan(&suppression.ID, &suppression.CreatedAt, &suppression.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert suppression: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a suppression by its ID
+func (r *PostgresSuppressionRepository) GetByID(ctx context.Context, id string) (*domain.Suppression, error) {
+	key, ok := serialID(id)
+	if !ok {
+		return nil, fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+	}
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+suppressionColumns+` FROM suppressions WHERE id = $1`, key)
+
+	suppression, err := scanSuppression(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+		}
+		return nil, fmt.Errorf("failed to query suppression: %w", err)
+	}
+	return suppression, nil
+}
+
+// List retrieves all suppressions, or those of one subgraph when a name is given
+func (r *PostgresSuppressionRepository) List(ctx context.Context, subgraphName string) ([]domain.Suppression, error) {
+	var rows *sql.Rows
+	var err error
+
+	if subgraphName == "" {
+		rows, err = r.db.QueryContext(ctx, `
+			SELECT `+suppressionColumns+` FROM suppressions
+			ORDER BY subgraph_name, rule_name, coordinate_pattern`)
+	} else {
+		rows, err = r.db.QueryContext(ctx, `
+			SELECT `+suppressionColumns+` FROM suppressions
+			WHERE subgraph_name = $1
+			ORDER BY rule_name, coordinate_pattern`, subgraphName)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSuppressions(rows)
+}
+
+// Update saves the changes to an existing suppression
+func (r *PostgresSuppressionRepository) Update(ctx context.Context, suppression *domain.Suppression) error {
+	notFound := fmt.Errorf("suppression with ID %s: %w", suppression.ID, domain.ErrSuppressionNotFound)
+	key, ok := serialID(suppression.ID)
+	if !ok {
+		return notFound
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE suppressions
+		SET subgraph_name = $2, rule_name = $3, coordinate_pattern = $4, reason = $5,
+			author = $6, expires_at = $7, updated_at = $8
+		WHERE id = $1`,
+		key, suppression.SubgraphName, suppression.RuleName, suppression.CoordinatePattern,
+		suppression.Reason, suppression.Author, suppression.ExpiresAt, suppression.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to update suppression: %w", err)
+	}
+	return requireAffected(result, notFound)
+}
+
+// Delete removes a suppression
+func (r *PostgresSuppressionRepository) Delete(ctx context.Context, id string) error {
+	notFound := fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+	key, ok := serialID(id)
+	if !ok {
+		return notFound
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM suppressions WHERE id = $1`, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete suppression: %w", err)
+	}
+	return requireAffected(result, notFound)
+}
+
+// GetActive retrieves the suppressions of a subgraph that have not expired at the given time
+func (r *PostgresSuppressionRepository) GetActive(ctx context.Context, subgraphName string, at time.Time) ([]domain.Suppression, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+suppressionColumns+` FROM suppressions
+		WHERE subgraph_name = $1 AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY id`, subgraphName, at)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSuppressions(rows)
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSuppression(row rowScanner) (*domain.Suppression, error) {
+	var suppression domain.Suppression
+	err := row.Scan(&suppression.ID, &suppression.SubgraphName, &suppression.RuleName,
+		&suppression.CoordinatePattern, &suppression.Reason, &suppression.Author,
+		&suppression.ExpiresAt, &suppression.CreatedAt, &suppression.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+func scanSuppressions(rows *sql.Rows) ([]domain.Suppression, error) {
+	var suppressions []domain.Suppression
+	for rows.Next() {
+		suppression, err := scanSuppression(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan suppression: %w", err)
+		}
+		suppressions = append(suppressions, *suppression)
+	}
+	return suppressions, rows.Err()
+}
+
+// serialID parses the ID of a row with a SERIAL key. An ID that is not a number cannot
+// exist, so callers report it as not found instead of letting the query fail.
+func serialID(id string) (int32, bool) {
+	key, err := strconv.ParseInt(id, 10, 32)
+	return int32(key), err == nil
+}
+
+// requireAffected returns notFound when the statement did not touch any row
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
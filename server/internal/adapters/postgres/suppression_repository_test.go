@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// IDs that are not numbers never reach the database, so no database is needed
+func TestPostgresRepositories_NonNumericID(t *testing.T) {
+	ctx := context.Background()
+	suppressions := NewPostgresSuppressionRepository(nil)
+	webhooks := NewPostgresWebhookRepository(nil)
+
+	_, err := suppressions.GetByID(ctx, "abc")
+	assert.ErrorIs(t, err, domain.ErrSuppressionNotFound)
+	assert.ErrorIs(t, suppressions.Update(ctx, &domain.Suppression{ID: "abc"}), domain.ErrSuppressionNotFound)
+	assert.ErrorIs(t, suppressions.Delete(ctx, "99999999999"), domain.ErrSuppressionNotFound)
+
+	_, err = webhooks.GetWebhook(ctx, "abc")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	assert.ErrorIs(t, webhooks.DeleteWebhook(ctx, "abc"), domain.ErrWebhookNotFound)
+	_, err = webhooks.GetDelivery(ctx, "abc")
+	assert.ErrorIs(t, err, domain.ErrWebhookDeliveryNotFound)
+	assert.ErrorIs(t, webhooks.RetryDelivery(ctx, "abc", time.Now()), domain.ErrWebhookDeliveryNotFound)
+
+	deliveries, err := webhooks.ListDeliveries(ctx, "abc", "", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
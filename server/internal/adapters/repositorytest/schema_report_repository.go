@@ -0,0 +1,605 @@
+// Package repositorytest contains contract tests that every implementation of the domain
+// repositories must pass, so that the adapters and the in-memory repository behave the same.
+//
+// An adapter runs the suite from its own tests with a factory that returns an empty
+// repository for every subtest:
+//
+//	func TestSchemaReportRepositoryContract(t *testing.T) {
+//		repositorytest.TestSchemaReportRepository(t, func(t *testing.T) domain.SchemaReportRepository {
+//			return NewMemorySchemaReportRepository()
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"schema-score-server/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SchemaReportRepositoryFactory returns an empty repository. It is called once per subtest.
+type SchemaReportRepositoryFactory func(t *testing.T) domain.SchemaReportRepository
+
+// baseTime is the timestamp of the first report in every scenario. Times are whole
+// seconds so that no database rounds them.
+var baseTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// TestSchemaReportRepository verifies that a SchemaReportRepository stores and reads
+// reports with the semantics the domain services rely on
+func TestSchemaReportRepository(t *testing.T, newRepository SchemaReportRepositoryFactory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo domain.SchemaReportRepository)
+	}{
+		{"StoreAssignsIDs", testStoreAssignsIDs},
+		{"StoreKeepsIDs", testStoreKeepsIDs},
+		{"GetByID", testGetByID},
+		{"GetByIDLegacyID", testGetByIDLegacyID},
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"GetRecentReports", testGetRecentReports},
+		{"GetReportsBySubgraph", testGetReportsBySubgraph},
+		{"UnknownSubgraph", testUnknownSubgraph},
+		{"GetPreviousReport", testGetPreviousReport},
+		{"GetLatestReportOnBranch", testGetLatestReportOnBranch},
+		{"GetSubgraphSummaries", testGetSubgraphSummaries},
+		{"GetLatestReports", testGetLatestReports},
+		{"GetReportsWithRuleResults", testGetReportsWithRuleResults},
+		{"ViolationTracking", testViolationTracking},
+		{"Empty", testEmpty},
+		{"CancelledContext", testCancelledContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+func testStoreAssignsIDs(t *testing.T, repo domain.SchemaReportRepository) {
+	first := store(t, repo, newReport("user-service", 80, baseTime, nil,
+		newRuleResult("PII", "User.email", "User.phone")))
+	second := store(t, repo, newReport("user-service", 85, baseTime.Add(time.Hour), nil))
+
+	assert.NotEmpty(t, first.ID)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	ruleResult := first.RuleResults[0]
+	assert.NotEmpty(t, ruleResult.ID)
+	assert.Equal(t, first.ID, ruleResult.ReportID)
+	for _, violation := range ruleResult.Violations {
+		assert.NotEmpty(t, violation.ID)
+		assert.Equal(t, ruleResult.ID, violation.RuleResultID)
+		if assert.NotNil(t, violation.FirstSeenReportID) && assert.NotNil(t, violation.FirstSeenAt) {
+			assert.Equal(t, first.ID, *violation.FirstSeenReportID)
+			assert.True(t, baseTime.Equal(*violation.FirstSeenAt))
+		}
+	}
+	assert.NotEqual(t, ruleResult.Violations[0].ID, ruleResult.Violations[1].ID)
+}
+
+func testStoreKeepsIDs(t *testing.T, repo domain.SchemaReportRepository) {
+	report := newReport("user-service", 80, baseTime, nil, newRuleResult("PII", "User.email"))
+	report.AssignIDs()
+	reportID := report.ID
+	ruleResultID := report.RuleResults[0].ID
+	violationID := report.RuleResults[0].Violations[0].ID
+	store(t, repo, report)
+
+	// The IDs handed out before the report was stored are the ones it is read back with
+	stored, err := repo.GetByID(context.Background(), reportID)
+	if !assert.NoError(t, err) || !assert.Len(t, stored.RuleResults, 1) {
+		return
+	}
+	assert.Equal(t, reportID, stored.ID)
+	assert.Equal(t, ruleResultID, stored.RuleResults[0].ID)
+	assert.Equal(t, reportID, stored.RuleResults[0].ReportID)
+	if assert.Len(t, stored.RuleResults[0].Violations, 1) {
+		assert.Equal(t, violationID, stored.RuleResults[0].Violations[0].ID)
+		assert.Equal(t, ruleResultID, stored.RuleResults[0].Violations[0].RuleResultID)
+	}
+}
+
+func testGetByID(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	// The offset of the timestamp must not matter, only the instant
+	timestamp := baseTime.In(time.FixedZone("CET", 3600))
+	pii := newRuleResult("PII", "User.email", "User.phone")
+	// A violation without a location sorts after the located ones
+	pii.Violations = append([]domain.Violation{{Message: "Schema exposes personal data"}}, pii.Violations...)
+	pii.ViolationCount = len(pii.Violations)
+
+	report := newReport("user-service", 85.5, timestamp,
+		map[string]interface{}{"branch": "main", "commit": "abc123", "pipeline": float64(42)},
+		newRuleResult("Naming", "User.first_name"), pii, newRuleResult("Deprecation", "Query.old"))
+	report.EffectiveScore = 90
+	report.SuppressedCount = 1
+	report.VerifyScore(domain.DefaultRuleCatalog, domain.DefaultScoreTolerance)
+	store(t, repo, report)
+
+	stored, err := repo.GetByID(ctx, report.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, report.ID, stored.ID)
+	assert.Equal(t, "user-service", stored.SubgraphName)
+	assert.Equal(t, 85.5, stored.Score)
+	assert.Equal(t, 90.0, stored.EffectiveScore)
+	assert.Equal(t, 1, stored.SuppressedCount)
+	assert.Equal(t, 100, stored.TotalFields)
+	assert.Equal(t, 12.5, stored.TotalWeightedViolations)
+	assert.True(t, timestamp.Equal(stored.Timestamp))
+	assert.Equal(t, map[string]interface{}{"branch": "main", "commit": "abc123", "pipeline": float64(42)}, stored.Metadata)
+
+	// The rule results cost 10 + 10 × 3^1.5 + 5 weighted violations, far from the claimed 85.5
+	if assert.NotNil(t, stored.RecomputedScore) {
+		assert.InDelta(t, 100-(15+domain.WeightedViolations(10, 3)), *stored.RecomputedScore, 0.0001)
+	}
+	assert.True(t, stored.ScoreMismatch)
+	if recent, err := repo.GetRecentReports(ctx, 1); assert.NoError(t, err) && assert.Len(t, recent, 1) {
+		assert.True(t, recent[0].ScoreMismatch)
+		assert.Equal(t, stored.RecomputedScore, recent[0].RecomputedScore)
+	}
+
+	// Rule results are ordered by violation count, then by name
+	if !assert.Len(t, stored.RuleResults, 3) {
+		return
+	}
+	assert.Equal(t, "PII", stored.RuleResults[0].RuleName)
+	assert.Equal(t, 3, stored.RuleResults[0].ViolationCount)
+	assert.Equal(t, "PII violations", stored.RuleResults[0].Message)
+	assert.Equal(t, "Deprecation", stored.RuleResults[1].RuleName)
+	assert.Equal(t, "Naming", stored.RuleResults[2].RuleName)
+
+	// Weights are stored with two decimals, like the other weighted violations
+	assert.Equal(t, 10.0, stored.RuleResults[0].Weight)
+	assert.Equal(t, domain.RuleCategoryImportant, stored.RuleResults[0].Category)
+	assert.InDelta(t, domain.WeightedViolations(10, 3), stored.RuleResults[0].Contribution, 0.005)
+	assert.Equal(t, domain.RuleCategoryUncategorized, stored.RuleResults[2].Category)
+
+	// Violations are ordered by location with unlocated ones last
+	violations := stored.RuleResults[0].Violations
+	if assert.Len(t, violations, 3) {
+		assert.Equal(t, "User.email", *violations[0].LocationCoordinate)
+		assert.Equal(t, 1, *violations[0].LocationLine)
+		assert.Equal(t, "User.phone", *violations[1].LocationCoordinate)
+		assert.Nil(t, violations[2].LocationLine)
+		assert.Equal(t, "Schema exposes personal data", violations[2].Message)
+
+		assert.Equal(t, pii.Violations[1].Fingerprint, violations[0].Fingerprint)
+		if assert.NotNil(t, violations[0].FirstSeenReportID) {
+			assert.Equal(t, report.ID, *violations[0].FirstSeenReportID)
+		}
+	}
+}
+
+func testGetByIDLegacyID(t *testing.T, repo domain.SchemaReportRepository) {
+	// Reports stored before IDs became UUIDs are migrated to UUIDs holding their integer ID
+	report := newReport("user-service", 80, baseTime, nil)
+	report.ID = domain.LegacyID(123)
+	store(t, repo, report)
+
+	for _, id := range []string{"123", domain.LegacyID(123), strings.ToUpper(domain.LegacyID(123))} {
+		stored, err := repo.GetByID(context.Background(), id)
+		if assert.NoError(t, err, id) {
+			assert.Equal(t, domain.LegacyID(123), stored.ID)
+		}
+	}
+}
+
+func testGetByIDNotFound(t *testing.T, repo domain.SchemaReportRepository) {
+	store(t, repo, newReport("user-service", 80, baseTime, nil))
+
+	for _, id := range []string{"999999", domain.NewID(), "not-an-id", ""} {
+		report, err := repo.GetByID(context.Background(), id)
+		assert.ErrorIs(t, err, domain.ErrReportNotFound, id)
+		assert.Nil(t, report)
+	}
+}
+
+func testGetRecentReports(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	// Stored out of order and with different offsets
+	store(t, repo, newReport("user-service", 70, baseTime.Add(time.Hour).In(time.FixedZone("PST", -8*3600)), nil))
+	store(t, repo, newReport("order-service", 60, baseTime, nil))
+	store(t, repo, newReport("cart-service", 90, baseTime.Add(3*time.Hour), nil))
+	store(t, repo, newReport("user-service", 80, baseTime.Add(2*time.Hour).In(time.FixedZone("JST", 9*3600)), nil))
+
+	reports, err := repo.GetRecentReports(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{90, 80, 70, 60}, scores(reports))
+
+	reports, err = repo.GetRecentReports(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{90, 80}, scores(reports))
+}
+
+func testGetReportsBySubgraph(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	for i, score := range []float64{70, 75, 80} {
+		store(t, repo, newReport("user-service", score, baseTime.Add(time.Duration(i)*time.Hour), nil))
+		store(t, repo, newReport("order-service", score-50, baseTime.Add(time.Duration(i)*time.Hour), nil))
+	}
+
+	// Only the reports of the requested subgraph, newest first
+	reports, err := repo.GetReportsBySubgraph(ctx, "user-service", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{80, 75, 70}, scores(reports))
+	for _, report := range reports {
+		assert.Equal(t, "user-service", report.SubgraphName)
+	}
+
+	reports, err = repo.GetReportsBySubgraph(ctx, "user-service", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{80, 75}, scores(reports))
+
+	reports, err = repo.GetReportsBySubgraph(ctx, "missing-service", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func testUnknownSubgraph(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	// A report without a subgraph name is filed under "Unknown"
+	report := domain.NewSchemaReport("", nil, 65, 100, 12.5, baseTime, nil)
+	store(t, repo, report)
+	store(t, repo, newReport("user-service", 80, baseTime.Add(time.Hour), nil))
+
+	reports, err := repo.GetReportsBySubgraph(ctx, "Unknown", 10)
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, report.ID, reports[0].ID)
+		assert.Equal(t, "Unknown", reports[0].SubgraphName)
+	}
+
+	summaries, err := repo.GetSubgraphSummaries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-service", "Unknown"}, summaryNames(summaries))
+
+	latest, err := repo.GetLatestReports(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, latest, 2)
+}
+
+func testGetPreviousReport(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	first := store(t, repo, newReport("user-service", 80, baseTime, nil))
+	store(t, repo, newReport("user-service", 70, baseTime.Add(2*time.Hour), nil))
+	store(t, repo, newReport("order-service", 90, baseTime.Add(30*time.Minute), nil))
+	// Stored last, but sent before the report above
+	late := store(t, repo, newReport("user-service", 85, baseTime.Add(time.Hour), nil))
+
+	previous, err := repo.GetPreviousReport(ctx, "user-service", late.Timestamp)
+	if assert.NoError(t, err) {
+		assert.Equal(t, first.ID, previous.ID)
+		assert.Equal(t, 80.0, previous.Score)
+	}
+
+	previous, err = repo.GetPreviousReport(ctx, "user-service", baseTime.Add(3*time.Hour))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 70.0, previous.Score)
+	}
+
+	_, err = repo.GetPreviousReport(ctx, "user-service", first.Timestamp)
+	assert.ErrorIs(t, err, domain.ErrReportNotFound)
+}
+
+func testGetLatestReportOnBranch(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	older := store(t, repo, newReport("user-service", 80, baseTime, map[string]interface{}{"branch": "main"}))
+	onMain := store(t, repo, newReport("user-service", 85, baseTime.Add(time.Hour), map[string]interface{}{"branch": "main"}))
+	store(t, repo, newReport("user-service", 60, baseTime.Add(2*time.Hour), map[string]interface{}{"branch": "feature"}))
+	store(t, repo, newReport("order-service", 90, baseTime.Add(3*time.Hour), map[string]interface{}{"branch": "main"}))
+	head := store(t, repo, newReport("user-service", 70, baseTime.Add(4*time.Hour), map[string]interface{}{"branch": "main"}))
+
+	baseline, err := repo.GetLatestReportOnBranch(ctx, "user-service", "main", head.Timestamp)
+	if assert.NoError(t, err) {
+		assert.Equal(t, onMain.ID, baseline.ID)
+		assert.Equal(t, 85.0, baseline.Score)
+	}
+
+	// A report that arrives late is compared with the report before it, not the newest one
+	baseline, err = repo.GetLatestReportOnBranch(ctx, "user-service", "main", onMain.Timestamp)
+	if assert.NoError(t, err) {
+		assert.Equal(t, older.ID, baseline.ID)
+	}
+
+	baseline, err = repo.GetLatestReportOnBranch(ctx, "user-service", "main", head.Timestamp.Add(time.Minute))
+	if assert.NoError(t, err) {
+		assert.Equal(t, head.ID, baseline.ID)
+	}
+
+	_, err = repo.GetLatestReportOnBranch(ctx, "user-service", "main", older.Timestamp)
+	assert.ErrorIs(t, err, domain.ErrReportNotFound)
+
+	_, err = repo.GetLatestReportOnBranch(ctx, "user-service", "release", head.Timestamp)
+	assert.ErrorIs(t, err, domain.ErrReportNotFound)
+
+	_, err = repo.GetLatestReportOnBranch(ctx, "cart-service", "main", head.Timestamp)
+	assert.ErrorIs(t, err, domain.ErrReportNotFound)
+}
+
+func testGetSubgraphSummaries(t *testing.T, repo domain.SchemaReportRepository) {
+	// Improving subgraph
+	store(t, repo, newReport("order-service", 60, baseTime, nil))
+	store(t, repo, newReport("order-service", 70, baseTime.Add(4*time.Hour), nil))
+	// Declining subgraph whose best score is not its latest one
+	store(t, repo, newReport("user-service", 90, baseTime, nil))
+	store(t, repo, newReport("user-service", 95, baseTime.Add(time.Hour), nil))
+	store(t, repo, newReport("user-service", 80, baseTime.Add(3*time.Hour), nil))
+	// Unchanged subgraph
+	store(t, repo, newReport("cart-service", 75, baseTime, nil))
+	store(t, repo, newReport("cart-service", 75, baseTime.Add(2*time.Hour), nil))
+	// Subgraph with a single report
+	store(t, repo, newReport("search-service", 50, baseTime.Add(time.Hour), nil))
+
+	summaries, err := repo.GetSubgraphSummaries(context.Background())
+	assert.NoError(t, err)
+
+	// Most recently reported subgraph first
+	if !assert.Equal(t, []string{"order-service", "user-service", "cart-service", "search-service"}, summaryNames(summaries)) {
+		return
+	}
+
+	expected := []struct {
+		score  float64
+		count  int
+		trend  string
+		latest time.Time
+	}{
+		{70, 2, "up", baseTime.Add(4 * time.Hour)},
+		{80, 3, "down", baseTime.Add(3 * time.Hour)},
+		{75, 2, "stable", baseTime.Add(2 * time.Hour)},
+		{50, 1, "stable", baseTime.Add(time.Hour)},
+	}
+	for i, want := range expected {
+		summary := summaries[i]
+		assert.Equal(t, want.score, summary.LatestScore, summary.Name)
+		assert.Equal(t, want.count, summary.ReportCount, summary.Name)
+		assert.Equal(t, want.trend, summary.Trend, summary.Name)
+		assert.True(t, want.latest.Equal(summary.LatestReport), summary.Name)
+	}
+}
+
+func testGetLatestReports(t *testing.T, repo domain.SchemaReportRepository) {
+	store(t, repo, newReport("user-service", 90, baseTime, nil, newRuleResult("PII", "User.email")))
+	latest := store(t, repo, newReport("user-service", 80, baseTime.Add(time.Hour), nil,
+		newRuleResult("PII", "User.email", "User.phone"), newRuleResult("Naming", "User.first_name")))
+	store(t, repo, newReport("order-service", 70, baseTime, nil))
+
+	reports, err := repo.GetLatestReports(context.Background())
+	assert.NoError(t, err)
+
+	// One report per subgraph, ordered by subgraph name
+	if !assert.Len(t, reports, 2) {
+		return
+	}
+	assert.Equal(t, "order-service", reports[0].SubgraphName)
+	assert.Empty(t, reports[0].RuleResults)
+
+	assert.Equal(t, latest.ID, reports[1].ID)
+	assert.Equal(t, 80.0, reports[1].Score)
+
+	// Rule results ordered by name, without the individual violations
+	if assert.Len(t, reports[1].RuleResults, 2) {
+		assert.Equal(t, "Naming", reports[1].RuleResults[0].RuleName)
+		assert.Equal(t, "PII", reports[1].RuleResults[1].RuleName)
+		assert.Equal(t, 2, reports[1].RuleResults[1].ViolationCount)
+		for _, ruleResult := range reports[1].RuleResults {
+			assert.Empty(t, ruleResult.Violations)
+		}
+	}
+}
+
+func testGetReportsWithRuleResults(t *testing.T, repo domain.SchemaReportRepository) {
+	newest := store(t, repo, newReport("user-service", 80, baseTime.Add(time.Hour), nil,
+		newRuleResult("PII", "User.email", "User.phone"), newRuleResult("Naming", "User.first_name")))
+	oldest := store(t, repo, newReport("user-service", 90, baseTime, nil, newRuleResult("PII", "User.email")))
+	store(t, repo, newReport("order-service", 70, baseTime, nil, newRuleResult("PII", "Order.email")))
+
+	reports, err := repo.GetReportsWithRuleResults(context.Background(), "user-service")
+	assert.NoError(t, err)
+
+	// Every report of the subgraph, oldest first
+	if !assert.Len(t, reports, 2) {
+		return
+	}
+	assert.Equal(t, oldest.ID, reports[0].ID)
+	assert.Equal(t, newest.ID, reports[1].ID)
+	assert.Equal(t, 100, reports[1].TotalFields)
+
+	// Rule results ordered by name, with their weights but without the individual violations
+	assert.Len(t, reports[0].RuleResults, 1)
+	if assert.Len(t, reports[1].RuleResults, 2) {
+		assert.Equal(t, "Naming", reports[1].RuleResults[0].RuleName)
+		assert.Equal(t, "PII", reports[1].RuleResults[1].RuleName)
+		assert.Equal(t, 2, reports[1].RuleResults[1].ViolationCount)
+		assert.Equal(t, domain.DefaultRuleCatalog.Weight("PII"), reports[1].RuleResults[1].Weight)
+		for _, ruleResult := range reports[1].RuleResults {
+			assert.Empty(t, ruleResult.Violations)
+		}
+	}
+
+	reports, err = repo.GetReportsWithRuleResults(context.Background(), "missing-service")
+	assert.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func testViolationTracking(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	first := store(t, repo, newReport("user-service", 80, baseTime, nil,
+		newRuleResult("PII", "User.email", "User.phone"), newRuleResult("Naming", "User.first_name")))
+	store(t, repo, newReport("order-service", 70, baseTime, nil, newRuleResult("PII", "Order.email")))
+	second := store(t, repo, newReport("user-service", 85, baseTime.Add(24*time.Hour), nil,
+		newRuleResult("PII", "User.email"), newRuleResult("Deprecation", "Query.old")))
+
+	// A violation that is still present keeps its first sighting
+	for _, ruleResult := range second.RuleResults {
+		violation := ruleResult.Violations[0]
+		if *violation.LocationCoordinate == "User.email" {
+			assert.Equal(t, first.ID, *violation.FirstSeenReportID)
+			assert.True(t, baseTime.Equal(*violation.FirstSeenAt))
+		} else {
+			assert.Equal(t, second.ID, *violation.FirstSeenReportID)
+		}
+	}
+
+	// Violations missing from the latest report are resolved, oldest open violations first
+	open, err := repo.GetOpenViolations(ctx, "user-service")
+	assert.NoError(t, err)
+	if assert.Len(t, open, 2) {
+		assert.Equal(t, "User.email", *open[0].LocationCoordinate)
+		assert.Equal(t, "PII", open[0].RuleName)
+		assert.Equal(t, "user-service", open[0].SubgraphName)
+		assert.Equal(t, first.ID, open[0].FirstSeenReportID)
+		assert.Equal(t, second.ID, open[0].LastSeenReportID)
+		assert.True(t, baseTime.Equal(open[0].FirstSeenAt))
+		assert.True(t, baseTime.Add(24*time.Hour).Equal(open[0].LastSeenAt))
+		assert.Nil(t, open[0].ResolvedAt)
+
+		assert.Equal(t, "Query.old", *open[1].LocationCoordinate)
+	}
+
+	// Other subgraphs are not affected
+	open, err = repo.GetOpenViolations(ctx, "order-service")
+	assert.NoError(t, err)
+	assert.Len(t, open, 1)
+
+	// A resolved violation that comes back starts a new lifecycle
+	third := store(t, repo, newReport("user-service", 80, baseTime.Add(48*time.Hour), nil,
+		newRuleResult("PII", "User.email", "User.phone"), newRuleResult("Deprecation", "Query.old")))
+	for _, violation := range third.RuleResults[0].Violations {
+		switch *violation.LocationCoordinate {
+		case "User.phone":
+			assert.Equal(t, third.ID, *violation.FirstSeenReportID)
+		case "User.email":
+			assert.Equal(t, first.ID, *violation.FirstSeenReportID)
+		}
+	}
+
+	open, err = repo.GetOpenViolations(ctx, "user-service")
+	assert.NoError(t, err)
+	assert.Len(t, open, 3)
+
+	// A report that arrives late neither resolves violations seen after it, nor reopens
+	// violations resolved after it, nor moves their last sighting back
+	late := store(t, repo, newReport("user-service", 90, baseTime.Add(12*time.Hour), nil,
+		newRuleResult("PII", "User.email"), newRuleResult("Naming", "User.first_name")))
+	open, err = repo.GetOpenViolations(ctx, "user-service")
+	assert.NoError(t, err)
+	if assert.Len(t, open, 3) {
+		for _, violation := range open {
+			assert.NotEqual(t, "User.first_name", *violation.LocationCoordinate)
+			assert.Equal(t, third.ID, violation.LastSeenReportID)
+			assert.True(t, baseTime.Add(48*time.Hour).Equal(violation.LastSeenAt))
+		}
+	}
+	assert.Equal(t, first.ID, *late.RuleResults[0].Violations[0].FirstSeenReportID)
+}
+
+func testEmpty(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx := context.Background()
+
+	assert.NoError(t, repo.HealthCheck(ctx))
+
+	count, err := repo.GetTotalReportCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	reports, err := repo.GetRecentReports(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, reports)
+
+	summaries, err := repo.GetSubgraphSummaries(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, summaries)
+
+	latest, err := repo.GetLatestReports(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, latest)
+
+	open, err := repo.GetOpenViolations(ctx, "user-service")
+	assert.NoError(t, err)
+	assert.Empty(t, open)
+
+	store(t, repo, newReport("user-service", 80, baseTime, nil))
+	store(t, repo, newReport("order-service", 80, baseTime, nil))
+	count, err = repo.GetTotalReportCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func testCancelledContext(t *testing.T, repo domain.SchemaReportRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Error(t, repo.Store(ctx, newReport("user-service", 80, baseTime, nil)))
+	_, err := repo.GetRecentReports(ctx, 10)
+	assert.Error(t, err)
+
+	count, err := repo.GetTotalReportCount(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+// store saves a report and fails the test when that is not possible
+func store(t *testing.T, repo domain.SchemaReportRepository, report *domain.SchemaReport) *domain.SchemaReport {
+	t.Helper()
+	if err := repo.Store(context.Background(), report); err != nil {
+		t.Fatalf("failed to store report: %v", err)
+	}
+	return report
+}
+
+func newReport(subgraph string, score float64, timestamp time.Time, metadata map[string]interface{}, ruleResults ...domain.RuleResult) *domain.SchemaReport {
+	report := domain.NewSchemaReport("", &subgraph, score, 100, 12.5, timestamp, metadata)
+	for _, ruleResult := range ruleResults {
+		ruleResult.Weigh(domain.DefaultRuleCatalog, nil, nil)
+		report.AddRuleResult(ruleResult)
+	}
+	report.AssignFingerprints()
+	return report
+}
+
+// newRuleResult creates a rule result with one violation per coordinate, on consecutive lines
+func newRuleResult(rule string, coordinates ...string) domain.RuleResult {
+	ruleResult := domain.RuleResult{RuleName: rule, ViolationCount: len(coordinates), Message: rule + " violations"}
+	for i, coordinate := range coordinates {
+		line := i + 1
+		coordinate := coordinate
+		ruleResult.Violations = append(ruleResult.Violations, domain.Violation{
+			Message:            "Violation at " + coordinate,
+			LocationLine:       &line,
+			LocationCoordinate: &coordinate,
+		})
+	}
+	return ruleResult
+}
+
+func scores(reports []domain.SchemaReport) []float64 {
+	var result []float64
+	for _, report := range reports {
+		result = append(result, report.Score)
+	}
+	return result
+}
+
+func summaryNames(summaries []domain.SubgraphSummary) []string {
+	var names []string
+	for _, summary := range summaries {
+		names = append(names, summary.Name)
+	}
+	return names
+}
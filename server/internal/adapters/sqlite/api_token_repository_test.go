@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteAPITokenRepository(t *testing.T) {
+	repo := NewSQLiteAPITokenRepository(openTestDB(t))
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	token := &domain.APIToken{
+		Name:      "ci",
+		Prefix:    "ssk_abcd",
+		Hash:      "hash",
+		Subgraphs: []string{"user-service", "order-service"},
+		CreatedAt: now,
+	}
+	assert.NoError(t, repo.Store(ctx, token))
+	assert.NotEmpty(t, token.ID)
+
+	stored, err := repo.GetByHash(ctx, "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-service", "order-service"}, stored.Subgraphs)
+	assert.Nil(t, stored.LastUsedAt)
+	assert.False(t, stored.IsRevoked())
+	assert.False(t, stored.Admin)
+
+	admin := &domain.APIToken{Name: "ops", Prefix: "ssk_efgh", Hash: "admin-hash", Admin: true, CreatedAt: now}
+	assert.NoError(t, repo.Store(ctx, admin))
+	stored, err = repo.GetByHash(ctx, "admin-hash")
+	assert.NoError(t, err)
+	assert.True(t, stored.Admin)
+	assert.Empty(t, stored.Subgraphs)
+
+	assert.NoError(t, repo.TouchLastUsed(ctx, token.ID, now.Add(time.Minute)))
+	assert.NoError(t, repo.Revoke(ctx, token.ID, now.Add(time.Hour)))
+
+	tokens, err := repo.List(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, tokens, 2) {
+		assert.True(t, now.Add(time.Minute).Equal(*tokens[0].LastUsedAt))
+		assert.True(t, tokens[0].IsRevoked())
+	}
+
+	_, err = repo.GetByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrAPITokenNotFound)
+	assert.ErrorIs(t, repo.Revoke(ctx, "999", now), domain.ErrAPITokenNotFound)
+}
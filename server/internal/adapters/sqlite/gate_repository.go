@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"schema-score-server/internal/domain"
+)
+
+// SQLiteGateRepository implements the GateRepository interface using SQLite
+type SQLiteGateRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteGateRepository creates a new SQLite implementation of GateRepository
+func NewSQLiteGateRepository(db *sql.DB) domain.GateRepository {
+	return &SQLiteGateRepository{
+		db: db,
+	}
+}
+
+// GetGateConfig retrieves the gate configuration of a subgraph
+func (r *SQLiteGateRepository) GetGateConfig(ctx context.Context, subgraphName string) (*domain.GateConfig, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT subgraph_name, min_score, max_score_drop, default_branch, rule_max_violations, updated_at
+		FROM gate_configs WHERE subgraph_name = $1`, subgraphName)
+
+	config, err := scanGateConfig(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("gate config for subgraph %s: %w", subgraphName, domain.ErrGateConfigNotFound)
+		}
+		return nil, fmt.Errorf("failed to query gate config: %w", err)
+	}
+	return config, nil
+}
+
+// ListGateConfigs retrieves the gate configurations of all subgraphs
+func (r *SQLiteGateRepository) ListGateConfigs(ctx context.Context) ([]domain.GateConfig, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT subgraph_name, min_score, max_score_drop, default_branch, rule_max_violations, updated_at
+		FROM gate_configs ORDER BY subgraph_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gate configs: %w", err)
+	}
+	defer rows.Close()
+
+	var configs []domain.GateConfig
+	for rows.Next() {
+		config, err := scanGateConfig(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gate config: %w", err)
+		}
+		configs = append(configs, *config)
+	}
+
+	return configs, rows.Err()
+}
+
+// SaveGateConfig creates or replaces the gate configuration of a subgraph
+func (r *SQLiteGateRepository) SaveGateConfig(ctx context.Context, config *domain.GateConfig) error {
+	ruleMaxViolationsJSON, err := json.Marshal(config.RuleMaxViolations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule maximums: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO gate_configs (subgraph_name, min_score, max_score_drop, default_branch, rule_max_violations, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (subgraph_name) DO UPDATE SET
+			min_score = excluded.min_score,
+			max_score_drop = excluded.max_score_drop,
+			default_branch = excluded.default_branch,
+			rule_max_violations = excluded.rule_max_violations,
+			updated_at = excluded.updated_at`,
+		config.SubgraphName, config.MinScore, config.MaxScoreDrop, config.DefaultBranch,
+		string(ruleMaxViolationsJSON), utc(config.UpdatedAt))
+
+	if err != nil {
+		return fmt.Errorf("failed to save gate config: %w", err)
+	}
+	return nil
+}
+
+// DeleteGateConfig removes the gate configuration of a subgraph
+func (r *SQLiteGateRepository) DeleteGateConfig(ctx context.Context, subgraphName string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM gate_configs WHERE subgraph_name = $1`, subgraphName)
+	if err != nil {
+		return fmt.Errorf("failed to delete gate config: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("gate config for subgraph %s: %w", subgraphName, domain.ErrGateConfigNotFound))
+}
+
+func scanGateConfig(row rowScanner) (*domain.GateConfig, error) {
+	var config domain.GateConfig
+	var ruleMaxViolations string
+
+	err := row.Scan(&config.SubgraphName, &config.MinScore, &config.MaxScoreDrop,
+		&config.DefaultBranch, &ruleMaxViolations, &config.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	config.RuleMaxViolations = make(map[string]int)
+	if ruleMaxViolations != "" {
+		if err := json.Unmarshal([]byte(ruleMaxViolations), &config.RuleMaxViolations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rule maximums: %w", err)
+		}
+	}
+
+	return &config, nil
+}
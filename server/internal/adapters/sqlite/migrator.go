@@ -0,0 +1,270 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"schema-score-server/migrations"
+	"time"
+)
+
+// Migrator handles database migrations. SQLite has no advisory locks, but every
+// transaction takes the database write lock when it begins, so each migration checks
+// inside its transaction that no other process applied it in the meantime.
+type Migrator struct {
+	db *sql.DB
+}
+
+// NewMigrator creates a new migrator instance
+func NewMigrator(db *sql.DB) *Migrator {
+	return &Migrator{db: db}
+}
+
+// RunMigrations executes all pending migrations found in the migrations filesystem. It
+// refuses to run when a migration that was already applied has been modified since.
+func (m *Migrator) RunMigrations(fsys fs.FS) error {
+	ctx := context.Background()
+
+	files, err := migrations.Load(fsys)
+	if err != nil {
+		return fmt.Errorf("failed to get migration files: %w", err)
+	}
+
+	statuses, err := m.Status(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	if err := migrations.Verify(statuses); err != nil {
+		return err
+	}
+
+	applied := make(map[string]migrations.Status, len(statuses))
+	for _, status := range statuses {
+		if status.Applied {
+			applied[status.Name] = status
+		}
+		if status.Applied && status.Checksum == "" {
+			log.Printf("Warning: migration %s is applied but its file no longer exists", status.Name)
+		}
+	}
+
+	for _, migration := range files {
+		if status, exists := applied[migration.Name]; exists {
+			// Migrations applied before checksums were stored adopt the current file
+			if status.AppliedChecksum == "" {
+				if _, err := m.db.ExecContext(ctx,
+					"UPDATE schema_migrations SET checksum = $1 WHERE migration_name = $2 AND checksum IS NULL",
+					migration.Checksum, migration.Name); err != nil {
+					return fmt.Errorf("failed to record checksum of migration %s: %w", migration.Name, err)
+				}
+			}
+			log.Printf("Migration %s already applied, skipping", migration.Name)
+			continue
+		}
+
+		log.Printf("Running migration: %s", migration.Name)
+		ran, err := m.runMigration(ctx, migration)
+		if err != nil {
+			return fmt.Errorf("failed to run migration %s: %w", migration.Name, err)
+		}
+		if !ran {
+			log.Printf("Migration %s was applied by another process, skipping", migration.Name)
+			continue
+		}
+		log.Printf("Migration %s completed successfully", migration.Name)
+	}
+
+	return nil
+}
+
+// Status lists every migration file and applied migration, in name order
+func (m *Migrator) Status(ctx context.Context, fsys fs.FS) ([]migrations.Status, error) {
+	files, err := migrations.Load(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration files: %w", err)
+	}
+
+	if err := m.createMigrationsTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	applied, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	return migrations.Statuses(files, applied), nil
+}
+
+// Rollback reverts the latest steps applied migrations with their down files, newest
+// first, and returns the names of the reverted migrations. Nothing is reverted when one
+// of them has no down file or was modified after it was applied.
+func (m *Migrator) Rollback(ctx context.Context, fsys fs.FS, steps int) ([]string, error) {
+	files, err := migrations.Load(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration files: %w", err)
+	}
+	byName := make(map[string]migrations.Migration, len(files))
+	for _, migration := range files {
+		byName[migration.Name] = migration
+	}
+
+	statuses, err := m.Status(ctx, fsys)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrations.Verify(statuses); err != nil {
+		return nil, err
+	}
+
+	var pending []migrations.Migration
+	for i := len(statuses) - 1; i >= 0 && len(pending) < steps; i-- {
+		if !statuses[i].Applied {
+			continue
+		}
+		migration, ok := byName[statuses[i].Name]
+		if !ok || !migration.HasDown {
+			return nil, fmt.Errorf("cannot roll back %s: %w", statuses[i].Name, migrations.ErrNoDownMigration)
+		}
+		pending = append(pending, migration)
+	}
+
+	var reverted []string
+	for _, migration := range pending {
+		log.Printf("Rolling back migration: %s", migration.Name)
+		if err := m.revertMigration(ctx, migration); err != nil {
+			return reverted, fmt.Errorf("failed to roll back migration %s: %w", migration.Name, err)
+		}
+		reverted = append(reverted, migration.Name)
+	}
+	return reverted, nil
+}
+
+// AppliedVersion returns the name of the latest applied migration, or an empty string
+// when no migration has been applied
+func (m *Migrator) AppliedVersion(ctx context.Context) (string, error) {
+	var name string
+	err := m.db.QueryRowContext(ctx, `
+		SELECT migration_name FROM schema_migrations
+		ORDER BY migration_name DESC
+		LIMIT 1`).Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to query applied migration version: %w", err)
+	}
+	return name, nil
+}
+
+// createMigrationsTable creates the migrations tracking table, and adds the checksum
+// column to tables created by earlier versions. SQLite cannot add a column only if it
+// is missing, so the column is looked up first within the same transaction.
+func (m *Migrator) createMigrationsTable(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			migration_name VARCHAR(255) UNIQUE NOT NULL,
+			applied_at DATETIME NOT NULL,
+			checksum VARCHAR(64)
+		)`); err != nil {
+		return err
+	}
+
+	var hasChecksum bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM pragma_table_info('schema_migrations') WHERE name = 'checksum')`,
+	).Scan(&hasChecksum); err != nil {
+		return err
+	}
+	if !hasChecksum {
+		if _, err := tx.ExecContext(ctx, "ALTER TABLE schema_migrations ADD COLUMN checksum VARCHAR(64)"); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getAppliedMigrations returns the applied migrations
+func (m *Migrator) getAppliedMigrations(ctx context.Context) ([]migrations.AppliedMigration, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT migration_name, applied_at, COALESCE(checksum, '') FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []migrations.AppliedMigration
+	for rows.Next() {
+		var migration migrations.AppliedMigration
+		if err := rows.Scan(&migration.Name, &migration.AppliedAt, &migration.Checksum); err != nil {
+			return nil, err
+		}
+		applied = append(applied, migration)
+	}
+
+	return applied, rows.Err()
+}
+
+// runMigration executes a single migration file, unless another process applied it
+// after the pending migrations were listed. SQLite runs schema changes inside
+// transactions too, so a failed migration leaves nothing behind.
+func (m *Migrator) runMigration(ctx context.Context, migration migrations.Migration) (bool, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var applied bool
+	if err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE migration_name = $1)", migration.Name,
+	).Scan(&applied); err != nil {
+		return false, fmt.Errorf("failed to check migration: %w", err)
+	}
+	if applied {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+		return false, fmt.Errorf("failed to execute migration SQL: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (migration_name, applied_at, checksum) VALUES ($1, $2, $3)",
+		migration.Name, utc(time.Now()), migration.Checksum); err != nil {
+		return false, fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return true, tx.Commit()
+}
+
+// revertMigration executes the down file of a migration and forgets that it was applied
+func (m *Migrator) revertMigration(ctx context.Context, migration migrations.Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE migration_name = $1", migration.Name)
+	if err != nil {
+		return fmt.Errorf("failed to remove migration record: %w", err)
+	}
+	// Another process rolled it back already
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("failed to execute down migration SQL: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,416 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"schema-score-server/internal/domain"
+	"schema-score-server/migrations"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// migrationFiles copies the SQLite migrations into a filesystem the test can change
+func migrationFiles(t *testing.T) fstest.MapFS {
+	t.Helper()
+
+	files, err := migrations.Load(migrations.SQLite)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	fsys := fstest.MapFS{}
+	for _, migration := range files {
+		fsys[migration.Name+".sql"] = &fstest.MapFile{Data: []byte(migration.Up)}
+		if migration.HasDown {
+			fsys[migration.Name+".down.sql"] = &fstest.MapFile{Data: []byte(migration.Down)}
+		}
+	}
+	return fsys
+}
+
+func TestMigrator(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+
+	// Running the migrations again does nothing
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+
+	version, err := migrator.AppliedVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "012_api_token_admin", version)
+
+	statuses, err := migrator.Status(context.Background(), migrations.SQLite)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 12)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, status.Name)
+		assert.True(t, status.HasDown, status.Name)
+		assert.NotNil(t, status.AppliedAt, status.Name)
+		assert.Equal(t, status.Checksum, status.AppliedChecksum, status.Name)
+	}
+}
+
+func TestMigrator_RollbackAndReapply(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	ctx := context.Background()
+
+	// Data in the tables that are changed must not stop the rollback
+	repo := NewSQLiteSchemaReportRepository(db)
+	assert.NoError(t, repo.Store(ctx, newTestReport()))
+
+	reverted, err := migrator.Rollback(ctx, migrations.SQLite, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"012_api_token_admin", "011_rescoring", "010_rule_catalog", "009_score_verification", "008_rule_weights", "007_uuid_ids"}, reverted)
+
+	version, err := migrator.AppliedVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "006_api_tokens", version)
+
+	// Every down file works, all the way back to an empty database
+	reverted, err = migrator.Rollback(ctx, migrations.SQLite, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"006_api_tokens", "005_webhooks", "004_quality_gates", "003_suppressions", "002_violation_tracking", "001_initial"}, reverted)
+
+	var tables int
+	assert.NoError(t, db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')`).Scan(&tables))
+	assert.Equal(t, 0, tables)
+
+	reverted, err = migrator.Rollback(ctx, migrations.SQLite, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, reverted)
+
+	// The down files leave a schema the up files can migrate again
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+	assert.NoError(t, repo.Store(ctx, newTestReport()))
+}
+
+func TestMigrator_RollbackWithoutDownFile(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	fsys := migrationFiles(t)
+	delete(fsys, "006_api_tokens.down.sql")
+
+	// Nothing is reverted when one of the steps cannot be
+	reverted, err := migrator.Rollback(context.Background(), fsys, 7)
+	assert.ErrorIs(t, err, migrations.ErrNoDownMigration)
+	assert.Empty(t, reverted)
+
+	version, err := migrator.AppliedVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "012_api_token_admin", version)
+}
+
+func TestMigrator_RefusesModifiedMigration(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	fsys := migrationFiles(t)
+	fsys["003_suppressions.sql"].Data = append(fsys["003_suppressions.sql"].Data, []byte("\n-- changed")...)
+
+	err := migrator.RunMigrations(fsys)
+	assert.ErrorIs(t, err, migrations.ErrChecksumMismatch)
+	assert.ErrorContains(t, err, "003_suppressions")
+
+	_, err = migrator.Rollback(context.Background(), fsys, 1)
+	assert.ErrorIs(t, err, migrations.ErrChecksumMismatch)
+
+	statuses, err := migrator.Status(context.Background(), fsys)
+	assert.NoError(t, err)
+	for _, status := range statuses {
+		assert.Equal(t, status.Name == "003_suppressions", status.Drifted(), status.Name)
+	}
+}
+
+func TestMigrator_RecordsMissingChecksums(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+
+	// Databases migrated by earlier versions have no checksums
+	_, err := db.Exec("UPDATE schema_migrations SET checksum = NULL")
+	assert.NoError(t, err)
+
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+
+	statuses, err := migrator.Status(context.Background(), migrations.SQLite)
+	assert.NoError(t, err)
+	for _, status := range statuses {
+		assert.Equal(t, status.Checksum, status.AppliedChecksum, status.Name)
+	}
+}
+
+func TestMigrator_AppliesNewMigration(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	fsys := migrationFiles(t)
+	fsys["999_notes.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY)")}
+
+	assert.NoError(t, migrator.RunMigrations(fsys))
+
+	statuses, err := migrator.Status(context.Background(), fsys)
+	assert.NoError(t, err)
+	last := statuses[len(statuses)-1]
+	assert.Equal(t, "999_notes", last.Name)
+	assert.True(t, last.Applied)
+	assert.False(t, last.HasDown)
+
+	// The migration cannot be rolled back without a down file
+	_, err = migrator.Rollback(context.Background(), fsys, 1)
+	assert.ErrorIs(t, err, migrations.ErrNoDownMigration)
+}
+
+// newTestReport creates a report with a tracked violation
+func newTestReport() *domain.SchemaReport {
+	subgraph := "users"
+	coordinate := "User.name"
+	report := domain.NewSchemaReport("", &subgraph, 90, 100, 10, time.Now(), map[string]interface{}{"branch": "main"})
+	ruleResult := domain.RuleResult{
+		RuleName:       "field-descriptions",
+		ViolationCount: 1,
+		Violations:     []domain.Violation{{Message: "Missing description", LocationCoordinate: &coordinate}},
+	}
+	ruleResult.Weigh(domain.DefaultRuleCatalog, nil, nil)
+	report.AddRuleResult(ruleResult)
+	report.AssignFingerprints()
+	return report
+}
+
+func TestMigrator_UUIDIDs(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "schema-score.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator := NewMigrator(db)
+	ctx := context.Background()
+
+	// Store reports with integer IDs before the UUID migration
+	fsys := migrationFiles(t)
+	delete(fsys, "007_uuid_ids.sql")
+	delete(fsys, "007_uuid_ids.down.sql")
+	delete(fsys, "008_rule_weights.sql")
+	delete(fsys, "008_rule_weights.down.sql")
+	delete(fsys, "009_score_verification.sql")
+	delete(fsys, "009_score_verification.down.sql")
+	delete(fsys, "010_rule_catalog.sql")
+	delete(fsys, "010_rule_catalog.down.sql")
+	delete(fsys, "011_rescoring.sql")
+	delete(fsys, "011_rescoring.down.sql")
+	delete(fsys, "012_api_token_admin.sql")
+	delete(fsys, "012_api_token_admin.down.sql")
+	assert.NoError(t, migrator.RunMigrations(fsys))
+
+	now := utc(time.Now())
+	for _, statement := range []string{
+		`INSERT INTO schema_reports (id, subgraph_name, score, total_fields, total_weighted_violations, timestamp, created_at)
+			VALUES (1, 'users', 80, 10, 2, $1, $1), (2, 'users', 90, 10, 1, $1, $1)`,
+		`INSERT INTO rule_results (id, report_id, rule_name, violation_count, message, created_at)
+			VALUES (5, 2, 'field-descriptions', 1, '', $1)`,
+		`INSERT INTO violations (id, rule_result_id, message, fingerprint, first_seen_report_id, first_seen_at, created_at)
+			VALUES (31, 5, 'Missing description', 'abc', 1, $1, $1)`,
+		`INSERT INTO tracked_violations (fingerprint, subgraph_name, rule_name, message,
+				first_seen_report_id, first_seen_at, last_seen_report_id, last_seen_at)
+			VALUES ('abc', 'users', 'field-descriptions', 'Missing description', 1, $1, 2, $1)`,
+	} {
+		_, err := db.Exec(statement, now)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+	repo := NewSQLiteSchemaReportRepository(db)
+
+	// Links with the integer IDs keep working
+	report, err := repo.GetByID(ctx, "2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.LegacyID(2), report.ID)
+		if assert.Len(t, report.RuleResults, 1) && assert.Len(t, report.RuleResults[0].Violations, 1) {
+			assert.Equal(t, domain.LegacyID(5), report.RuleResults[0].ID)
+			violation := report.RuleResults[0].Violations[0]
+			assert.Equal(t, domain.LegacyID(31), violation.ID)
+			if assert.NotNil(t, violation.FirstSeenReportID) {
+				assert.Equal(t, domain.LegacyID(1), *violation.FirstSeenReportID)
+			}
+		}
+	}
+
+	open, err := repo.GetOpenViolations(ctx, "users")
+	if assert.NoError(t, err) && assert.Len(t, open, 1) {
+		assert.Equal(t, domain.LegacyID(1), open[0].FirstSeenReportID)
+		assert.Equal(t, domain.LegacyID(2), open[0].LastSeenReportID)
+	}
+
+	// Reports stored with a UUID are numbered after the existing ones when rolling back
+	stored := newTestReport()
+	assert.NoError(t, repo.Store(ctx, stored))
+	_, err = repo.GetByID(ctx, stored.ID)
+	assert.NoError(t, err)
+
+	reverted, err := migrator.Rollback(ctx, migrations.SQLite, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"012_api_token_admin", "011_rescoring", "010_rule_catalog", "009_score_verification", "008_rule_weights", "007_uuid_ids"}, reverted)
+
+	var reportIDs []int64
+	rows, err := db.Query("SELECT id FROM schema_reports ORDER BY id")
+	if assert.NoError(t, err) {
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			assert.NoError(t, rows.Scan(&id))
+			reportIDs = append(reportIDs, id)
+		}
+	}
+	assert.Equal(t, []int64{1, 2, 3}, reportIDs)
+
+	var firstSeen, lastSeen int64
+	assert.NoError(t, db.QueryRow(`
+		SELECT first_seen_report_id, last_seen_report_id FROM tracked_violations WHERE fingerprint = 'abc'`,
+	).Scan(&firstSeen, &lastSeen))
+	assert.Equal(t, int64(1), firstSeen)
+	assert.Equal(t, int64(2), lastSeen)
+
+	var ruleResultReportID int64
+	assert.NoError(t, db.QueryRow(`
+		SELECT report_id FROM rule_results WHERE rule_name = 'field-descriptions' AND id <> 5`,
+	).Scan(&ruleResultReportID))
+	assert.Equal(t, int64(3), ruleResultReportID)
+}
+
+func TestMigrator_RuleWeights(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	ctx := context.Background()
+
+	// Store rule results without weights before the migration
+	_, err := migrator.Rollback(ctx, migrations.SQLite, 5)
+	assert.NoError(t, err)
+
+	now := utc(time.Now())
+	reportID := domain.NewID()
+	for _, statement := range []string{
+		`INSERT INTO schema_reports (id, subgraph_name, score, total_fields, total_weighted_violations, timestamp, created_at)
+			VALUES ('` + reportID + `', 'users', 80, 100, 90, $1, $1)`,
+		`INSERT INTO rule_results (id, report_id, rule_name, violation_count, message, created_at)
+			VALUES ('` + domain.NewID() + `', '` + reportID + `', 'PII', 4, '', $1),
+				('` + domain.NewID() + `', '` + reportID + `', 'field-descriptions', 1, '', $1)`,
+	} {
+		_, err := db.Exec(statement, now)
+		assert.NoError(t, err)
+	}
+
+	// Existing rule results get the weights of the scorer's rules
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+	report, err := NewSQLiteSchemaReportRepository(db).GetByID(ctx, reportID)
+	if assert.NoError(t, err) && assert.Len(t, report.RuleResults, 2) {
+		assert.Equal(t, "PII", report.RuleResults[0].RuleName)
+		assert.Equal(t, 10.0, report.RuleResults[0].Weight)
+		assert.Equal(t, domain.RuleCategoryImportant, report.RuleResults[0].Category)
+		assert.InDelta(t, 80.0, report.RuleResults[0].Contribution, 0.0001)
+
+		assert.Equal(t, domain.DefaultRuleWeight, report.RuleResults[1].Weight)
+		assert.Equal(t, domain.RuleCategoryUncategorized, report.RuleResults[1].Category)
+		assert.InDelta(t, 10.0, report.RuleResults[1].Contribution, 0.0001)
+	}
+}
+
+func TestMigrator_ScoreVerification(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	ctx := context.Background()
+
+	// Store reports without a recomputed score before the migration
+	_, err := migrator.Rollback(ctx, migrations.SQLite, 4)
+	assert.NoError(t, err)
+
+	// PII: 10 × 4^1.5 = 80 weighted violations in 100 fields make a score of 20
+	now := utc(time.Now())
+	honest, inflated, empty := domain.NewID(), domain.NewID(), domain.NewID()
+	for _, statement := range []string{
+		`INSERT INTO schema_reports (id, subgraph_name, score, total_fields, total_weighted_violations, timestamp, created_at)
+			VALUES ('` + honest + `', 'users', 20, 100, 80, $1, $1),
+				('` + inflated + `', 'users', 100, 100, 0, $1, $1),
+				('` + empty + `', 'users', 0, 0, 0, $1, $1)`,
+		`INSERT INTO rule_results (id, report_id, rule_name, violation_count, message, weight, category, contribution, created_at)
+			VALUES ('` + domain.NewID() + `', '` + honest + `', 'PII', 4, '', 10, 'important', 80, $1),
+				('` + domain.NewID() + `', '` + inflated + `', 'PII', 4, '', 10, 'important', 80, $1)`,
+	} {
+		_, err := db.Exec(statement, now)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+	repo := NewSQLiteSchemaReportRepository(db)
+
+	for _, id := range []string{honest, inflated} {
+		report, err := repo.GetByID(ctx, id)
+		if assert.NoError(t, err) && assert.NotNil(t, report.RecomputedScore) {
+			assert.InDelta(t, 20.0, *report.RecomputedScore, 0.0001)
+			assert.Equal(t, id == inflated, report.ScoreMismatch)
+		}
+	}
+
+	// Reports without fields cannot be verified
+	report, err := repo.GetByID(ctx, empty)
+	if assert.NoError(t, err) {
+		assert.Nil(t, report.RecomputedScore)
+		assert.False(t, report.ScoreMismatch)
+	}
+}
+
+func TestMigrator_RuleCatalog(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(db)
+	ctx := context.Background()
+
+	// Store rule results of a rule the scorer does not define before the migration
+	_, err := migrator.Rollback(ctx, migrations.SQLite, 3)
+	assert.NoError(t, err)
+
+	now := utc(time.Now())
+	reportID := domain.NewID()
+	for _, statement := range []string{
+		`INSERT INTO schema_reports (id, subgraph_name, score, total_fields, total_weighted_violations, timestamp, created_at)
+			VALUES ('` + reportID + `', 'users', 80, 100, 20, $1, $1)`,
+		`INSERT INTO rule_results (id, report_id, rule_name, violation_count, message, weight, category, contribution, created_at)
+			VALUES ('` + domain.NewID() + `', '` + reportID + `', 'PII', 1, '', 10, 'important', 10, $1),
+				('` + domain.NewID() + `', '` + reportID + `', 'field-descriptions', 1, '', 7.5, 'uncategorized', 7.5, $1)`,
+	} {
+		_, err := db.Exec(statement, now)
+		assert.NoError(t, err)
+	}
+
+	// The catalog starts with the scorer's rules and registers the others for review
+	assert.NoError(t, migrator.RunMigrations(migrations.SQLite))
+	repo := NewSQLiteRuleRepository(db)
+
+	rules, err := repo.ListRules(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, rules, len(domain.DefaultRuleCatalog)+1)
+	for _, rule := range rules {
+		if definition, ok := domain.DefaultRuleCatalog[rule.Name]; ok {
+			assert.Equal(t, definition.Weight, rule.Weight, rule.Name)
+			assert.Equal(t, definition.Category, rule.Category, rule.Name)
+			assert.NotEmpty(t, rule.Description, rule.Name)
+			assert.NotEmpty(t, rule.FixExamples, rule.Name)
+			assert.False(t, rule.NeedsReview, rule.Name)
+		}
+	}
+
+	registered, err := repo.GetRule(ctx, "field-descriptions")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 7.5, registered.Weight)
+		assert.Equal(t, domain.RuleCategoryUncategorized, registered.Category)
+		assert.True(t, registered.NeedsReview)
+		assert.Empty(t, registered.FixExamples)
+		assert.False(t, registered.CreatedAt.IsZero())
+	}
+
+	pii, err := repo.GetRule(ctx, "PII")
+	if assert.NoError(t, err) && assert.Len(t, pii.FixExamples, 1) {
+		assert.Contains(t, pii.FixExamples[0].After, "@pii")
+	}
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteRescoreRepository_Rescore(t *testing.T) {
+	db := openTestDB(t)
+	reports := NewSQLiteSchemaReportRepository(db)
+	rules := NewSQLiteRuleRepository(db)
+	repo := NewSQLiteRescoreRepository(db)
+	service := domain.NewRescoreService(reports, rules, repo)
+	ctx := context.Background()
+
+	report := newTestReport()
+	assert.NoError(t, reports.Store(ctx, report))
+
+	// Rules the catalog does not know keep the weight the report was scored with
+	before, err := service.Rescore(ctx, domain.CurrentRulesetVersion, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, before.Rescored)
+
+	now := time.Now()
+	assert.NoError(t, rules.CreateRule(ctx, &domain.Rule{
+		Name: "field-descriptions", Weight: 4, Category: domain.RuleCategoryStyle,
+		FixExamples: []domain.RuleExample{}, CreatedAt: now, UpdatedAt: now,
+	}))
+
+	after, err := service.Rescore(ctx, "", "users")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, after.Rescored)
+	assert.NotEqual(t, before.RulesetVersion, after.RulesetVersion)
+
+	// Both versions keep their own score series
+	scores, err := repo.GetRescoredScores(ctx, "users", before.RulesetVersion)
+	if assert.NoError(t, err) && assert.Len(t, scores, 1) {
+		assert.Equal(t, report.ID, scores[0].ReportID)
+		assert.InDelta(t, 90, scores[0].Score, 0.001)
+	}
+	scores, err = repo.GetRescoredScores(ctx, "users", after.RulesetVersion)
+	if assert.NoError(t, err) && assert.Len(t, scores, 1) {
+		assert.InDelta(t, 96, scores[0].Score, 0.001)
+		assert.Equal(t, "users", scores[0].SubgraphName)
+	}
+
+	// Rescoring with a stored version replaces its scores
+	_, err = service.Rescore(ctx, before.RulesetVersion, "")
+	assert.NoError(t, err)
+	scores, err = repo.GetRescoredScores(ctx, "users", before.RulesetVersion)
+	assert.NoError(t, err)
+	assert.Len(t, scores, 1)
+
+	versions, err := repo.ListRulesetVersions(ctx)
+	if assert.NoError(t, err) && assert.Len(t, versions, 2) {
+		assert.Equal(t, 4.0, versions[0].Weights["field-descriptions"])
+	}
+
+	_, err = service.Rescore(ctx, "unknown", "")
+	assert.ErrorIs(t, err, domain.ErrRulesetVersionNotFound)
+}
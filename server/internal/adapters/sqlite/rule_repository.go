@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"schema-score-server/internal/domain"
+)
+
+// SQLiteRuleRepository implements the RuleRepository interface using SQLite
+type SQLiteRuleRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRuleRepository creates a new SQLite implementation of RuleRepository
+func NewSQLiteRuleRepository(db *sql.DB) domain.RuleRepository {
+	return &SQLiteRuleRepository{
+		db: db,
+	}
+}
+
+// GetRule retrieves a rule by its name
+func (r *SQLiteRuleRepository) GetRule(ctx context.Context, name string) (*domain.Rule, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT name, weight, category, description, rationale, fix_examples, needs_review, created_at, updated_at
+		FROM rules WHERE name = $1`, name)
+
+	rule, err := scanRule(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("rule %s: %w", name, domain.ErrRuleNotFound)
+		}
+		return nil, fmt.Errorf("failed to query rule: %w", err)
+	}
+	return rule, nil
+}
+
+// ListRules retrieves all rules ordered by name
+func (r *SQLiteRuleRepository) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT name, weight, category, description, rationale, fix_examples, needs_review, created_at, updated_at
+		FROM rules ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []domain.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// CreateRule saves a new rule, failing when the catalog already has a rule of that name
+func (r *SQLiteRuleRepository) CreateRule(ctx context.Context, rule *domain.Rule) error {
+	result, err := insertRule(ctx, r.db, rule)
+	if err != nil {
+		return fmt.Errorf("failed to insert rule: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("rule %s: %w", rule.Name, domain.ErrRuleExists))
+}
+
+// UpdateRule saves the changes to an existing rule, keeping its creation time
+func (r *SQLiteRuleRepository) UpdateRule(ctx context.Context, rule *domain.Rule) error {
+	fixExamplesJSON, err := json.Marshal(rule.FixExamples)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fix examples: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE rules SET weight = $2, category = $3, description = $4, rationale = $5,
+			fix_examples = $6, needs_review = $7, updated_at = $8
+		WHERE name = $1`,
+		rule.Name, rule.Weight, rule.Category, rule.Description, rule.Rationale,
+		string(fixExamplesJSON), rule.NeedsReview, utc(rule.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("failed to update rule: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("rule %s: %w", rule.Name, domain.ErrRuleNotFound))
+}
+
+// DeleteRule removes a rule
+func (r *SQLiteRuleRepository) DeleteRule(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM rules WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("rule %s: %w", name, domain.ErrRuleNotFound))
+}
+
+// RegisterRules adds the rules the catalog does not have yet in one transaction
+func (r *SQLiteRuleRepository) RegisterRules(ctx context.Context, rules []domain.Rule) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var registered []string
+	for i := range rules {
+		result, err := insertRule(ctx, tx, &rules[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert rule: %w", err)
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to get affected rows: %w", err)
+		} else if affected > 0 {
+			registered = append(registered, rules[i].Name)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return registered, nil
+}
+
+// insertRule inserts a rule unless the catalog already has a rule of that name
+func insertRule(ctx context.Context, db execer, rule *domain.Rule) (sql.Result, error) {
+	fixExamplesJSON, err := json.Marshal(rule.FixExamples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fix examples: %w", err)
+	}
+
+	return db.ExecContext(ctx, `
+		INSERT INTO rules (name, weight, category, description, rationale, fix_examples, needs_review, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (name) DO NOTHING`,
+		rule.Name, rule.Weight, rule.Category, rule.Description, rule.Rationale,
+		string(fixExamplesJSON), rule.NeedsReview, utc(rule.CreatedAt), utc(rule.UpdatedAt))
+}
+
+func scanRule(row rowScanner) (*domain.Rule, error) {
+	var rule domain.Rule
+	var fixExamples string
+
+	err := row.Scan(&rule.Name, &rule.Weight, &rule.Category, &rule.Description, &rule.Rationale,
+		&fixExamples, &rule.NeedsReview, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.FixExamples = []domain.RuleExample{}
+	if fixExamples != "" {
+		if err := json.Unmarshal([]byte(fixExamples), &rule.FixExamples); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fix examples: %w", err)
+		}
+	}
+
+	return &rule, nil
+}
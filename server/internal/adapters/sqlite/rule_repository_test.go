@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteRuleRepository_CRUD(t *testing.T) {
+	repo := NewSQLiteRuleRepository(openTestDB(t))
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	rule := &domain.Rule{
+		Name:        "Field Descriptions",
+		Weight:      2.5,
+		Category:    domain.RuleCategoryStyle,
+		Description: "Flags fields without a description.",
+		FixExamples: []domain.RuleExample{{Title: "Describe the field", After: "\"The email address\"\nemail: String!"}},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	assert.NoError(t, repo.CreateRule(ctx, rule))
+	assert.ErrorIs(t, repo.CreateRule(ctx, rule), domain.ErrRuleExists)
+
+	stored, err := repo.GetRule(ctx, "Field Descriptions")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2.5, stored.Weight)
+		assert.Equal(t, rule.FixExamples, stored.FixExamples)
+		assert.True(t, now.Equal(stored.CreatedAt))
+	}
+
+	// Updates keep the creation time
+	updated := *rule
+	updated.Weight = 4
+	updated.CreatedAt = time.Time{}
+	updated.UpdatedAt = now.Add(time.Hour)
+	assert.NoError(t, repo.UpdateRule(ctx, &updated))
+
+	stored, err = repo.GetRule(ctx, "Field Descriptions")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4.0, stored.Weight)
+		assert.True(t, now.Equal(stored.CreatedAt))
+		assert.True(t, now.Add(time.Hour).Equal(stored.UpdatedAt))
+	}
+
+	missing := domain.Rule{Name: "Missing", UpdatedAt: now}
+	assert.ErrorIs(t, repo.UpdateRule(ctx, &missing), domain.ErrRuleNotFound)
+
+	assert.NoError(t, repo.DeleteRule(ctx, "Field Descriptions"))
+	assert.ErrorIs(t, repo.DeleteRule(ctx, "Field Descriptions"), domain.ErrRuleNotFound)
+	_, err = repo.GetRule(ctx, "Field Descriptions")
+	assert.ErrorIs(t, err, domain.ErrRuleNotFound)
+}
+
+func TestSQLiteRuleRepository_RegisterRules(t *testing.T) {
+	repo := NewSQLiteRuleRepository(openTestDB(t))
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Rules of the catalog are left untouched
+	registered, err := repo.RegisterRules(ctx, []domain.Rule{
+		{Name: "PII", Weight: 1, Category: domain.RuleCategoryUncategorized, NeedsReview: true, CreatedAt: now, UpdatedAt: now},
+		{Name: "Field Descriptions", Weight: 3, Category: domain.RuleCategoryUncategorized, NeedsReview: true, CreatedAt: now, UpdatedAt: now},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Field Descriptions"}, registered)
+
+	pii, err := repo.GetRule(ctx, "PII")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 10.0, pii.Weight)
+		assert.False(t, pii.NeedsReview)
+	}
+
+	rule, err := repo.GetRule(ctx, "Field Descriptions")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3.0, rule.Weight)
+		assert.True(t, rule.NeedsReview)
+	}
+
+	registered, err = repo.RegisterRules(ctx, []domain.Rule{{Name: "Field Descriptions", CreatedAt: now, UpdatedAt: now}})
+	assert.NoError(t, err)
+	assert.Empty(t, registered)
+}
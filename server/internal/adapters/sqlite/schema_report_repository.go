@@ -0,0 +1,591 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"time"
+)
+
+// SQLiteSchemaReportRepository implements the SchemaReportRepository interface using SQLite
+type SQLiteSchemaReportRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSchemaReportRepository creates a new SQLite implementation of SchemaReportRepository
+func NewSQLiteSchemaReportRepository(db *sql.DB) domain.SchemaReportRepository {
+	return &SQLiteSchemaReportRepository{
+		db: db,
+	}
+}
+
+const reportColumns = `id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+	total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+	timestamp, created_at`
+
+// Store saves a new schema report to the database. SQLite runs in process, so the rule
+// results and violations are inserted one statement at a time within one transaction.
+// Everything keeps the UUID the domain assigned, and rows without one get a new ID first.
+func (r *SQLiteSchemaReportRepository) Store(ctx context.Context, report *domain.SchemaReport) error {
+	report.AssignIDs()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	metadataJSON, _ := json.Marshal(report.Metadata)
+	report.CreatedAt = utc(time.Now())
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO schema_reports (id, subgraph_name, score, effective_score, suppressed_count,
+			total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			timestamp, metadata, api_token_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		report.ID, report.SubgraphName, report.Score, report.EffectiveScore, report.SuppressedCount,
+		report.TotalFields, report.TotalWeightedViolations, report.RecomputedScore, report.ScoreMismatch,
+		utc(report.Timestamp), string(metadataJSON), report.APITokenID, report.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert schema report: %w", err)
+	}
+
+	insertRuleResult, err := tx.PrepareContext(ctx, `
+		INSERT INTO rule_results (id, report_id, rule_name, violation_count, suppressed_count, message,
+			weight, category, contribution, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare rule result insert: %w", err)
+	}
+	defer insertRuleResult.Close()
+
+	insertViolation, err := tx.PrepareContext(ctx, `
+		INSERT INTO violations (id, rule_result_id, message, location_line, location_column,
+			location_field, location_type, location_coordinate,
+			fingerprint, first_seen_report_id, first_seen_at, suppressed, suppression_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare violation insert: %w", err)
+	}
+	defer insertViolation.Close()
+
+	for i := range report.RuleResults {
+		ruleResult := &report.RuleResults[i]
+		ruleResult.CreatedAt = report.CreatedAt
+
+		_, err = insertRuleResult.ExecContext(ctx,
+			ruleResult.ID, ruleResult.ReportID, ruleResult.RuleName, len(ruleResult.Violations),
+			ruleResult.SuppressedCount, ruleResult.Message,
+			ruleResult.Weight, ruleResult.Category, ruleResult.Contribution, ruleResult.CreatedAt,
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to insert rule result: %w", err)
+		}
+
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			violation.CreatedAt = report.CreatedAt
+
+			if violation.Fingerprint != "" {
+				if err := r.trackViolation(ctx, tx, report, ruleResult.RuleName, violation); err != nil {
+					return err
+				}
+			}
+
+			_, err = insertViolation.ExecContext(ctx,
+				violation.ID, violation.RuleResultID, violation.Message, violation.LocationLine, violation.LocationColumn,
+				violation.LocationField, violation.LocationType, violation.LocationCoordinate,
+				violation.Fingerprint, violation.FirstSeenReportID, utcPtr(violation.FirstSeenAt),
+				violation.Suppressed, violation.SuppressionID, violation.CreatedAt,
+			)
+
+			if err != nil {
+				return fmt.Errorf("failed to insert violation: %w", err)
+			}
+		}
+	}
+
+	// Resolve tracked violations of this subgraph that no longer occur. A report that
+	// arrives late does not resolve violations seen after it.
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tracked_violations
+		SET resolved_report_id = $2, resolved_at = $3
+		WHERE subgraph_name = $1 AND resolved_at IS NULL
+			AND last_seen_report_id IS NOT $2 AND last_seen_at <= $3`,
+		report.SubgraphName, report.ID, utc(report.Timestamp))
+
+	if err != nil {
+		return fmt.Errorf("failed to resolve tracked violations: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// trackViolation records a sighting of a violation in tracked_violations and copies the
+// start of its lifecycle onto the violation. A violation that reappears after being
+// resolved starts a new lifecycle. A report that arrives late neither moves the last
+// sighting back nor reopens a violation resolved after it.
+func (r *SQLiteSchemaReportRepository) trackViolation(ctx context.Context, tx *sql.Tx, report *domain.SchemaReport, ruleName string, violation *domain.Violation) error {
+	var firstSeenReportID sql.NullString
+	var firstSeenAt time.Time
+
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO tracked_violations (fingerprint, subgraph_name, rule_name, location_coordinate, message,
+			first_seen_report_id, first_seen_at, last_seen_report_id, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $6, $7)
+		ON CONFLICT (fingerprint) DO UPDATE SET
+			first_seen_report_id = CASE WHEN excluded.first_seen_at >= tracked_violations.resolved_at
+				THEN excluded.first_seen_report_id ELSE tracked_violations.first_seen_report_id END,
+			first_seen_at = CASE WHEN excluded.first_seen_at >= tracked_violations.resolved_at
+				THEN excluded.first_seen_at ELSE tracked_violations.first_seen_at END,
+			last_seen_report_id = CASE WHEN excluded.last_seen_at >= tracked_violations.last_seen_at
+				THEN excluded.last_seen_report_id ELSE tracked_violations.last_seen_report_id END,
+			last_seen_at = MAX(excluded.last_seen_at, tracked_violations.last_seen_at),
+			message = CASE WHEN excluded.last_seen_at >= tracked_violations.last_seen_at
+				THEN excluded.message ELSE tracked_violations.message END,
+			resolved_report_id = CASE WHEN excluded.last_seen_at >= tracked_violations.resolved_at
+				THEN NULL ELSE tracked_violations.resolved_report_id END,
+			resolved_at = CASE WHEN excluded.last_seen_at >= tracked_violations.resolved_at
+				THEN NULL ELSE tracked_violations.resolved_at END
+		RETURNING first_seen_report_id, first_seen_at`,
+		violation.Fingerprint, report.SubgraphName, ruleName, violation.LocationCoordinate,
+		violation.Message, report.ID, utc(report.Timestamp),
+	).Scan(&firstSeenReportID, &firstSeenAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to track violation: %w", err)
+	}
+
+	if firstSeenReportID.Valid {
+		violation.FirstSeenReportID = &firstSeenReportID.String
+	}
+	violation.FirstSeenAt = &firstSeenAt
+
+	return nil
+}
+
+// GetByID retrieves a schema report by its ID, or by the integer ID of a report stored
+// before IDs became UUIDs
+func (r *SQLiteSchemaReportRepository) GetByID(ctx context.Context, rawID string) (*domain.SchemaReport, error) {
+	id, ok := domain.ParseReportID(rawID)
+	if !ok {
+		return nil, fmt.Errorf("report with ID %s: %w", rawID, domain.ErrReportNotFound)
+	}
+
+	var report domain.SchemaReport
+	var metadata sql.NullString
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, subgraph_name, score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, metadata, api_token_id, created_at
+		FROM schema_reports WHERE id = $1`, id).Scan(
+		&report.ID, &report.SubgraphName, &report.Score,
+		&report.EffectiveScore, &report.SuppressedCount, &report.TotalFields, &report.TotalWeightedViolations,
+		&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &metadata, &report.APITokenID, &report.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("report with ID %s: %w", rawID, domain.ErrReportNotFound)
+		}
+		return nil, fmt.Errorf("failed to query report: %w", err)
+	}
+
+	if metadata.Valid {
+		json.Unmarshal([]byte(metadata.String), &report.Metadata)
+	}
+
+	ruleRows, err := r.db.QueryContext(ctx, `
+		SELECT id, rule_name, violation_count, suppressed_count, message,
+			   weight, category, contribution, created_at
+		FROM rule_results WHERE report_id = $1
+		ORDER BY violation_count DESC, rule_name`, id)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule results: %w", err)
+	}
+	defer ruleRows.Close()
+
+	positions := make(map[string]int)
+	for ruleRows.Next() {
+		var ruleResult domain.RuleResult
+		err := ruleRows.Scan(&ruleResult.ID, &ruleResult.RuleName,
+			&ruleResult.ViolationCount, &ruleResult.SuppressedCount, &ruleResult.Message,
+			&ruleResult.Weight, &ruleResult.Category, &ruleResult.Contribution, &ruleResult.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule result: %w", err)
+		}
+		ruleResult.ReportID = report.ID
+
+		positions[ruleResult.ID] = len(report.RuleResults)
+		report.RuleResults = append(report.RuleResults, ruleResult)
+	}
+	if err := ruleRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rule results: %w", err)
+	}
+
+	violationRows, err := r.db.QueryContext(ctx, `
+		SELECT v.id, v.rule_result_id, v.message, v.location_line, v.location_column,
+			   v.location_field, v.location_type, v.location_coordinate,
+			   COALESCE(v.fingerprint, ''), v.first_seen_report_id, v.first_seen_at,
+			   v.suppressed, v.suppression_id, v.created_at
+		FROM violations v
+		JOIN rule_results rr ON rr.id = v.rule_result_id
+		WHERE rr.report_id = $1
+		ORDER BY v.rule_result_id, v.location_line NULLS LAST, v.location_column NULLS LAST`, id)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query violations: %w", err)
+	}
+	defer violationRows.Close()
+
+	for violationRows.Next() {
+		var violation domain.Violation
+		err := violationRows.Scan(&violation.ID, &violation.RuleResultID, &violation.Message,
+			&violation.LocationLine, &violation.LocationColumn,
+			&violation.LocationField, &violation.LocationType,
+			&violation.LocationCoordinate, &violation.Fingerprint,
+			&violation.FirstSeenReportID, &violation.FirstSeenAt,
+			&violation.Suppressed, &violation.SuppressionID, &violation.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan violation: %w", err)
+		}
+
+		ruleResult := &report.RuleResults[positions[violation.RuleResultID]]
+		ruleResult.Violations = append(ruleResult.Violations, violation)
+	}
+	if err := violationRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read violations: %w", err)
+	}
+
+	return &report, nil
+}
+
+// GetRecentReports retrieves the most recent reports
+func (r *SQLiteSchemaReportRepository) GetRecentReports(ctx context.Context, limit int) ([]domain.SchemaReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reportColumns+`
+		FROM schema_reports
+		ORDER BY timestamp DESC
+		LIMIT $1`, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent reports: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReports(rows)
+}
+
+// GetReportsBySubgraph retrieves reports for a specific subgraph
+func (r *SQLiteSchemaReportRepository) GetReportsBySubgraph(ctx context.Context, subgraphName string, limit int) ([]domain.SchemaReport, error) {
+	// "Unknown" needs no special case, reports sent without a subgraph name are stored under it
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reportColumns+`
+		FROM schema_reports
+		WHERE subgraph_name = $1
+		ORDER BY timestamp DESC
+		LIMIT $2`, subgraphName, limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subgraph reports: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReports(rows)
+}
+
+// GetPreviousReport retrieves the most recent report of a subgraph from before the given time
+func (r *SQLiteSchemaReportRepository) GetPreviousReport(ctx context.Context, subgraphName string, before time.Time) (*domain.SchemaReport, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+reportColumns+`
+		FROM schema_reports
+		WHERE subgraph_name = $1 AND timestamp < $2
+		ORDER BY timestamp DESC
+		LIMIT 1`, subgraphName, utc(before))
+
+	report, err := scanReport(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no report of %s before %s: %w", subgraphName, before.Format(time.RFC3339), domain.ErrReportNotFound)
+		}
+		return nil, fmt.Errorf("failed to query previous report: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetLatestReportOnBranch retrieves the most recent report of a subgraph created on the given branch
+// before the given time
+func (r *SQLiteSchemaReportRepository) GetLatestReportOnBranch(ctx context.Context, subgraphName, branch string, before time.Time) (*domain.SchemaReport, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+reportColumns+`
+		FROM schema_reports
+		WHERE subgraph_name = $1 AND json_extract(metadata, '$.branch') = $2 AND timestamp < $3
+		ORDER BY timestamp DESC
+		LIMIT 1`, subgraphName, branch, utc(before))
+
+	report, err := scanReport(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no report on branch %s: %w", branch, domain.ErrReportNotFound)
+		}
+		return nil, fmt.Errorf("failed to query latest report on branch: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetSubgraphSummaries retrieves aggregated data for all subgraphs in a single query. The
+// latest score and the trend come from the most recent report and the one before it.
+func (r *SQLiteSchemaReportRepository) GetSubgraphSummaries(ctx context.Context) ([]domain.SubgraphSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH ranked AS (
+			SELECT
+				COALESCE(subgraph_name, 'Unknown') AS name,
+				score,
+				timestamp,
+				COUNT(*) OVER subgraph AS report_count,
+				LAG(score) OVER (subgraph ORDER BY timestamp, id) AS previous_score,
+				ROW_NUMBER() OVER (subgraph ORDER BY timestamp DESC, id DESC) AS recency
+			FROM schema_reports
+			WINDOW subgraph AS (PARTITION BY COALESCE(subgraph_name, 'Unknown'))
+		)
+		SELECT name, report_count, score, timestamp, previous_score
+		FROM ranked
+		WHERE recency = 1
+		ORDER BY timestamp DESC NULLS LAST`)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subgraph summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []domain.SubgraphSummary
+	for rows.Next() {
+		var summary domain.SubgraphSummary
+		var prevScore sql.NullFloat64
+
+		err := rows.Scan(&summary.Name, &summary.ReportCount,
+			&summary.LatestScore, &summary.LatestReport, &prevScore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subgraph summary: %w", err)
+		}
+
+		// Compare with the previous report
+		if prevScore.Valid {
+			if summary.LatestScore > prevScore.Float64 {
+				summary.Trend = "up"
+			} else if summary.LatestScore < prevScore.Float64 {
+				summary.Trend = "down"
+			} else {
+				summary.Trend = "stable"
+			}
+		} else {
+			summary.Trend = "stable"
+		}
+
+		summaries = append(summaries, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read subgraph summaries: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// latestReportIDsQuery selects the ID of the most recent report of every subgraph
+const latestReportIDsQuery = `
+	SELECT id FROM (
+		SELECT id, ROW_NUMBER() OVER (
+			PARTITION BY COALESCE(subgraph_name, 'Unknown')
+			ORDER BY timestamp DESC, id DESC
+		) AS recency
+		FROM schema_reports
+	)
+	WHERE recency = 1`
+
+// GetLatestReports retrieves the most recent report of every subgraph with its rule results
+func (r *SQLiteSchemaReportRepository) GetLatestReports(ctx context.Context) ([]domain.SchemaReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, COALESCE(subgraph_name, 'Unknown'), score, COALESCE(effective_score, score), suppressed_count,
+			   total_fields, total_weighted_violations, recomputed_score, score_mismatch,
+			   timestamp, created_at
+		FROM schema_reports
+		WHERE id IN (`+latestReportIDsQuery+`)
+		ORDER BY COALESCE(subgraph_name, 'Unknown')`)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest reports: %w", err)
+	}
+	reports, err := scanReports(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ruleRows, err := r.db.QueryContext(ctx, `
+		SELECT id, report_id, rule_name, violation_count, suppressed_count, message,
+			   weight, category, contribution, created_at
+		FROM rule_results
+		WHERE report_id IN (`+latestReportIDsQuery+`)
+		ORDER BY report_id, rule_name`)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule results: %w", err)
+	}
+	defer ruleRows.Close()
+
+	if err := attachRuleResults(ruleRows, reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// GetReportsWithRuleResults retrieves every report of a subgraph, oldest first, with its rule results
+func (r *SQLiteSchemaReportRepository) GetReportsWithRuleResults(ctx context.Context, subgraphName string) ([]domain.SchemaReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reportColumns+`
+		FROM schema_reports
+		WHERE subgraph_name = $1
+		ORDER BY timestamp, id`, subgraphName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subgraph reports: %w", err)
+	}
+	reports, err := scanReports(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	ruleRows, err := r.db.QueryContext(ctx, `
+		SELECT rr.id, rr.report_id, rr.rule_name, rr.violation_count, rr.suppressed_count, rr.message,
+			   rr.weight, rr.category, rr.contribution, rr.created_at
+		FROM rule_results rr
+		JOIN schema_reports sr ON sr.id = rr.report_id
+		WHERE sr.subgraph_name = $1
+		ORDER BY rr.report_id, rr.rule_name`, subgraphName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule results: %w", err)
+	}
+	defer ruleRows.Close()
+
+	if err := attachRuleResults(ruleRows, reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// attachRuleResults adds the rule results of the rows to the reports they belong to
+func attachRuleResults(ruleRows *sql.Rows, reports []domain.SchemaReport) error {
+	positions := make(map[string]int, len(reports))
+	for i, report := range reports {
+		positions[report.ID] = i
+	}
+
+	for ruleRows.Next() {
+		var ruleResult domain.RuleResult
+		err := ruleRows.Scan(&ruleResult.ID, &ruleResult.ReportID, &ruleResult.RuleName,
+			&ruleResult.ViolationCount, &ruleResult.SuppressedCount, &ruleResult.Message,
+			&ruleResult.Weight, &ruleResult.Category, &ruleResult.Contribution, &ruleResult.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan rule result: %w", err)
+		}
+
+		// A report stored between the two queries can show up here without its report row
+		position, ok := positions[ruleResult.ReportID]
+		if !ok {
+			continue
+		}
+		reports[position].RuleResults = append(reports[position].RuleResults, ruleResult)
+	}
+	if err := ruleRows.Err(); err != nil {
+		return fmt.Errorf("failed to read rule results: %w", err)
+	}
+
+	return nil
+}
+
+// GetOpenViolations retrieves the unresolved tracked violations of a subgraph, oldest first
+func (r *SQLiteSchemaReportRepository) GetOpenViolations(ctx context.Context, subgraphName string) ([]domain.TrackedViolation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT fingerprint, subgraph_name, rule_name, location_coordinate, message,
+			   COALESCE(CAST(first_seen_report_id AS TEXT), ''), first_seen_at,
+			   COALESCE(CAST(last_seen_report_id AS TEXT), ''), last_seen_at, resolved_at
+		FROM tracked_violations
+		WHERE subgraph_name = $1 AND resolved_at IS NULL
+		ORDER BY first_seen_at, rule_name`, subgraphName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open violations: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []domain.TrackedViolation
+	for rows.Next() {
+		var violation domain.TrackedViolation
+		err := rows.Scan(&violation.Fingerprint, &violation.SubgraphName, &violation.RuleName,
+			&violation.LocationCoordinate, &violation.Message,
+			&violation.FirstSeenReportID, &violation.FirstSeenAt,
+			&violation.LastSeenReportID, &violation.LastSeenAt, &violation.ResolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tracked violation: %w", err)
+		}
+
+		violations = append(violations, violation)
+	}
+
+	return violations, rows.Err()
+}
+
+// GetTotalReportCount returns the total number of reports
+func (r *SQLiteSchemaReportRepository) GetTotalReportCount(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_reports").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get total report count: %w", err)
+	}
+	return count, nil
+}
+
+// HealthCheck verifies the repository is accessible
+func (r *SQLiteSchemaReportRepository) HealthCheck(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+func scanReport(row rowScanner) (*domain.SchemaReport, error) {
+	var report domain.SchemaReport
+	err := row.Scan(&report.ID, &report.SubgraphName, &report.Score,
+		&report.EffectiveScore, &report.SuppressedCount,
+		&report.TotalFields, &report.TotalWeightedViolations,
+		&report.RecomputedScore, &report.ScoreMismatch, &report.Timestamp, &report.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func scanReports(rows *sql.Rows) ([]domain.SchemaReport, error) {
+	var reports []domain.SchemaReport
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, *report)
+	}
+	return reports, rows.Err()
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"schema-score-server/internal/adapters/repositorytest"
+	"schema-score-server/internal/domain"
+	"schema-score-server/migrations"
+	"testing"
+)
+
+// openTestDB opens a migrated database in a temporary file
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "schema-score.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := NewMigrator(db).RunMigrations(migrations.SQLite); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return db
+}
+
+func TestSQLiteSchemaReportRepository_Contract(t *testing.T) {
+	repositorytest.TestSchemaReportRepository(t, func(t *testing.T) domain.SchemaReportRepository {
+		return NewSQLiteSchemaReportRepository(openTestDB(t))
+	})
+}
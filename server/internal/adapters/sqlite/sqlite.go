@@ -0,0 +1,100 @@
+// Package sqlite stores schema reports in a SQLite database file, for deployments
+// that do not want to run PostgreSQL. It uses a pure Go driver, so the server still
+// builds without cgo.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// DriverName is the database/sql driver name of the SQLite driver
+const DriverName = "sqlite"
+
+// connectionPragmas are applied to every connection: foreign keys for the cascades the
+// schema relies on, WAL so that readers do not block the writer, and a busy timeout so
+// that concurrent writers wait for each other instead of failing
+var connectionPragmas = []string{
+	"foreign_keys(1)",
+	"journal_mode(WAL)",
+	"busy_timeout(5000)",
+}
+
+// Open opens the SQLite database at dsn, which is a file path, a file: URI or a
+// sqlite:// URL, and creates the file if it does not exist yet
+func Open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open(DriverName, connectionString(dsn))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open SQLite database %s: %w", dsn, err)
+	}
+
+	return db, nil
+}
+
+// connectionString adds the driver options the repositories depend on to dsn. Times
+// are written in a sortable text format, and transactions take the write lock when
+// they begin so that two writers cannot deadlock while upgrading their locks.
+func connectionString(dsn string) string {
+	dsn = strings.TrimPrefix(dsn, "sqlite://")
+	path, rawQuery, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		query = url.Values{}
+	}
+	query.Set("_time_format", "sqlite")
+	query.Set("_txlock", "immediate")
+	for _, pragma := range connectionPragmas {
+		query.Add("_pragma", pragma)
+	}
+
+	return "file:" + path + "?" + query.Encode()
+}
+
+// utc converts a time to UTC before it is written. Times are stored as text, which
+// only sorts and compares correctly when every value has the same offset.
+func utc(t time.Time) time.Time {
+	return t.UTC()
+}
+
+// utcPtr is utc for optional times
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	converted := t.UTC()
+	return &converted
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// requireAffected returns notFound when the statement did not touch any row
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"time"
+)
+
+// SQLiteSuppressionRepository implements the SuppressionRepository interface using SQLite
+type SQLiteSuppressionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSuppressionRepository creates a new SQLite implementation of SuppressionRepository
+func NewSQLiteSuppressionRepository(db *sql.DB) domain.SuppressionRepository {
+	return &SQLiteSuppressionRepository{
+		db: db,
+	}
+}
+
+const suppressionColumns = `id, subgraph_name, rule_name, coordinate_pattern, reason, author,
+	expires_at, created_at, updated_at`
+
+// Store saves a new suppression to the database
+func (r *SQLiteSuppressionRepository) Store(ctx context.Context, suppression *domain.Suppression) error {
+	now := utc(time.Now())
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO suppressions (subgraph_name, rule_name, coordinate_pattern, reason, author, expires_at,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		RETURNING id, created_at, updated_at`,
+		suppression.SubgraphName, suppression.RuleName, suppression.CoordinatePattern,
+		suppression.Reason, suppression.Author, utcPtr(suppression.ExpiresAt), now,
+	).Scan(&suppression.ID, &suppression.CreatedAt, &suppression.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert suppression: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a suppression by its ID
+func (r *SQLiteSuppressionRepository) GetByID(ctx context.Context, id string) (*domain.Suppression, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+suppressionColumns+` FROM suppressions WHERE id = $1`, id)
+
+	suppression, err := scanSuppression(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound)
+		}
+		return nil, fmt.Errorf("failed to query suppression: %w", err)
+	}
+	return suppression, nil
+}
+
+// List retrieves all suppressions, or those of one subgraph when a name is given
+func (r *SQLiteSuppressionRepository) List(ctx context.Context, subgraphName string) ([]domain.Suppression, error) {
+	var rows *sql.Rows
+	var err error
+
+	if subgraphName == "" {
+		rows, err = r.db.QueryContext(ctx, `
+			SELECT `+suppressionColumns+` FROM suppressions
+			ORDER BY subgraph_name, rule_name, coordinate_pattern`)
+	} else {
+		rows, err = r.db.QueryContext(ctx, `
+			SELECT `+suppressionColumns+` FROM suppressions
+			WHERE subgraph_name = $1
+			ORDER BY rule_name, coordinate_pattern`, subgraphName)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSuppressions(rows)
+}
+
+// Update saves the changes to an existing suppression
+func (r *SQLiteSuppressionRepository) Update(ctx context.Context, suppression *domain.Suppression) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE suppressions
+		SET subgraph_name = $2, rule_name = $3, coordinate_pattern = $4, reason = $5,
+			author = $6, expires_at = $7, updated_at = $8
+		WHERE id = $1`,
+		suppression.ID, suppression.SubgraphName, suppression.RuleName, suppression.CoordinatePattern,
+		suppression.Reason, suppression.Author, utcPtr(suppression.ExpiresAt), utc(suppression.UpdatedAt))
+
+	if err != nil {
+		return fmt.Errorf("failed to update suppression: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("suppression with ID %s: %w", suppression.ID, domain.ErrSuppressionNotFound))
+}
+
+// Delete removes a suppression
+func (r *SQLiteSuppressionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM suppressions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete suppression: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("suppression with ID %s: %w", id, domain.ErrSuppressionNotFound))
+}
+
+// GetActive retrieves the suppressions of a subgraph that have not expired at the given time
+func (r *SQLiteSuppressionRepository) GetActive(ctx context.Context, subgraphName string, at time.Time) ([]domain.Suppression, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+suppressionColumns+` FROM suppressions
+		WHERE subgraph_name = $1 AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY id`, subgraphName, utc(at))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSuppressions(rows)
+}
+
+func scanSuppression(row rowScanner) (*domain.Suppression, error) {
+	var suppression domain.Suppression
+	err := row.Scan(&suppression.ID, &suppression.SubgraphName, &suppression.RuleName,
+		&suppression.CoordinatePattern, &suppression.Reason, &suppression.Author,
+		&suppression.ExpiresAt, &suppression.CreatedAt, &suppression.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+func scanSuppressions(rows *sql.Rows) ([]domain.Suppression, error) {
+	var suppressions []domain.Suppression
+	for rows.Next() {
+		suppression, err := scanSuppression(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan suppression: %w", err)
+		}
+		suppressions = append(suppressions, *suppression)
+	}
+	return suppressions, rows.Err()
+}
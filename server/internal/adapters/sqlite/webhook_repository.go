@@ -0,0 +1,298 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"schema-score-server/internal/domain"
+	"strings"
+	"time"
+)
+
+// SQLiteWebhookRepository implements the WebhookRepository interface using SQLite
+type SQLiteWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteWebhookRepository creates a new SQLite implementation of WebhookRepository
+func NewSQLiteWebhookRepository(db *sql.DB) domain.WebhookRepository {
+	return &SQLiteWebhookRepository{
+		db: db,
+	}
+}
+
+const webhookColumns = `id, url, secret, subgraph_name, min_score, max_score_drop, created_at`
+
+const deliveryColumns = `id, webhook_id, event_type, payload, status, attempts, next_attempt_at,
+	last_error, last_status_code, created_at, delivered_at`
+
+// StoreWebhook saves a new webhook to the database
+func (r *SQLiteWebhookRepository) StoreWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO webhooks (url, secret, subgraph_name, min_score, max_score_drop, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		webhook.URL, webhook.Secret, webhook.SubgraphName, webhook.MinScore, webhook.MaxScoreDrop,
+		utc(webhook.CreatedAt),
+	).Scan(&webhook.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhook retrieves a webhook by its ID
+func (r *SQLiteWebhookRepository) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id)
+
+	webhook, err := scanWebhook(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("webhook with ID %s: %w", id, domain.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("failed to query webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// ListWebhooks retrieves all webhooks
+func (r *SQLiteWebhookRepository) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+// ListWebhooksForSubgraph retrieves the global webhooks and those of one subgraph
+func (r *SQLiteWebhookRepository) ListWebhooksForSubgraph(ctx context.Context, subgraphName string) ([]domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookColumns+` FROM webhooks
+		WHERE subgraph_name = '' OR subgraph_name = $1
+		ORDER BY id`, subgraphName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+// DeleteWebhook removes a webhook, its deliveries are removed by cascade
+func (r *SQLiteWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("webhook with ID %s: %w", id, domain.ErrWebhookNotFound))
+}
+
+// EnqueueDeliveries adds pending deliveries to the outbox
+func (r *SQLiteWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO webhook_deliveries (webhook_id, event_type, payload, status, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`,
+			delivery.WebhookID, delivery.EventType, delivery.Payload, delivery.Status,
+			utc(delivery.NextAttemptAt), utc(delivery.CreatedAt),
+		).Scan(&delivery.ID)
+		if err != nil {
+			return fmt.Errorf("failed to insert webhook delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns due pending deliveries and postpones them by the lease.
+// SQLite serializes writers, so the claim cannot overlap with another one.
+func (r *SQLiteWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+		)
+		RETURNING `+deliveryColumns,
+		utc(now), utc(now.Add(lease)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+// SaveDeliveryAttempt records an attempt and the resulting delivery state
+func (r *SQLiteWebhookRepository) SaveDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery, attempt domain.DeliveryAttempt) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempt_number, status_code, error, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		delivery.ID, attempt.Number, attempt.StatusCode, attempt.Error,
+		attempt.Duration.Milliseconds(), utc(attempt.AttemptedAt))
+	if err != nil {
+		return fmt.Errorf("failed to insert delivery attempt: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5,
+			last_status_code = $6, delivered_at = $7
+		WHERE id = $1`,
+		delivery.ID, delivery.Status, delivery.Attempts, utc(delivery.NextAttemptAt), delivery.LastError,
+		delivery.LastStatusCode, utcPtr(delivery.DeliveredAt))
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if err := requireAffected(result, fmt.Errorf("webhook delivery with ID %s: %w", delivery.ID, domain.ErrWebhookDeliveryNotFound)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery retrieves a delivery with its attempts
+func (r *SQLiteWebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id)
+
+	delivery, err := scanDelivery(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("webhook delivery with ID %s: %w", id, domain.ErrWebhookDeliveryNotFound)
+		}
+		return nil, fmt.Errorf("failed to query webhook delivery: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT attempt_number, status_code, error, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempt_number`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt domain.DeliveryAttempt
+		var durationMs int64
+		if err := rows.Scan(&attempt.Number, &attempt.StatusCode, &attempt.Error, &durationMs, &attempt.AttemptedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery attempt: %w", err)
+		}
+		attempt.Duration = time.Duration(durationMs) * time.Millisecond
+		delivery.AttemptLog = append(delivery.AttemptLog, attempt)
+	}
+
+	return delivery, rows.Err()
+}
+
+// ListDeliveries retrieves the most recent deliveries, optionally filtered by webhook and status
+func (r *SQLiteWebhookRepository) ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]domain.WebhookDelivery, error) {
+	var conditions []string
+	var args []interface{}
+
+	if webhookID != "" {
+		args = append(args, webhookID)
+		conditions = append(conditions, fmt.Sprintf("webhook_id = $%d", len(args)))
+	}
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+// RetryDelivery makes a delivery pending again, due at the given time
+func (r *SQLiteWebhookRepository) RetryDelivery(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET status = 'pending', next_attempt_at = $2
+		WHERE id = $1`, id, utc(at))
+	if err != nil {
+		return fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+	return requireAffected(result, fmt.Errorf("webhook delivery with ID %s: %w", id, domain.ErrWebhookDeliveryNotFound))
+}
+
+func scanWebhook(row rowScanner) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &webhook.SubgraphName,
+		&webhook.MinScore, &webhook.MaxScoreDrop, &webhook.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func scanWebhooks(rows *sql.Rows) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func scanDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventType, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastError,
+		&delivery.LastStatusCode, &delivery.CreatedAt, &delivery.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func scanDeliveries(rows *sql.Rows) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteWebhookRepository_DeliveryOutbox(t *testing.T) {
+	repo := NewSQLiteWebhookRepository(openTestDB(t))
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	webhook := &domain.Webhook{URL: "https://example.com/hook", SubgraphName: "user-service", CreatedAt: now}
+	assert.NoError(t, repo.StoreWebhook(ctx, webhook))
+
+	deliveries := []domain.WebhookDelivery{
+		{WebhookID: webhook.ID, EventType: "report.stored", Payload: []byte(`{"n":1}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now, CreatedAt: now},
+		{WebhookID: webhook.ID, EventType: "report.stored", Payload: []byte(`{"n":2}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now},
+	}
+	assert.NoError(t, repo.EnqueueDeliveries(ctx, deliveries))
+
+	// Only due deliveries are claimed, and a claimed delivery is leased
+	claimed, err := repo.ClaimDueDeliveries(ctx, now.Add(time.Minute), 30*time.Second, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, claimed, 1) {
+		assert.Equal(t, deliveries[0].ID, claimed[0].ID)
+		assert.Equal(t, []byte(`{"n":1}`), claimed[0].Payload)
+	}
+
+	claimed, err = repo.ClaimDueDeliveries(ctx, now.Add(time.Minute), 30*time.Second, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	statusCode := 200
+	deliveredAt := now.Add(time.Minute)
+	delivery := deliveries[0]
+	delivery.Status = domain.DeliveryStatusDelivered
+	delivery.Attempts = 1
+	delivery.LastStatusCode = &statusCode
+	delivery.DeliveredAt = &deliveredAt
+	assert.NoError(t, repo.SaveDeliveryAttempt(ctx, &delivery, domain.DeliveryAttempt{
+		Number: 1, StatusCode: &statusCode, Duration: 120 * time.Millisecond, AttemptedAt: deliveredAt,
+	}))
+
+	stored, err := repo.GetDelivery(ctx, delivery.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryStatusDelivered, stored.Status)
+	assert.True(t, deliveredAt.Equal(*stored.DeliveredAt))
+	if assert.Len(t, stored.AttemptLog, 1) {
+		assert.Equal(t, 120*time.Millisecond, stored.AttemptLog[0].Duration)
+	}
+
+	pending, err := repo.ListDeliveries(ctx, webhook.ID, domain.DeliveryStatusPending, 10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	// Deleting the webhook removes its deliveries
+	assert.NoError(t, repo.DeleteWebhook(ctx, webhook.ID))
+	_, err = repo.GetDelivery(ctx, delivery.ID)
+	assert.ErrorIs(t, err, domain.ErrWebhookDeliveryNotFound)
+	assert.ErrorIs(t, repo.DeleteWebhook(ctx, webhook.ID), domain.ErrWebhookNotFound)
+}
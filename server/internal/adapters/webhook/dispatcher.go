@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"schema-score-server/internal/domain"
+	"time"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 20
+	defaultTimeout      = 10 * time.Second
+)
+
+// Dispatcher sends the pending deliveries of the webhook outbox
+type Dispatcher struct {
+	webhookService *domain.WebhookService
+	client         *http.Client
+	pollInterval   time.Duration
+	batchSize      int
+}
+
+// NewDispatcher creates a new webhook dispatcher
+func NewDispatcher(webhookService *domain.WebhookService) *Dispatcher {
+	return &Dispatcher{
+		webhookService: webhookService,
+		client:         &http.Client{Timeout: defaultTimeout},
+		pollInterval:   defaultPollInterval,
+		batchSize:      defaultBatchSize,
+	}
+}
+
+// Run polls the outbox and sends due deliveries until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Errors caused by shutting down are expected
+		if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error dispatching webhook deliveries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends one batch of due deliveries and returns how many were attempted
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	deliveries, err := d.webhookService.ClaimDueDeliveries(ctx, d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		attempt := d.send(ctx, delivery)
+		if !attempt.Succeeded() {
+			log.Printf("Webhook delivery %s failed (attempt %d): %s", delivery.ID, delivery.Attempts+1, attempt.Error)
+		}
+
+		// Record the attempt even when shutdown cancelled the request, so it is not lost
+		if err := d.webhookService.RecordDeliveryAttempt(context.WithoutCancel(ctx), delivery, attempt); err != nil {
+			log.Printf("Error recording webhook delivery %s: %v", delivery.ID, err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send posts a delivery to its webhook and describes the outcome
+func (d *Dispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery) domain.DeliveryAttempt {
+	start := time.Now()
+	attempt := domain.DeliveryAttempt{AttemptedAt: start}
+
+	webhook, err := d.webhookService.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			attempt.Error = "webhook no longer exists"
+		} else {
+			attempt.Error = err.Error()
+		}
+		return attempt
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = fmt.Sprintf("invalid request: %v", err)
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "schema-score-webhooks")
+	req.Header.Set(domain.WebhookEventHeader, delivery.EventType)
+	req.Header.Set(domain.WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhookPayload(webhook.Secret, start, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	attempt.Duration = time.Since(start)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	statusCode := resp.StatusCode
+	attempt.StatusCode = &statusCode
+	if statusCode < 200 || statusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %d", statusCode)
+	}
+
+	return attempt
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"schema-score-server/internal/domain"
+	"testing"
+	"time"
+)
+
+// noReports satisfies the report repository needed by the webhook service
+type noReports struct {
+	domain.SchemaReportRepository
+}
+
+func newTestDispatcher(url string) (*Dispatcher, *MockWebhookRepository) {
+	minScore := 80.0
+	repo := NewMockWebhookRepository().
+		WithWebhook(domain.Webhook{URL: url, Secret: "s3cret", MinScore: &minScore}).
+		WithDelivery(domain.WebhookDelivery{
+			WebhookID:     "1",
+			EventType:     domain.WebhookEventScoreRegression,
+			Payload:       []byte(`{"type":"score.regression"}`),
+			Status:        domain.DeliveryStatusPending,
+			NextAttemptAt: time.Now(),
+		})
+
+	return NewDispatcher(domain.NewWebhookService(repo, noReports{})), repo
+}
+
+func TestDispatcher_DispatchDue_Success(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher, repo := newTestDispatcher(server.URL)
+
+	count, err := dispatcher.DispatchDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.Equal(t, `{"type":"score.regression"}`, string(body))
+	assert.Equal(t, domain.WebhookEventScoreRegression, received.Header.Get(domain.WebhookEventHeader))
+	assert.Equal(t, "1", received.Header.Get(domain.WebhookDeliveryHeader))
+	assert.Regexp(t, `^t=\d+,v1=[0-9a-f]{64}$`, received.Header.Get(domain.WebhookSignatureHeader))
+
+	delivery := repo.Deliveries["1"]
+	assert.Equal(t, domain.DeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, http.StatusNoContent, *delivery.LastStatusCode)
+	assert.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestDispatcher_DispatchDue_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	dispatcher, repo := newTestDispatcher(server.URL)
+
+	count, err := dispatcher.DispatchDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	delivery := repo.Deliveries["1"]
+	assert.Equal(t, domain.DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "unexpected status 502", delivery.LastError)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now()), "failed deliveries are retried later")
+
+	count, err = dispatcher.DispatchDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "nothing is due before the backoff elapses")
+}
+
+func TestDispatcher_DispatchDue_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	dispatcher, repo := newTestDispatcher(url)
+
+	_, err := dispatcher.DispatchDue(context.Background())
+	assert.NoError(t, err)
+
+	delivery := repo.Deliveries["1"]
+	assert.Nil(t, delivery.LastStatusCode)
+	assert.NotEmpty(t, delivery.LastError)
+	assert.Len(t, delivery.AttemptLog, 1)
+}
+
+func TestDispatcher_DispatchDue_ClaimFailure(t *testing.T) {
+	dispatcher, repo := newTestDispatcher("http://localhost")
+	repo.ShouldFailClaim = true
+
+	_, err := dispatcher.DispatchDue(context.Background())
+	assert.ErrorContains(t, err, "failed to claim webhook deliveries")
+}
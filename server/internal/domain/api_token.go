@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APITokenPrefix starts every API token so leaked tokens are easy to recognise
+const APITokenPrefix = "ss_"
+
+// apiTokenDisplayLength is the number of leading token characters kept for identification
+const apiTokenDisplayLength = len(APITokenPrefix) + 8
+
+// APIToken authorises report submissions. Only the SHA-256 hash of the token is stored;
+// an empty subgraph list allows submissions for every subgraph. Only admin tokens may use
+// the admin endpoints.
+type APIToken struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Hash       string
+	Subgraphs  []string
+	Admin      bool
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IsRevoked reports whether the token can no longer be used
+func (t APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// AllowsSubgraph reports whether the token may submit reports for a subgraph
+func (t APIToken) AllowsSubgraph(subgraphName string) bool {
+	if len(t.Subgraphs) == 0 {
+		return true
+	}
+	for _, allowed := range t.Subgraphs {
+		if allowed == subgraphName {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the token has a name and no blank subgraph scopes
+func (t APIToken) Validate() error {
+	var problems []string
+	if strings.TrimSpace(t.Name) == "" {
+		problems = append(problems, "name is required")
+	}
+	for _, subgraph := range t.Subgraphs {
+		if strings.TrimSpace(subgraph) == "" {
+			problems = append(problems, "subgraph names must not be blank")
+			break
+		}
+	}
+	if t.Admin && len(t.Subgraphs) > 0 {
+		problems = append(problems, "admin tokens cannot be limited to subgraphs")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAPIToken, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// GenerateAPIToken returns a new random plaintext token
+func GenerateAPIToken() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return APITokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// HashAPIToken returns the hash under which a plaintext token is stored
+func HashAPIToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
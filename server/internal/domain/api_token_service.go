@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// APITokenService contains the business logic for API tokens
+type APITokenService struct {
+	repo APITokenRepository
+}
+
+// NewAPITokenService creates a new API token service
+func NewAPITokenService(repo APITokenRepository) *APITokenService {
+	return &APITokenService{
+		repo: repo,
+	}
+}
+
+// CreateToken generates and stores a new token, admin tokens may also use the admin
+// endpoints. The plaintext token is only returned here and cannot be recovered later.
+func (s *APITokenService) CreateToken(ctx context.Context, name string, subgraphs []string, admin bool) (*APIToken, string, error) {
+	token := &APIToken{
+		Name:      name,
+		Subgraphs: subgraphs,
+		Admin:     admin,
+	}
+	if err := token.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	plaintext, err := GenerateAPIToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+
+	token.Prefix = plaintext[:apiTokenDisplayLength]
+	token.Hash = HashAPIToken(plaintext)
+	token.CreatedAt = time.Now()
+
+	if err := s.repo.Store(ctx, token); err != nil {
+		return nil, "", fmt.Errorf("failed to store API token: %w", err)
+	}
+
+	return token, plaintext, nil
+}
+
+// ListTokens retrieves all tokens, including revoked ones
+func (s *APITokenService) ListTokens(ctx context.Context) ([]APIToken, error) {
+	tokens, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokeToken makes a token unusable
+func (s *APITokenService) RevokeToken(ctx context.Context, id string) error {
+	if err := s.repo.Revoke(ctx, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves a plaintext token. Unknown and revoked tokens return an
+// error wrapping ErrUnauthorized.
+func (s *APITokenService) Authenticate(ctx context.Context, plaintext string) (*APIToken, error) {
+	token, err := s.repo.GetByHash(ctx, HashAPIToken(plaintext))
+	if err != nil {
+		if errors.Is(err, ErrAPITokenNotFound) {
+			return nil, fmt.Errorf("unknown API token: %w", ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("failed to look up API token: %w", err)
+	}
+
+	if token.IsRevoked() {
+		return nil, fmt.Errorf("API token %s is revoked: %w", token.Prefix, ErrUnauthorized)
+	}
+
+	now := time.Now()
+	if err := s.repo.TouchLastUsed(ctx, token.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to update API token usage: %w", err)
+	}
+	token.LastUsedAt = &now
+
+	return token, nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAPITokenService_CreateToken(t *testing.T) {
+	repo := NewMockAPITokenRepository()
+	service := NewAPITokenService(repo)
+
+	token, plaintext, err := service.CreateToken(context.Background(), "ci", []string{"user-service"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", token.ID)
+	assert.Equal(t, HashAPIToken(plaintext), repo.Tokens["1"].Hash)
+	assert.Equal(t, plaintext[:len(token.Prefix)], token.Prefix)
+
+	_, _, err = service.CreateToken(context.Background(), "", nil, false)
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+
+	admin, _, err := service.CreateToken(context.Background(), "ops", nil, true)
+	assert.NoError(t, err)
+	assert.True(t, repo.Tokens[admin.ID].Admin)
+
+	_, _, err = service.CreateToken(context.Background(), "ops", []string{"user-service"}, true)
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+}
+
+func TestAPITokenService_Authenticate(t *testing.T) {
+	tests := []struct {
+		name          string
+		plaintext     string
+		revoke        bool
+		shouldFail    bool
+		expectedError error
+	}{
+		{
+			name:      "valid token",
+			plaintext: "ss_valid",
+		},
+		{
+			name:          "unknown token",
+			plaintext:     "ss_unknown",
+			expectedError: ErrUnauthorized,
+		},
+		{
+			name:          "revoked token",
+			plaintext:     "ss_valid",
+			revoke:        true,
+			expectedError: ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockAPITokenRepository().WithToken("ss_valid", "user-service")
+			service := NewAPITokenService(repo)
+			if tt.revoke {
+				assert.NoError(t, service.RevokeToken(context.Background(), "1"))
+			}
+
+			token, err := service.Authenticate(context.Background(), tt.plaintext)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, token)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "1", token.ID)
+			assert.NotNil(t, repo.Tokens["1"].LastUsedAt)
+		})
+	}
+}
+
+func TestAPITokenService_Authenticate_RepositoryFailure(t *testing.T) {
+	repo := NewMockAPITokenRepository()
+	repo.ShouldFailGet = true
+	service := NewAPITokenService(repo)
+
+	_, err := service.Authenticate(context.Background(), "ss_valid")
+	assert.ErrorContains(t, err, "failed to look up API token")
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestAPITokenService_RevokeToken(t *testing.T) {
+	service := NewAPITokenService(NewMockAPITokenRepository())
+	assert.ErrorIs(t, service.RevokeToken(context.Background(), "999"), ErrAPITokenNotFound)
+}
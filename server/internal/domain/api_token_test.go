@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestAPIToken_AllowsSubgraph(t *testing.T) {
+	unscoped := APIToken{}
+	assert.True(t, unscoped.AllowsSubgraph("user-service"))
+
+	scoped := APIToken{Subgraphs: []string{"user-service", "order-service"}}
+	assert.True(t, scoped.AllowsSubgraph("order-service"))
+	assert.False(t, scoped.AllowsSubgraph("billing-service"))
+	assert.False(t, scoped.AllowsSubgraph("Unknown"))
+}
+
+func TestAPIToken_Validate(t *testing.T) {
+	assert.NoError(t, APIToken{Name: "ci"}.Validate())
+
+	err := APIToken{Subgraphs: []string{" "}}.Validate()
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+	assert.ErrorContains(t, err, "name is required")
+	assert.ErrorContains(t, err, "subgraph names must not be blank")
+}
+
+func TestGenerateAPIToken(t *testing.T) {
+	first, err := GenerateAPIToken()
+	assert.NoError(t, err)
+	second, err := GenerateAPIToken()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, APITokenPrefix))
+	assert.Len(t, first, len(APITokenPrefix)+48)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, HashAPIToken(first), 64)
+	assert.NotEqual(t, HashAPIToken(first), HashAPIToken(second))
+}
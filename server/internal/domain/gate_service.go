@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GateService contains the business logic for quality gates
+type GateService struct {
+	gates   GateRepository
+	reports SchemaReportRepository
+}
+
+// NewGateService creates a new quality gate service
+func NewGateService(gates GateRepository, reports SchemaReportRepository) *GateService {
+	return &GateService{
+		gates:   gates,
+		reports: reports,
+	}
+}
+
+// GetConfig retrieves the gate configuration of a subgraph
+func (s *GateService) GetConfig(ctx context.Context, subgraphName string) (*GateConfig, error) {
+	config, err := s.gates.GetGateConfig(ctx, subgraphName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gate config: %w", err)
+	}
+	return config, nil
+}
+
+// ListConfigs retrieves the gate configurations of all subgraphs
+func (s *GateService) ListConfigs(ctx context.Context) ([]GateConfig, error) {
+	configs, err := s.gates.ListGateConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gate configs: %w", err)
+	}
+	return configs, nil
+}
+
+// SaveConfig validates and stores the gate configuration of a subgraph
+func (s *GateService) SaveConfig(ctx context.Context, config *GateConfig) (*GateConfig, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	config.UpdatedAt = time.Now()
+	if err := s.gates.SaveGateConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save gate config: %w", err)
+	}
+	return config, nil
+}
+
+// DeleteConfig removes the gate configuration of a subgraph
+func (s *GateService) DeleteConfig(ctx context.Context, subgraphName string) error {
+	if err := s.gates.DeleteGateConfig(ctx, subgraphName); err != nil {
+		return fmt.Errorf("failed to delete gate config: %w", err)
+	}
+	return nil
+}
+
+// Evaluate checks a report against the gate of its subgraph. Subgraphs without a
+// gate configuration are skipped rather than failed.
+func (s *GateService) Evaluate(ctx context.Context, report *SchemaReport) (*GateResult, error) {
+	config, err := s.gates.GetGateConfig(ctx, report.SubgraphName)
+	if err != nil {
+		if errors.Is(err, ErrGateConfigNotFound) {
+			return &GateResult{
+				Status:       GateStatusSkipped,
+				SubgraphName: report.SubgraphName,
+				Score:        report.EffectiveScore,
+				Failures:     make([]GateFailure, 0),
+				Notes:        []string{"no quality gate configured for this subgraph"},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get gate config: %w", err)
+	}
+
+	var baseline *SchemaReport
+	if config.MaxScoreDrop != nil {
+		baseline, err = s.reports.GetLatestReportOnBranch(ctx, report.SubgraphName, config.BaselineBranch(), report.Timestamp)
+		if err != nil && !errors.Is(err, ErrReportNotFound) {
+			return nil, fmt.Errorf("failed to get baseline report: %w", err)
+		}
+	}
+
+	return config.Evaluate(report, baseline), nil
+}
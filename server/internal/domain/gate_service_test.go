@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGateService_Evaluate(t *testing.T) {
+	baseline := newGateTestReport("1", 90, "main", 0)
+	baseline.Timestamp = time.Now().Add(-time.Hour)
+	otherBranch := newGateTestReport("3", 50, "feature", 0)
+	newer := newGateTestReport("4", 80, "main", 0)
+	newer.Timestamp = time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name             string
+		gates            *MockGateRepository
+		reports          *MockSchemaReportRepository
+		expectedStatus   string
+		expectedBaseline *string
+		expectedError    string
+	}{
+		{
+			name:           "no gate configured",
+			gates:          NewMockGateRepository(),
+			reports:        NewMockSchemaReportRepository(),
+			expectedStatus: GateStatusSkipped,
+		},
+		{
+			name: "baseline from default branch",
+			gates: NewMockGateRepository().WithConfig(GateConfig{
+				SubgraphName: "user-service",
+				MaxScoreDrop: float64Ptr(1),
+			}),
+			reports: func() *MockSchemaReportRepository {
+				repo := NewMockSchemaReportRepository()
+				repo.Reports[baseline.ID] = baseline
+				repo.Reports[otherBranch.ID] = otherBranch
+				return repo
+			}(),
+			expectedStatus:   GateStatusFailed,
+			expectedBaseline: stringPtr("1"),
+		},
+		{
+			name: "baseline from before a late report",
+			gates: NewMockGateRepository().WithConfig(GateConfig{
+				SubgraphName: "user-service",
+				MaxScoreDrop: float64Ptr(1),
+			}),
+			reports: func() *MockSchemaReportRepository {
+				repo := NewMockSchemaReportRepository()
+				repo.Reports[baseline.ID] = baseline
+				repo.Reports[newer.ID] = newer
+				return repo
+			}(),
+			expectedStatus:   GateStatusFailed,
+			expectedBaseline: stringPtr("1"),
+		},
+		{
+			name: "no baseline report",
+			gates: NewMockGateRepository().WithConfig(GateConfig{
+				SubgraphName: "user-service",
+				MaxScoreDrop: float64Ptr(1),
+			}),
+			reports:        NewMockSchemaReportRepository(),
+			expectedStatus: GateStatusPassed,
+		},
+		{
+			name: "repository failure",
+			gates: func() *MockGateRepository {
+				repo := NewMockGateRepository()
+				repo.ShouldFailGet = true
+				return repo
+			}(),
+			reports:       NewMockSchemaReportRepository(),
+			expectedError: "failed to get gate config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewGateService(tt.gates, tt.reports)
+
+			result, err := service.Evaluate(context.Background(), newGateTestReport("2", 85, "feature", 0))
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedBaseline, result.BaselineReportID)
+		})
+	}
+}
+
+func TestGateService_SaveConfig(t *testing.T) {
+	repo := NewMockGateRepository()
+	service := NewGateService(repo, NewMockSchemaReportRepository())
+
+	config, err := service.SaveConfig(context.Background(), &GateConfig{SubgraphName: "user-service", MinScore: float64Ptr(80)})
+	assert.NoError(t, err)
+	assert.False(t, config.UpdatedAt.IsZero())
+	assert.Contains(t, repo.Configs, "user-service")
+
+	_, err = service.SaveConfig(context.Background(), &GateConfig{SubgraphName: "user-service", MaxScoreDrop: float64Ptr(-1)})
+	assert.ErrorIs(t, err, ErrInvalidGateConfig)
+
+	repo.ShouldFailSave = true
+	_, err = service.SaveConfig(context.Background(), &GateConfig{SubgraphName: "user-service"})
+	assert.ErrorContains(t, err, "failed to save gate config")
+}
+
+func TestGateService_DeleteConfig(t *testing.T) {
+	repo := NewMockGateRepository().WithConfig(GateConfig{SubgraphName: "user-service"})
+	service := NewGateService(repo, NewMockSchemaReportRepository())
+
+	assert.NoError(t, service.DeleteConfig(context.Background(), "user-service"))
+	assert.Empty(t, repo.Configs)
+	assert.ErrorIs(t, service.DeleteConfig(context.Background(), "user-service"), ErrGateConfigNotFound)
+}
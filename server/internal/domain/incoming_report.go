@@ -1,12 +1,13 @@
 package domain
 
 import (
-	"github.com/google/uuid"
-	"time"
+	"fmt"
 )
 
-// IncomingReport represents the JSON structure we expect from the schema scorer
+// IncomingReport represents the JSON structure we expect from the schema scorer, in the
+// current schema version. ReportValidator.Decode upconverts older payloads to it.
 type IncomingReport struct {
+	SchemaVersion           int                    `json:"schemaVersion,omitempty"`
 	Timestamp               string                 `json:"timestamp"`
 	SubgraphName            *string                `json:"subgraphName"`
 	Score                   float64                `json:"score"`
@@ -21,6 +22,10 @@ type IncomingRuleResult struct {
 	Rule       string              `json:"rule"`
 	Violations []IncomingViolation `json:"violations"`
 	Message    string              `json:"message"`
+	// Weight and Category are sent by newer scorers. Rule results without them fall back
+	// to the rule catalog.
+	Weight   *float64 `json:"weight,omitempty"`
+	Category *string  `json:"category,omitempty"`
 }
 
 // IncomingViolation represents a violation from the schema scorer
@@ -38,15 +43,17 @@ type IncomingLocation struct {
 	Coordinate *string `json:"coordinate"`
 }
 
-// ToDomainEntity converts the incoming DTO to domain entities
-func (ir *IncomingReport) ToDomainEntity() (*SchemaReport, []RuleResult, error) {
+// ToDomainEntity converts the incoming DTO to domain entities, weighing rule results that
+// do not send their weight or category with the given definitions. It returns a
+// *ValidationError wrapping ErrInvalidReport when a field cannot be converted.
+func (ir *IncomingReport) ToDomainEntity(definitions RuleDefinitions) (*SchemaReport, []RuleResult, error) {
 	// Parse timestamp
-	timestamp, err := time.Parse(time.RFC3339, ir.Timestamp)
+	timestamp, err := ParseReportTimestamp(ir.Timestamp)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, NewValidationError(ErrInvalidReport, FieldError{Path: "timestamp", Reason: timestampReason})
 	}
 
-	id := uuid.NewString()
+	id := NewID()
 
 	// Create schema report
 	report := NewSchemaReport(
@@ -61,7 +68,8 @@ func (ir *IncomingReport) ToDomainEntity() (*SchemaReport, []RuleResult, error)
 
 	// Convert rule results
 	var ruleResults []RuleResult
-	for _, incomingRuleResult := range ir.RuleResults {
+	var fields []FieldError
+	for i, incomingRuleResult := range ir.RuleResults {
 		ruleResult := RuleResult{
 			RuleName:       incomingRuleResult.Rule,
 			ViolationCount: len(incomingRuleResult.Violations),
@@ -81,9 +89,16 @@ func (ir *IncomingReport) ToDomainEntity() (*SchemaReport, []RuleResult, error)
 			}
 			ruleResult.Violations = append(ruleResult.Violations, violation)
 		}
+		ruleResult.Weigh(definitions, incomingRuleResult.Weight, incomingRuleResult.Category)
+		if reason := invalidContribution(ruleResult); reason != "" {
+			fields = append(fields, FieldError{Path: fmt.Sprintf("ruleResults[%d].violations", i), Reason: reason})
+		}
 
 		ruleResults = append(ruleResults, ruleResult)
 	}
+	if len(fields) > 0 {
+		return nil, nil, NewValidationError(ErrInvalidReport, fields...)
+	}
 
 	return report, ruleResults, nil
 }
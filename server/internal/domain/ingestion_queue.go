@@ -0,0 +1,319 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Ingestion ticket statuses
+const (
+	IngestionStatusQueued     = "queued"
+	IngestionStatusProcessing = "processing"
+	IngestionStatusStored     = "stored"
+	IngestionStatusFailed     = "failed"
+)
+
+const (
+	defaultTicketRetention = time.Hour
+	defaultDrainTimeout    = 30 * time.Second
+	ticketPruneInterval    = time.Minute
+)
+
+// IngestionTicket tracks a report from the moment it is queued until it is stored
+type IngestionTicket struct {
+	ID           string
+	Status       string
+	SubgraphName string
+	ReportID     string
+	Error        string
+	GateResult   *GateResult
+	GateError    string
+	QueuedAt     time.Time
+	UpdatedAt    time.Time
+}
+
+// Done reports whether the ticket has reached a final status
+func (t IngestionTicket) Done() bool {
+	return t.Status == IngestionStatusStored || t.Status == IngestionStatusFailed
+}
+
+// IngestionQueueStats describes the current load of an ingestion queue
+type IngestionQueueStats struct {
+	Depth      int
+	Capacity   int
+	Workers    int
+	InProgress int
+	Stored     int64
+	Failed     int64
+	Rejected   int64
+}
+
+// IngestionQueue accepts reports faster than the database can store them. Reports wait
+// in a bounded in-memory queue that a fixed pool of workers stores through the
+// SchemaReportService, which caps the database connections that ingestion uses. A full
+// queue rejects new reports instead of blocking the request.
+//
+// Queued reports and tickets live in memory: they are only visible to the instance that
+// accepted them, and reports that are still queued when the process is killed are lost.
+type IngestionQueue struct {
+	service      *SchemaReportService
+	gateService  *GateService
+	workers      int
+	retention    time.Duration
+	drainTimeout time.Duration
+	now          func() time.Time
+
+	jobs chan ingestionJob
+
+	mu         sync.RWMutex
+	closed     bool
+	tickets    map[string]*IngestionTicket
+	inProgress int
+	stored     int64
+	failed     int64
+	rejected   int64
+}
+
+type ingestionJob struct {
+	ticketID   string
+	submission ReportSubmission
+}
+
+// IngestionQueueOption configures optional features of the ingestion queue
+type IngestionQueueOption func(*IngestionQueue)
+
+// WithIngestionGates evaluates every stored report against the quality gate of its
+// subgraph and records the result on the ticket
+func WithIngestionGates(gateService *GateService) IngestionQueueOption {
+	return func(q *IngestionQueue) {
+		q.gateService = gateService
+	}
+}
+
+// WithTicketRetention sets how long finished tickets can be looked up
+func WithTicketRetention(retention time.Duration) IngestionQueueOption {
+	return func(q *IngestionQueue) {
+		q.retention = retention
+	}
+}
+
+// WithDrainTimeout sets how long the queue keeps storing queued reports after Run is
+// cancelled. Reports that are not stored by then fail.
+func WithDrainTimeout(timeout time.Duration) IngestionQueueOption {
+	return func(q *IngestionQueue) {
+		q.drainTimeout = timeout
+	}
+}
+
+// NewIngestionQueue creates a queue that holds up to capacity reports and stores them
+// with the given number of workers
+func NewIngestionQueue(service *SchemaReportService, capacity, workers int, opts ...IngestionQueueOption) *IngestionQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	q := &IngestionQueue{
+		service:      service,
+		workers:      workers,
+		retention:    defaultTicketRetention,
+		drainTimeout: defaultDrainTimeout,
+		now:          time.Now,
+		jobs:         make(chan ingestionJob, capacity),
+		tickets:      make(map[string]*IngestionTicket),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// Enqueue queues a report for storage and returns its ticket. It fails with
+// ErrIngestionQueueFull instead of waiting when the queue has no room left.
+func (q *IngestionQueue) Enqueue(submission ReportSubmission) (IngestionTicket, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return IngestionTicket{}, ErrIngestionQueueClosed
+	}
+
+	now := q.now()
+	ticket := &IngestionTicket{
+		ID:           NewID(),
+		Status:       IngestionStatusQueued,
+		SubgraphName: submission.Report.SubgraphName,
+		QueuedAt:     now,
+		UpdatedAt:    now,
+	}
+
+	select {
+	case q.jobs <- ingestionJob{ticketID: ticket.ID, submission: submission}:
+	default:
+		q.rejected++
+		return IngestionTicket{}, ErrIngestionQueueFull
+	}
+
+	q.tickets[ticket.ID] = ticket
+	return *ticket, nil
+}
+
+// Ticket returns the current state of a ticket
+func (q *IngestionQueue) Ticket(id string) (IngestionTicket, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	ticket, ok := q.tickets[id]
+	if !ok {
+		return IngestionTicket{}, fmt.Errorf("ticket %s: %w", id, ErrIngestionTicketNotFound)
+	}
+	return *ticket, nil
+}
+
+// Stats returns the queue depth and the number of processed reports
+func (q *IngestionQueue) Stats() IngestionQueueStats {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return IngestionQueueStats{
+		Depth:      len(q.jobs),
+		Capacity:   cap(q.jobs),
+		Workers:    q.workers,
+		InProgress: q.inProgress,
+		Stored:     q.stored,
+		Failed:     q.failed,
+		Rejected:   q.rejected,
+	}
+}
+
+// Run stores queued reports until the context is cancelled. It then stops accepting
+// reports and returns once the workers have stored everything that was already queued,
+// or once the drain timeout has passed and the remaining reports have failed.
+func (q *IngestionQueue) Run(ctx context.Context) {
+	// Reports that were accepted must still be stored while shutting down, but not forever
+	storeCtx, cancelStore := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range q.jobs {
+				q.process(storeCtx, job)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(ticketPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.close()
+			before := q.Stats()
+			if pending := before.Depth + before.InProgress; pending > 0 {
+				log.Printf("Storing %d queued reports before shutting down", pending)
+			}
+			drainDeadline := time.AfterFunc(q.drainTimeout, cancelStore)
+			wg.Wait()
+			drainDeadline.Stop()
+
+			// Tickets live in memory, so a report that fails now is lost for good
+			after := q.Stats()
+			if lost := after.Failed - before.Failed; lost > 0 {
+				log.Printf("Lost %d of %d queued reports while shutting down, stored %d",
+					lost, before.Depth+before.InProgress, after.Stored-before.Stored)
+			}
+			return
+		case <-ticker.C:
+			q.pruneTickets()
+		}
+	}
+}
+
+// process stores one queued report and records the outcome on its ticket
+func (q *IngestionQueue) process(ctx context.Context, job ingestionJob) {
+	q.update(job.ticketID, func(ticket *IngestionTicket) {
+		ticket.Status = IngestionStatusProcessing
+		q.inProgress++
+	})
+
+	report, err := q.service.StoreSubmission(ctx, job.submission)
+	if err != nil {
+		log.Printf("Error storing queued report %s: %v", job.ticketID, err)
+		q.update(job.ticketID, func(ticket *IngestionTicket) {
+			ticket.Status = IngestionStatusFailed
+			ticket.Error = "failed to store report"
+			q.inProgress--
+			q.failed++
+		})
+		return
+	}
+
+	log.Printf("Stored queued report %s for subgraph: %v, score: %.2f", job.ticketID,
+		report.SubgraphName, report.Score)
+
+	// The report is already stored, so a gate error is recorded instead of failing the ticket
+	var gateResult *GateResult
+	var gateError string
+	if q.gateService != nil {
+		gateResult, err = q.gateService.Evaluate(ctx, report)
+		if err != nil {
+			log.Printf("Error evaluating quality gate: %v", err)
+			gateResult, gateError = nil, "failed to evaluate quality gate"
+		}
+	}
+
+	q.update(job.ticketID, func(ticket *IngestionTicket) {
+		ticket.Status = IngestionStatusStored
+		ticket.ReportID = report.ID
+		ticket.GateResult = gateResult
+		ticket.GateError = gateError
+		q.inProgress--
+		q.stored++
+	})
+}
+
+// update changes a ticket and its counters under the lock
+func (q *IngestionQueue) update(ticketID string, change func(ticket *IngestionTicket)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ticket, ok := q.tickets[ticketID]
+	if !ok {
+		return
+	}
+	change(ticket)
+	ticket.UpdatedAt = q.now()
+}
+
+// close stops accepting reports; the workers stop once the queue is empty
+func (q *IngestionQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+}
+
+// pruneTickets forgets finished tickets once the retention period has passed
+func (q *IngestionQueue) pruneTickets() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cutoff := q.now().Add(-q.retention)
+	for id, ticket := range q.tickets {
+		if ticket.Done() && ticket.UpdatedAt.Before(cutoff) {
+			delete(q.tickets, id)
+		}
+	}
+}
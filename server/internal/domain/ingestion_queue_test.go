@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestSubmission(subgraph string) ReportSubmission {
+	return ReportSubmission{
+		Report: NewSchemaReport("", &subgraph, 85, 100, 15, time.Now(), nil),
+		RuleResults: []RuleResult{
+			{RuleName: "Naming", ViolationCount: 1, Violations: []Violation{{Message: "Field names must be camelCase"}}},
+		},
+	}
+}
+
+// runQueue runs the queue in the background and returns a function that stops it and
+// waits until the queued reports are stored
+func runQueue(queue *IngestionQueue) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		queue.Run(ctx)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestIngestionQueue_StoresQueuedReports(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	queue := NewIngestionQueue(NewSchemaReportService(repo), 10, 1)
+	stop := runQueue(queue)
+
+	submission := newTestSubmission("user-service")
+	tokenID := "7"
+	submission.APITokenID = &tokenID
+
+	ticket, err := queue.Enqueue(submission)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ticket.ID)
+	assert.Equal(t, IngestionStatusQueued, ticket.Status)
+	assert.Equal(t, "user-service", ticket.SubgraphName)
+
+	assert.Eventually(t, func() bool {
+		current, err := queue.Ticket(ticket.ID)
+		return err == nil && current.Status == IngestionStatusStored
+	}, 2*time.Second, 5*time.Millisecond)
+
+	stop()
+
+	stored, err := queue.Ticket(ticket.ID)
+	assert.NoError(t, err)
+	assert.True(t, stored.Done())
+	assert.Contains(t, repo.Reports, stored.ReportID)
+	assert.Equal(t, &tokenID, repo.Reports[stored.ReportID].APITokenID)
+	assert.Len(t, repo.Reports[stored.ReportID].RuleResults, 1)
+
+	stats := queue.Stats()
+	assert.Equal(t, int64(1), stats.Stored)
+	assert.Equal(t, 0, stats.Depth)
+	assert.Equal(t, 0, stats.InProgress)
+}
+
+func TestIngestionQueue_RejectsWhenFull(t *testing.T) {
+	queue := NewIngestionQueue(NewSchemaReportService(NewMockSchemaReportRepository()), 2, 1)
+
+	// Without running workers nothing leaves the queue
+	for i := 0; i < 2; i++ {
+		_, err := queue.Enqueue(newTestSubmission("user-service"))
+		assert.NoError(t, err)
+	}
+
+	_, err := queue.Enqueue(newTestSubmission("user-service"))
+	assert.ErrorIs(t, err, ErrIngestionQueueFull)
+
+	stats := queue.Stats()
+	assert.Equal(t, 2, stats.Depth)
+	assert.Equal(t, 2, stats.Capacity)
+	assert.Equal(t, int64(1), stats.Rejected)
+}
+
+func TestIngestionQueue_DrainsOnShutdown(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	queue := NewIngestionQueue(NewSchemaReportService(repo), 10, 1)
+
+	var tickets []IngestionTicket
+	for i := 0; i < 5; i++ {
+		ticket, err := queue.Enqueue(newTestSubmission("user-service"))
+		assert.NoError(t, err)
+		tickets = append(tickets, ticket)
+	}
+
+	// Cancelling before the workers start must still store everything that was accepted
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx)
+
+	assert.Len(t, repo.Reports, 5)
+	for _, ticket := range tickets {
+		current, err := queue.Ticket(ticket.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, IngestionStatusStored, current.Status)
+	}
+
+	_, err := queue.Enqueue(newTestSubmission("user-service"))
+	assert.ErrorIs(t, err, ErrIngestionQueueClosed)
+}
+
+func TestIngestionQueue_DrainFailure(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	repo.ShouldFailStore = true
+	queue := NewIngestionQueue(NewSchemaReportService(repo), 10, 2)
+
+	for i := 0; i < 3; i++ {
+		_, err := queue.Enqueue(newTestSubmission("user-service"))
+		assert.NoError(t, err)
+	}
+
+	// Reports that fail while draining are counted as failed rather than left queued
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx)
+
+	stats := queue.Stats()
+	assert.Equal(t, int64(3), stats.Failed)
+	assert.Equal(t, 0, stats.Depth)
+	assert.Equal(t, 0, stats.InProgress)
+}
+
+func TestIngestionQueue_DrainTimeout(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	repo.BlockStore = true
+	queue := NewIngestionQueue(NewSchemaReportService(repo), 10, 1, WithDrainTimeout(20*time.Millisecond))
+
+	for i := 0; i < 3; i++ {
+		_, err := queue.Enqueue(newTestSubmission("user-service"))
+		assert.NoError(t, err)
+	}
+
+	// A database that stops responding must not keep the queue from shutting down
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		queue.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("queue did not give up after the drain timeout")
+	}
+
+	stats := queue.Stats()
+	assert.Equal(t, int64(3), stats.Failed)
+	assert.Equal(t, 0, stats.Depth)
+	assert.Equal(t, 0, stats.InProgress)
+}
+
+func TestIngestionQueue_StoreFailure(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	repo.ShouldFailStore = true
+	queue := NewIngestionQueue(NewSchemaReportService(repo), 10, 1)
+
+	ticket, err := queue.Enqueue(newTestSubmission("user-service"))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx)
+
+	failed, err := queue.Ticket(ticket.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, IngestionStatusFailed, failed.Status)
+	assert.NotEmpty(t, failed.Error)
+	assert.Empty(t, failed.ReportID)
+	assert.Equal(t, int64(1), queue.Stats().Failed)
+}
+
+func TestIngestionQueue_GateEvaluation(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	gates := NewMockGateRepository().WithConfig(GateConfig{SubgraphName: "user-service", MinScore: float64Ptr(90)})
+	queue := NewIngestionQueue(NewSchemaReportService(repo), 10, 1,
+		WithIngestionGates(NewGateService(gates, repo)))
+
+	ticket, err := queue.Enqueue(newTestSubmission("user-service"))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx)
+
+	stored, err := queue.Ticket(ticket.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, IngestionStatusStored, stored.Status)
+	if assert.NotNil(t, stored.GateResult) {
+		assert.Equal(t, GateStatusFailed, stored.GateResult.Status)
+	}
+}
+
+func TestIngestionQueue_Tickets(t *testing.T) {
+	queue := NewIngestionQueue(NewSchemaReportService(NewMockSchemaReportRepository()), 10, 1,
+		WithTicketRetention(time.Hour))
+
+	_, err := queue.Ticket("missing")
+	assert.ErrorIs(t, err, ErrIngestionTicketNotFound)
+
+	ticket, err := queue.Enqueue(newTestSubmission("user-service"))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx)
+
+	// Finished tickets are kept for the retention period only
+	queue.pruneTickets()
+	_, err = queue.Ticket(ticket.ID)
+	assert.NoError(t, err)
+
+	queue.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	queue.pruneTickets()
+	_, err = queue.Ticket(ticket.ID)
+	assert.ErrorIs(t, err, ErrIngestionTicketNotFound)
+}
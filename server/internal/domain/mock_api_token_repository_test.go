@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MockAPITokenRepository is a mock implementation for testing
+type MockAPITokenRepository struct {
+	// Control behavior
+	ShouldFailGet bool
+
+	// Storage for test data
+	Tokens map[string]*APIToken
+	nextID int
+}
+
+// NewMockAPITokenRepository creates a new mock API token repository
+func NewMockAPITokenRepository() *MockAPITokenRepository {
+	return &MockAPITokenRepository{
+		Tokens: make(map[string]*APIToken),
+	}
+}
+
+// Store saves a token (mock implementation)
+func (m *MockAPITokenRepository) Store(ctx context.Context, token *APIToken) error {
+	m.nextID++
+	token.ID = strconv.Itoa(m.nextID)
+	m.Tokens[token.ID] = token
+	return nil
+}
+
+// GetByHash retrieves a token by hash (mock implementation)
+func (m *MockAPITokenRepository) GetByHash(ctx context.Context, hash string) (*APIToken, error) {
+	if m.ShouldFailGet {
+		return nil, errors.New("mock get API token error")
+	}
+
+	for _, token := range m.Tokens {
+		if token.Hash == hash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("API token: %w", ErrAPITokenNotFound)
+}
+
+// List retrieves all tokens (mock implementation)
+func (m *MockAPITokenRepository) List(ctx context.Context) ([]APIToken, error) {
+	tokens := []APIToken{}
+	for i := 1; i <= m.nextID; i++ {
+		if token, exists := m.Tokens[strconv.Itoa(i)]; exists {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+// Revoke marks a token as revoked (mock implementation)
+func (m *MockAPITokenRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	token, exists := m.Tokens[id]
+	if !exists {
+		return fmt.Errorf("API token with ID %s: %w", id, ErrAPITokenNotFound)
+	}
+
+	token.RevokedAt = &at
+	return nil
+}
+
+// TouchLastUsed records token usage (mock implementation)
+func (m *MockAPITokenRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	if token, exists := m.Tokens[id]; exists {
+		token.LastUsedAt = &at
+	}
+	return nil
+}
+
+// WithToken stores a token for the given plaintext value
+func (m *MockAPITokenRepository) WithToken(plaintext string, subgraphs ...string) *MockAPITokenRepository {
+	_ = m.Store(context.Background(), &APIToken{
+		Name:      "test",
+		Prefix:    plaintext[:len(APITokenPrefix)],
+		Hash:      HashAPIToken(plaintext),
+		Subgraphs: subgraphs,
+		CreatedAt: time.Now(),
+	})
+	return m
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MockGateRepository is a mock implementation for testing
+type MockGateRepository struct {
+	// Control behavior
+	ShouldFailGet  bool
+	ShouldFailSave bool
+
+	// Storage for test data
+	Configs map[string]*GateConfig
+}
+
+// NewMockGateRepository creates a new mock gate repository
+func NewMockGateRepository() *MockGateRepository {
+	return &MockGateRepository{
+		Configs: make(map[string]*GateConfig),
+	}
+}
+
+// GetGateConfig retrieves a gate configuration (mock implementation)
+func (m *MockGateRepository) GetGateConfig(ctx context.Context, subgraphName string) (*GateConfig, error) {
+	if m.ShouldFailGet {
+		return nil, errors.New("mock get gate config error")
+	}
+
+	config, exists := m.Configs[subgraphName]
+	if !exists {
+		return nil, fmt.Errorf("gate config for subgraph %s: %w", subgraphName, ErrGateConfigNotFound)
+	}
+
+	copied := *config
+	return &copied, nil
+}
+
+// ListGateConfigs retrieves all gate configurations (mock implementation)
+func (m *MockGateRepository) ListGateConfigs(ctx context.Context) ([]GateConfig, error) {
+	configs := []GateConfig{}
+	for _, config := range m.Configs {
+		configs = append(configs, *config)
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].SubgraphName < configs[j].SubgraphName
+	})
+
+	return configs, nil
+}
+
+// SaveGateConfig stores a gate configuration (mock implementation)
+func (m *MockGateRepository) SaveGateConfig(ctx context.Context, config *GateConfig) error {
+	if m.ShouldFailSave {
+		return errors.New("mock save gate config error")
+	}
+
+	m.Configs[config.SubgraphName] = config
+	return nil
+}
+
+// DeleteGateConfig removes a gate configuration (mock implementation)
+func (m *MockGateRepository) DeleteGateConfig(ctx context.Context, subgraphName string) error {
+	if _, exists := m.Configs[subgraphName]; !exists {
+		return fmt.Errorf("gate config for subgraph %s: %w", subgraphName, ErrGateConfigNotFound)
+	}
+
+	delete(m.Configs, subgraphName)
+	return nil
+}
+
+// WithConfig adds a gate configuration to the mock storage
+func (m *MockGateRepository) WithConfig(config GateConfig) *MockGateRepository {
+	m.Configs[config.SubgraphName] = &config
+	return m
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MockRescoreRepository is a mock implementation for testing
+type MockRescoreRepository struct {
+	// Control behavior
+	ShouldFailSaveScores bool
+
+	// Storage for test data
+	Versions map[string]*RulesetVersion
+	Scores   []RescoredScore
+}
+
+// NewMockRescoreRepository creates a new mock rescore repository
+func NewMockRescoreRepository() *MockRescoreRepository {
+	return &MockRescoreRepository{
+		Versions: make(map[string]*RulesetVersion),
+	}
+}
+
+// SaveRulesetVersion stores a ruleset version (mock implementation)
+func (m *MockRescoreRepository) SaveRulesetVersion(ctx context.Context, version *RulesetVersion) error {
+	if _, exists := m.Versions[version.Version]; !exists {
+		copied := *version
+		m.Versions[version.Version] = &copied
+	}
+	return nil
+}
+
+// GetRulesetVersion retrieves a ruleset version (mock implementation)
+func (m *MockRescoreRepository) GetRulesetVersion(ctx context.Context, version string) (*RulesetVersion, error) {
+	ruleset, exists := m.Versions[version]
+	if !exists {
+		return nil, fmt.Errorf("ruleset version %s: %w", version, ErrRulesetVersionNotFound)
+	}
+
+	copied := *ruleset
+	return &copied, nil
+}
+
+// ListRulesetVersions retrieves all ruleset versions, newest first (mock implementation)
+func (m *MockRescoreRepository) ListRulesetVersions(ctx context.Context) ([]RulesetVersion, error) {
+	versions := []RulesetVersion{}
+	for _, version := range m.Versions {
+		versions = append(versions, *version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+	return versions, nil
+}
+
+// SaveRescoredScores replaces the scores of reports for their version (mock implementation)
+func (m *MockRescoreRepository) SaveRescoredScores(ctx context.Context, scores []RescoredScore) error {
+	if m.ShouldFailSaveScores {
+		return errors.New("mock save rescored scores error")
+	}
+
+	for _, score := range scores {
+		replaced := false
+		for i, stored := range m.Scores {
+			if stored.ReportID == score.ReportID && stored.RulesetVersion == score.RulesetVersion {
+				m.Scores[i] = score
+				replaced = true
+			}
+		}
+		if !replaced {
+			m.Scores = append(m.Scores, score)
+		}
+	}
+	return nil
+}
+
+// GetRescoredScores retrieves the scores of a subgraph for a version (mock implementation)
+func (m *MockRescoreRepository) GetRescoredScores(ctx context.Context, subgraphName, version string) ([]RescoredScore, error) {
+	scores := []RescoredScore{}
+	for _, score := range m.Scores {
+		if score.SubgraphName == subgraphName && score.RulesetVersion == version {
+			scores = append(scores, score)
+		}
+	}
+	return scores, nil
+}
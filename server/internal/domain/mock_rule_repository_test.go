@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MockRuleRepository is a mock implementation for testing
+type MockRuleRepository struct {
+	// Control behavior
+	ShouldFailRegister bool
+
+	// Storage for test data
+	Rules map[string]*Rule
+}
+
+// NewMockRuleRepository creates a new mock rule repository
+func NewMockRuleRepository() *MockRuleRepository {
+	return &MockRuleRepository{
+		Rules: make(map[string]*Rule),
+	}
+}
+
+// GetRule retrieves a rule (mock implementation)
+func (m *MockRuleRepository) GetRule(ctx context.Context, name string) (*Rule, error) {
+	rule, exists := m.Rules[name]
+	if !exists {
+		return nil, fmt.Errorf("rule %s: %w", name, ErrRuleNotFound)
+	}
+
+	copied := *rule
+	return &copied, nil
+}
+
+// ListRules retrieves all rules (mock implementation)
+func (m *MockRuleRepository) ListRules(ctx context.Context) ([]Rule, error) {
+	rules := []Rule{}
+	for _, rule := range m.Rules {
+		rules = append(rules, *rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+
+	return rules, nil
+}
+
+// CreateRule stores a new rule (mock implementation)
+func (m *MockRuleRepository) CreateRule(ctx context.Context, rule *Rule) error {
+	if _, exists := m.Rules[rule.Name]; exists {
+		return fmt.Errorf("rule %s: %w", rule.Name, ErrRuleExists)
+	}
+
+	copied := *rule
+	m.Rules[rule.Name] = &copied
+	return nil
+}
+
+// UpdateRule replaces an existing rule (mock implementation)
+func (m *MockRuleRepository) UpdateRule(ctx context.Context, rule *Rule) error {
+	if _, exists := m.Rules[rule.Name]; !exists {
+		return fmt.Errorf("rule %s: %w", rule.Name, ErrRuleNotFound)
+	}
+
+	copied := *rule
+	m.Rules[rule.Name] = &copied
+	return nil
+}
+
+// DeleteRule removes a rule (mock implementation)
+func (m *MockRuleRepository) DeleteRule(ctx context.Context, name string) error {
+	if _, exists := m.Rules[name]; !exists {
+		return fmt.Errorf("rule %s: %w", name, ErrRuleNotFound)
+	}
+
+	delete(m.Rules, name)
+	return nil
+}
+
+// RegisterRules adds the rules that are not stored yet (mock implementation)
+func (m *MockRuleRepository) RegisterRules(ctx context.Context, rules []Rule) ([]string, error) {
+	if m.ShouldFailRegister {
+		return nil, errors.New("mock register rules error")
+	}
+
+	var registered []string
+	for _, rule := range rules {
+		if _, exists := m.Rules[rule.Name]; exists {
+			continue
+		}
+		copied := rule
+		m.Rules[rule.Name] = &copied
+		registered = append(registered, rule.Name)
+	}
+	return registered, nil
+}
+
+// WithRule adds a rule to the mock storage
+func (m *MockRuleRepository) WithRule(rule Rule) *MockRuleRepository {
+	m.Rules[rule.Name] = &rule
+	return m
+}
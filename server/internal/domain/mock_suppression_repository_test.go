@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MockSuppressionRepository is a mock implementation for testing
+type MockSuppressionRepository struct {
+	// Control behavior
+	ShouldFailStore     bool
+	ShouldFailGetActive bool
+
+	// Storage for test data
+	Suppressions map[string]*Suppression
+	nextID       int
+}
+
+// NewMockSuppressionRepository creates a new mock suppression repository
+func NewMockSuppressionRepository() *MockSuppressionRepository {
+	return &MockSuppressionRepository{
+		Suppressions: make(map[string]*Suppression),
+	}
+}
+
+// Store saves a suppression (mock implementation)
+func (m *MockSuppressionRepository) Store(ctx context.Context, suppression *Suppression) error {
+	if m.ShouldFailStore {
+		return errors.New("mock store error")
+	}
+
+	m.nextID++
+	suppression.ID = strconv.Itoa(m.nextID)
+	m.Suppressions[suppression.ID] = suppression
+
+	return nil
+}
+
+// GetByID retrieves a suppression by ID (mock implementation)
+func (m *MockSuppressionRepository) GetByID(ctx context.Context, id string) (*Suppression, error) {
+	suppression, exists := m.Suppressions[id]
+	if !exists {
+		return nil, fmt.Errorf("suppression with ID %s: %w", id, ErrSuppressionNotFound)
+	}
+
+	copied := *suppression
+	return &copied, nil
+}
+
+// List retrieves suppressions (mock implementation)
+func (m *MockSuppressionRepository) List(ctx context.Context, subgraphName string) ([]Suppression, error) {
+	suppressions := []Suppression{}
+	for _, suppression := range m.Suppressions {
+		if subgraphName == "" || suppression.SubgraphName == subgraphName {
+			suppressions = append(suppressions, *suppression)
+		}
+	}
+
+	sort.Slice(suppressions, func(i, j int) bool {
+		return suppressions[i].ID < suppressions[j].ID
+	})
+
+	return suppressions, nil
+}
+
+// Update saves changes to a suppression (mock implementation)
+func (m *MockSuppressionRepository) Update(ctx context.Context, suppression *Suppression) error {
+	if _, exists := m.Suppressions[suppression.ID]; !exists {
+		return fmt.Errorf("suppression with ID %s: %w", suppression.ID, ErrSuppressionNotFound)
+	}
+
+	m.Suppressions[suppression.ID] = suppression
+	return nil
+}
+
+// Delete removes a suppression (mock implementation)
+func (m *MockSuppressionRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.Suppressions[id]; !exists {
+		return fmt.Errorf("suppression with ID %s: %w", id, ErrSuppressionNotFound)
+	}
+
+	delete(m.Suppressions, id)
+	return nil
+}
+
+// GetActive retrieves active suppressions (mock implementation)
+func (m *MockSuppressionRepository) GetActive(ctx context.Context, subgraphName string, at time.Time) ([]Suppression, error) {
+	if m.ShouldFailGetActive {
+		return nil, errors.New("mock get active suppressions error")
+	}
+
+	suppressions := []Suppression{}
+	for _, suppression := range m.Suppressions {
+		if suppression.SubgraphName == subgraphName && suppression.IsActive(at) {
+			suppressions = append(suppressions, *suppression)
+		}
+	}
+
+	return suppressions, nil
+}
+
+// WithSuppression adds a suppression to the mock storage
+func (m *MockSuppressionRepository) WithSuppression(suppression Suppression) *MockSuppressionRepository {
+	_ = m.Store(context.Background(), &suppression)
+	return m
+}
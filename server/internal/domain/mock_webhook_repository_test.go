@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MockWebhookRepository is a mock implementation for testing
+type MockWebhookRepository struct {
+	// Control behavior
+	ShouldFailEnqueue bool
+	ShouldFailClaim   bool
+
+	// Storage for test data
+	Webhooks     map[string]*Webhook
+	Deliveries   map[string]*WebhookDelivery
+	nextID       int
+	nextDelivery int
+}
+
+// NewMockWebhookRepository creates a new mock webhook repository
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		Webhooks:   make(map[string]*Webhook),
+		Deliveries: make(map[string]*WebhookDelivery),
+	}
+}
+
+// StoreWebhook saves a webhook (mock implementation)
+func (m *MockWebhookRepository) StoreWebhook(ctx context.Context, webhook *Webhook) error {
+	m.nextID++
+	webhook.ID = strconv.Itoa(m.nextID)
+	m.Webhooks[webhook.ID] = webhook
+	return nil
+}
+
+// GetWebhook retrieves a webhook by ID (mock implementation)
+func (m *MockWebhookRepository) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	webhook, exists := m.Webhooks[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook with ID %s: %w", id, ErrWebhookNotFound)
+	}
+
+	copied := *webhook
+	return &copied, nil
+}
+
+// ListWebhooks retrieves all webhooks (mock implementation)
+func (m *MockWebhookRepository) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	return m.ListWebhooksForSubgraph(ctx, "")
+}
+
+// ListWebhooksForSubgraph retrieves global and subgraph webhooks (mock implementation)
+func (m *MockWebhookRepository) ListWebhooksForSubgraph(ctx context.Context, subgraphName string) ([]Webhook, error) {
+	webhooks := []Webhook{}
+	for _, webhook := range m.Webhooks {
+		if subgraphName == "" || webhook.AppliesTo(subgraphName) {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries (mock implementation)
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	if _, exists := m.Webhooks[id]; !exists {
+		return fmt.Errorf("webhook with ID %s: %w", id, ErrWebhookNotFound)
+	}
+
+	delete(m.Webhooks, id)
+	for deliveryID, delivery := range m.Deliveries {
+		if delivery.WebhookID == id {
+			delete(m.Deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// EnqueueDeliveries adds deliveries to the outbox (mock implementation)
+func (m *MockWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []WebhookDelivery) error {
+	if m.ShouldFailEnqueue {
+		return errors.New("mock enqueue error")
+	}
+	// Like a database, refuse work for a cancelled request
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		m.nextDelivery++
+		deliveries[i].ID = strconv.Itoa(m.nextDelivery)
+		delivery := deliveries[i]
+		m.Deliveries[delivery.ID] = &delivery
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns due pending deliveries (mock implementation)
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]WebhookDelivery, error) {
+	if m.ShouldFailClaim {
+		return nil, errors.New("mock claim error")
+	}
+
+	deliveries := []WebhookDelivery{}
+	for _, delivery := range m.Deliveries {
+		if delivery.Status == DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = now.Add(lease)
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// SaveDeliveryAttempt records an attempt (mock implementation)
+func (m *MockWebhookRepository) SaveDeliveryAttempt(ctx context.Context, delivery *WebhookDelivery, attempt DeliveryAttempt) error {
+	if _, exists := m.Deliveries[delivery.ID]; !exists {
+		return fmt.Errorf("webhook delivery with ID %s: %w", delivery.ID, ErrWebhookDeliveryNotFound)
+	}
+
+	copied := *delivery
+	m.Deliveries[delivery.ID] = &copied
+	return nil
+}
+
+// GetDelivery retrieves a delivery by ID (mock implementation)
+func (m *MockWebhookRepository) GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	delivery, exists := m.Deliveries[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook delivery with ID %s: %w", id, ErrWebhookDeliveryNotFound)
+	}
+
+	copied := *delivery
+	return &copied, nil
+}
+
+// ListDeliveries retrieves deliveries (mock implementation)
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+	for _, delivery := range m.Deliveries {
+		if (webhookID == "" || delivery.WebhookID == webhookID) && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+// RetryDelivery makes a delivery pending again (mock implementation)
+func (m *MockWebhookRepository) RetryDelivery(ctx context.Context, id string, at time.Time) error {
+	delivery, exists := m.Deliveries[id]
+	if !exists {
+		return fmt.Errorf("webhook delivery with ID %s: %w", id, ErrWebhookDeliveryNotFound)
+	}
+
+	delivery.Status = DeliveryStatusPending
+	delivery.NextAttemptAt = at
+	return nil
+}
+
+// WithWebhook adds a webhook to the mock storage
+func (m *MockWebhookRepository) WithWebhook(webhook Webhook) *MockWebhookRepository {
+	_ = m.StoreWebhook(context.Background(), &webhook)
+	return m
+}
+
+// WithDelivery adds a delivery to the mock outbox
+func (m *MockWebhookRepository) WithDelivery(delivery WebhookDelivery) *MockWebhookRepository {
+	_ = m.EnqueueDeliveries(context.Background(), []WebhookDelivery{delivery})
+	return m
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Gate statuses returned by a quality gate evaluation
+const (
+	GateStatusPassed  = "passed"
+	GateStatusFailed  = "failed"
+	GateStatusSkipped = "skipped"
+)
+
+// Gate checks that can fail
+const (
+	GateCheckMinScore          = "min_score"
+	GateCheckMaxScoreDrop      = "max_score_drop"
+	GateCheckRuleMaxViolations = "rule_max_violations"
+)
+
+// DefaultGateBranch is the branch used as baseline when a gate does not configure one
+const DefaultGateBranch = "main"
+
+// BranchMetadataKey is the report metadata key holding the branch a report was created on
+const BranchMetadataKey = "branch"
+
+// GateConfig holds the quality gate policy of a subgraph
+type GateConfig struct {
+	SubgraphName      string
+	MinScore          *float64
+	MaxScoreDrop      *float64
+	DefaultBranch     string
+	RuleMaxViolations map[string]int
+	UpdatedAt         time.Time
+}
+
+// GateFailure explains why a quality gate check failed
+type GateFailure struct {
+	Check     string
+	RuleName  string
+	Threshold float64
+	Actual    float64
+	Message   string
+}
+
+// GateResult is the verdict of evaluating a report against a quality gate
+type GateResult struct {
+	Status           string
+	SubgraphName     string
+	Score            float64
+	BaselineReportID *string
+	BaselineScore    *float64
+	Failures         []GateFailure
+	Notes            []string
+}
+
+// Passed reports whether the gate did not fail
+func (gr *GateResult) Passed() bool {
+	return gr.Status != GateStatusFailed
+}
+
+// Validate checks that the gate configuration is usable
+func (gc GateConfig) Validate() error {
+	var problems []string
+	if strings.TrimSpace(gc.SubgraphName) == "" {
+		problems = append(problems, "subgraph name is required")
+	}
+	if gc.MaxScoreDrop != nil && *gc.MaxScoreDrop < 0 {
+		problems = append(problems, "maximum score drop must not be negative")
+	}
+	for rule, max := range gc.RuleMaxViolations {
+		if max < 0 {
+			problems = append(problems, fmt.Sprintf("maximum violations for rule %q must not be negative", rule))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", ErrInvalidGateConfig, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// BaselineBranch returns the branch whose latest report is used as baseline
+func (gc GateConfig) BaselineBranch() string {
+	if gc.DefaultBranch == "" {
+		return DefaultGateBranch
+	}
+	return gc.DefaultBranch
+}
+
+// Evaluate checks a report against the gate. The effective score and unsuppressed
+// violation counts are used, so accepted violations never fail a gate. The baseline
+// is the latest report on the default branch and may be nil.
+func (gc GateConfig) Evaluate(report *SchemaReport, baseline *SchemaReport) *GateResult {
+	result := &GateResult{
+		Status:       GateStatusPassed,
+		SubgraphName: report.SubgraphName,
+		Score:        report.EffectiveScore,
+		Failures:     make([]GateFailure, 0),
+		Notes:        make([]string, 0),
+	}
+
+	if gc.MinScore != nil && report.EffectiveScore < *gc.MinScore {
+		result.Failures = append(result.Failures, GateFailure{
+			Check:     GateCheckMinScore,
+			Threshold: *gc.MinScore,
+			Actual:    report.EffectiveScore,
+			Message:   fmt.Sprintf("score %.2f is below the minimum of %.2f", report.EffectiveScore, *gc.MinScore),
+		})
+	}
+
+	if gc.MaxScoreDrop != nil {
+		if baseline == nil {
+			result.Notes = append(result.Notes,
+				fmt.Sprintf("no baseline report on branch %q, score drop not checked", gc.BaselineBranch()))
+		} else {
+			baselineID := baseline.ID
+			baselineScore := baseline.EffectiveScore
+			result.BaselineReportID = &baselineID
+			result.BaselineScore = &baselineScore
+
+			drop := baselineScore - report.EffectiveScore
+			if drop > *gc.MaxScoreDrop {
+				result.Failures = append(result.Failures, GateFailure{
+					Check:     GateCheckMaxScoreDrop,
+					Threshold: *gc.MaxScoreDrop,
+					Actual:    drop,
+					Message: fmt.Sprintf("score dropped %.2f points versus report %s on branch %q, the maximum is %.2f",
+						drop, baseline.ID, gc.BaselineBranch(), *gc.MaxScoreDrop),
+				})
+			}
+		}
+	}
+
+	if len(gc.RuleMaxViolations) > 0 {
+		counts := make(map[string]int)
+		for _, ruleResult := range report.RuleResults {
+			counts[ruleResult.RuleName] += len(ruleResult.ActiveViolations())
+		}
+
+		rules := make([]string, 0, len(gc.RuleMaxViolations))
+		for rule := range gc.RuleMaxViolations {
+			rules = append(rules, rule)
+		}
+		sort.Strings(rules)
+
+		for _, rule := range rules {
+			max := gc.RuleMaxViolations[rule]
+			if counts[rule] > max {
+				result.Failures = append(result.Failures, GateFailure{
+					Check:     GateCheckRuleMaxViolations,
+					RuleName:  rule,
+					Threshold: float64(max),
+					Actual:    float64(counts[rule]),
+					Message:   fmt.Sprintf("rule %q has %d violations, the maximum is %d", rule, counts[rule], max),
+				})
+			}
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		result.Status = GateStatusFailed
+	}
+
+	return result
+}
+
+// IncomingGateConfig represents the JSON structure used to configure a quality gate
+type IncomingGateConfig struct {
+	MinScore          *float64       `json:"minScore"`
+	MaxScoreDrop      *float64       `json:"maxScoreDrop"`
+	DefaultBranch     string         `json:"defaultBranch"`
+	RuleMaxViolations map[string]int `json:"ruleMaxViolations"`
+}
+
+// ToDomainEntity converts the incoming DTO to a gate configuration for a subgraph
+func (ig *IncomingGateConfig) ToDomainEntity(subgraphName string) *GateConfig {
+	ruleMaxViolations := ig.RuleMaxViolations
+	if ruleMaxViolations == nil {
+		ruleMaxViolations = make(map[string]int)
+	}
+
+	return &GateConfig{
+		SubgraphName:      subgraphName,
+		MinScore:          ig.MinScore,
+		MaxScoreDrop:      ig.MaxScoreDrop,
+		DefaultBranch:     ig.DefaultBranch,
+		RuleMaxViolations: ruleMaxViolations,
+	}
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func newGateTestReport(id string, score float64, branch string, violations int) *SchemaReport {
+	report := NewSchemaReport(id, stringPtr("user-service"), score, 100, 0, time.Now(),
+		map[string]interface{}{BranchMetadataKey: branch})
+
+	ruleResult := RuleResult{RuleName: "PII", ViolationCount: violations}
+	for i := 0; i < violations; i++ {
+		ruleResult.Violations = append(ruleResult.Violations, Violation{Message: "Field contains PII"})
+	}
+	report.AddRuleResult(ruleResult)
+
+	return report
+}
+
+func TestGateConfig_Evaluate(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           GateConfig
+		report           *SchemaReport
+		baseline         *SchemaReport
+		expectedStatus   string
+		expectedFailures []string
+		expectedNotes    int
+	}{
+		{
+			name:           "no thresholds",
+			config:         GateConfig{SubgraphName: "user-service"},
+			report:         newGateTestReport("2", 50, "feature", 3),
+			expectedStatus: GateStatusPassed,
+		},
+		{
+			name:             "below minimum score",
+			config:           GateConfig{SubgraphName: "user-service", MinScore: float64Ptr(80)},
+			report:           newGateTestReport("2", 75, "feature", 0),
+			expectedStatus:   GateStatusFailed,
+			expectedFailures: []string{GateCheckMinScore},
+		},
+		{
+			name:             "score dropped too much",
+			config:           GateConfig{SubgraphName: "user-service", MaxScoreDrop: float64Ptr(2)},
+			report:           newGateTestReport("2", 85, "feature", 0),
+			baseline:         newGateTestReport("1", 90, "main", 0),
+			expectedStatus:   GateStatusFailed,
+			expectedFailures: []string{GateCheckMaxScoreDrop},
+		},
+		{
+			name:           "score drop within limit",
+			config:         GateConfig{SubgraphName: "user-service", MaxScoreDrop: float64Ptr(2)},
+			report:         newGateTestReport("2", 89, "feature", 0),
+			baseline:       newGateTestReport("1", 90, "main", 0),
+			expectedStatus: GateStatusPassed,
+		},
+		{
+			name:           "missing baseline is noted",
+			config:         GateConfig{SubgraphName: "user-service", MaxScoreDrop: float64Ptr(2)},
+			report:         newGateTestReport("2", 50, "feature", 0),
+			expectedStatus: GateStatusPassed,
+			expectedNotes:  1,
+		},
+		{
+			name: "too many rule violations",
+			config: GateConfig{
+				SubgraphName:      "user-service",
+				RuleMaxViolations: map[string]int{"PII": 1, "Boolean Prefix": 0},
+			},
+			report:           newGateTestReport("2", 90, "feature", 2),
+			expectedStatus:   GateStatusFailed,
+			expectedFailures: []string{GateCheckRuleMaxViolations},
+		},
+		{
+			name: "all checks fail",
+			config: GateConfig{
+				SubgraphName:      "user-service",
+				MinScore:          float64Ptr(95),
+				MaxScoreDrop:      float64Ptr(0),
+				RuleMaxViolations: map[string]int{"PII": 0},
+			},
+			report:           newGateTestReport("2", 90, "feature", 1),
+			baseline:         newGateTestReport("1", 99, "main", 0),
+			expectedStatus:   GateStatusFailed,
+			expectedFailures: []string{GateCheckMinScore, GateCheckMaxScoreDrop, GateCheckRuleMaxViolations},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.Evaluate(tt.report, tt.baseline)
+
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedStatus != GateStatusFailed, result.Passed())
+			assert.Len(t, result.Notes, tt.expectedNotes)
+
+			checks := make([]string, 0, len(result.Failures))
+			for _, failure := range result.Failures {
+				checks = append(checks, failure.Check)
+				assert.NotEmpty(t, failure.Message)
+			}
+			if tt.expectedFailures == nil {
+				assert.Empty(t, checks)
+			} else {
+				assert.Equal(t, tt.expectedFailures, checks)
+			}
+
+			if tt.baseline != nil {
+				assert.Equal(t, tt.baseline.ID, *result.BaselineReportID)
+			}
+		})
+	}
+}
+
+func TestGateConfig_Evaluate_IgnoresSuppressedViolations(t *testing.T) {
+	report := newGateTestReport("2", 90, "feature", 2)
+	report.ApplySuppressions([]Suppression{{ID: "1", SubgraphName: "user-service", RuleName: "PII"}})
+
+	config := GateConfig{SubgraphName: "user-service", RuleMaxViolations: map[string]int{"PII": 0}}
+	result := config.Evaluate(report, nil)
+
+	assert.Equal(t, GateStatusPassed, result.Status)
+}
+
+func TestGateConfig_Validate(t *testing.T) {
+	valid := GateConfig{SubgraphName: "user-service", MinScore: float64Ptr(80), MaxScoreDrop: float64Ptr(5)}
+	assert.NoError(t, valid.Validate())
+
+	invalid := GateConfig{MaxScoreDrop: float64Ptr(-1), RuleMaxViolations: map[string]int{"PII": -2}}
+	err := invalid.Validate()
+	assert.ErrorIs(t, err, ErrInvalidGateConfig)
+	assert.ErrorContains(t, err, "subgraph name is required")
+	assert.ErrorContains(t, err, "maximum score drop must not be negative")
+	assert.ErrorContains(t, err, `maximum violations for rule "PII" must not be negative`)
+}
+
+func TestGateConfig_BaselineBranch(t *testing.T) {
+	assert.Equal(t, DefaultGateBranch, GateConfig{}.BaselineBranch())
+	assert.Equal(t, "develop", GateConfig{DefaultBranch: "develop"}.BaselineBranch())
+}
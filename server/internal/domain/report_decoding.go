@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CurrentReportSchemaVersion is the version of the report format IncomingReport decodes.
+// Payloads of older versions are upconverted to it.
+//
+// Version 1 is the format first documented for the server, with snake_case keys such as
+// "subgraph_name" and "rule_results". Version 2 is the camelCase format the schema scorer
+// sends, such as "subgraphName" and "ruleResults".
+const CurrentReportSchemaVersion = 2
+
+// ReportUpconverter converts a decoded report payload of one schema version to the next.
+// Numbers in the payload are json.Number values.
+type ReportUpconverter func(payload map[string]interface{}) error
+
+// reportUpconverters holds the upconverter from every older schema version to the next
+// one. A new version of the report format bumps CurrentReportSchemaVersion and registers
+// the upconverter from the previous version here.
+var reportUpconverters = map[int]ReportUpconverter{
+	1: upconvertSnakeCaseKeys,
+}
+
+// snakeCaseReportKeys are the top-level keys that only version 1 payloads use
+var snakeCaseReportKeys = []string{
+	"schema_version", "subgraph_name", "total_fields", "total_weighted_violations", "rule_results",
+}
+
+// Decode decodes a report payload of any supported schema version and upconverts it to
+// the current version. Problems are returned as a *ValidationError wrapping
+// ErrInvalidReport, such as a value of the wrong type or, for a strict validator, a field
+// the report format does not define.
+func (v *ReportValidator) Decode(data []byte) (*IncomingReport, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	notAnObject := NewValidationError(ErrInvalidReport, FieldError{Path: "$", Reason: "must be a JSON object"})
+	var payload map[string]interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		if field, ok := JSONFieldError(err); ok {
+			return nil, NewValidationError(ErrInvalidReport, field)
+		}
+		return nil, notAnObject
+	}
+	// A JSON null decodes without an error and leaves the payload nil
+	if payload == nil {
+		return nil, notAnObject
+	}
+
+	version, err := payloadSchemaVersion(payload)
+	if err != nil {
+		return nil, err
+	}
+	for ; version < CurrentReportSchemaVersion; version++ {
+		if err := reportUpconverters[version](payload); err != nil {
+			return nil, fmt.Errorf("failed to upconvert report from schema version %d: %w", version, err)
+		}
+	}
+	payload["schemaVersion"] = CurrentReportSchemaVersion
+
+	// Epoch milliseconds are carried as their decimal digits
+	if millis, ok := payload["timestamp"].(json.Number); ok {
+		payload["timestamp"] = millis.String()
+	}
+
+	upconverted, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upconverted report: %w", err)
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(upconverted))
+	if v.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	var incoming IncomingReport
+	if err := decoder.Decode(&incoming); err != nil {
+		if field, ok := JSONFieldError(err); ok {
+			return nil, NewValidationError(ErrInvalidReport, field)
+		}
+		return nil, fmt.Errorf("failed to decode upconverted report: %w", err)
+	}
+	return &incoming, nil
+}
+
+// payloadSchemaVersion returns the schema version a payload declares. Payloads without
+// one are version 1 when they use its snake_case keys, and the current version otherwise.
+func payloadSchemaVersion(payload map[string]interface{}) (int, error) {
+	path, value := "schemaVersion", payload["schemaVersion"]
+	if value == nil {
+		path, value = "schema_version", payload["schema_version"]
+	}
+
+	if value == nil {
+		for _, key := range snakeCaseReportKeys {
+			if _, ok := payload[key]; ok {
+				return 1, nil
+			}
+		}
+		return CurrentReportSchemaVersion, nil
+	}
+
+	number, ok := value.(json.Number)
+	version, err := strconv.Atoi(number.String())
+	if !ok || err != nil || version < 1 {
+		return 0, NewValidationError(ErrInvalidReport, FieldError{Path: path, Reason: "must be a positive integer"})
+	}
+	if version > CurrentReportSchemaVersion {
+		return 0, NewValidationError(ErrInvalidReport, FieldError{
+			Path:   path,
+			Reason: fmt.Sprintf("must be at most %d, the latest version this server supports", CurrentReportSchemaVersion),
+		})
+	}
+	return version, nil
+}
+
+// upconvertSnakeCaseKeys converts a version 1 payload to version 2 by renaming the
+// snake_case keys of the report, its rule results, violations and locations to camelCase.
+// Metadata is left alone, its keys are chosen by the user.
+func upconvertSnakeCaseKeys(payload map[string]interface{}) error {
+	camelCaseKeys(payload)
+	delete(payload, "schemaVersion")
+
+	ruleResults, _ := payload["ruleResults"].([]interface{})
+	for _, ruleResult := range ruleResults {
+		ruleResult, ok := ruleResult.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		camelCaseKeys(ruleResult)
+
+		violations, _ := ruleResult["violations"].([]interface{})
+		for _, violation := range violations {
+			violation, ok := violation.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			camelCaseKeys(violation)
+			if location, ok := violation["location"].(map[string]interface{}); ok {
+				camelCaseKeys(location)
+			}
+		}
+	}
+	return nil
+}
+
+// camelCaseKeys renames the snake_case keys of an object to camelCase. A key that is
+// also present in camelCase is left as it is, so that strict validators reject it.
+func camelCaseKeys(object map[string]interface{}) {
+	for key, value := range object {
+		if !strings.Contains(key, "_") {
+			continue
+		}
+		camel := snakeToCamel(key)
+		if _, exists := object[camel]; !exists {
+			delete(object, key)
+			object[camel] = value
+		}
+	}
+}
+
+// snakeToCamel converts a snake_case name to camelCase
+func snakeToCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			runes := []rune(parts[i])
+			runes[0] = unicode.ToUpper(runes[0])
+			parts[i] = string(runes)
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// timestampReason explains which report timestamps are accepted
+const timestampReason = "must be an RFC3339 timestamp or milliseconds since the Unix epoch"
+
+// ParseReportTimestamp parses the timestamp of a report, given as an RFC3339 timestamp
+// with or without fractional seconds, or as milliseconds since the Unix epoch
+func ParseReportTimestamp(value string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// JSONFieldError describes a JSON decoding error that concerns a single field: a value of
+// the wrong type, or a field that is not allowed by Decoder.DisallowUnknownFields
+func JSONFieldError(err error) (FieldError, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return FieldError{Path: typeErr.Field, Reason: "must be " + jsonTypeName(typeErr.Type)}, true
+	}
+
+	// The decoder has no error type for unknown fields, only its message names them
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return FieldError{Path: strings.Trim(field, `"`), Reason: "is not a known field"}, true
+	}
+	return FieldError{}, false
+}
+
+// jsonTypeName names the JSON type that decodes into t
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReportValidator_Decode(t *testing.T) {
+	line := 3
+	field := "email"
+	subgraph := "users"
+	expected := &IncomingReport{
+		SchemaVersion:           CurrentReportSchemaVersion,
+		Timestamp:               "2025-01-30T17:30:00Z",
+		SubgraphName:            &subgraph,
+		Score:                   90,
+		TotalFields:             20,
+		TotalWeightedViolations: 2,
+		RuleResults: []IncomingRuleResult{{
+			Rule:       "PII",
+			Message:    "Fields contain PII",
+			Violations: []IncomingViolation{{Message: "email", Location: IncomingLocation{Line: &line, Field: &field}}},
+		}},
+		Metadata: map[string]interface{}{"build_id": "42"},
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{
+			name: "current camelCase payload",
+			payload: `{"schemaVersion": 2, "timestamp": "2025-01-30T17:30:00Z", "subgraphName": "users",
+				"score": 90, "totalFields": 20, "totalWeightedViolations": 2,
+				"ruleResults": [{"rule": "PII", "message": "Fields contain PII",
+					"violations": [{"message": "email", "location": {"line": 3, "field": "email"}}]}],
+				"metadata": {"build_id": "42"}}`,
+		},
+		{
+			name: "camelCase payload without a version",
+			payload: `{"timestamp": "2025-01-30T17:30:00Z", "subgraphName": "users",
+				"score": 90, "totalFields": 20, "totalWeightedViolations": 2,
+				"ruleResults": [{"rule": "PII", "message": "Fields contain PII",
+					"violations": [{"message": "email", "location": {"line": 3, "field": "email"}}]}],
+				"metadata": {"build_id": "42"}}`,
+		},
+		{
+			name: "snake_case payload without a version",
+			payload: `{"timestamp": "2025-01-30T17:30:00Z", "subgraph_name": "users",
+				"score": 90, "total_fields": 20, "total_weighted_violations": 2,
+				"rule_results": [{"rule": "PII", "message": "Fields contain PII",
+					"violations": [{"message": "email", "location": {"line": 3, "field": "email"}}]}],
+				"metadata": {"build_id": "42"}}`,
+		},
+		{
+			name: "snake_case payload of version 1",
+			payload: `{"schema_version": 1, "timestamp": "2025-01-30T17:30:00Z", "subgraph_name": "users",
+				"score": 90, "total_fields": 20, "total_weighted_violations": 2,
+				"rule_results": [{"rule": "PII", "message": "Fields contain PII",
+					"violations": [{"message": "email", "location": {"line": 3, "field": "email"}}]}],
+				"metadata": {"build_id": "42"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incoming, err := NewReportValidator(WithStrictFields()).Decode([]byte(tt.payload))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, incoming)
+		})
+	}
+}
+
+func TestReportValidator_Decode_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		options       []ReportValidatorOption
+		expectedField FieldError
+	}{
+		{
+			name:          "not an object",
+			payload:       `[1, 2]`,
+			expectedField: FieldError{Path: "$", Reason: "must be a JSON object"},
+		},
+		{
+			name:          "null",
+			payload:       `null`,
+			expectedField: FieldError{Path: "$", Reason: "must be a JSON object"},
+		},
+		{
+			name:          "future schema version",
+			payload:       `{"schemaVersion": 3, "timestamp": "2025-01-30T17:30:00Z"}`,
+			expectedField: FieldError{Path: "schemaVersion", Reason: "must be at most 2, the latest version this server supports"},
+		},
+		{
+			name:          "schema version that is not an integer",
+			payload:       `{"schema_version": "one"}`,
+			expectedField: FieldError{Path: "schema_version", Reason: "must be a positive integer"},
+		},
+		{
+			name:          "wrong type",
+			payload:       `{"subgraph_name": "users", "total_fields": "many"}`,
+			expectedField: FieldError{Path: "totalFields", Reason: "must be an integer"},
+		},
+		{
+			name:          "both key styles in a strict validator",
+			payload:       `{"subgraphName": "users", "subgraph_name": "users"}`,
+			options:       []ReportValidatorOption{WithStrictFields()},
+			expectedField: FieldError{Path: "subgraph_name", Reason: "is not a known field"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReportValidator(tt.options...).Decode([]byte(tt.payload))
+			assert.ErrorIs(t, err, ErrInvalidReport)
+
+			var validationErr *ValidationError
+			if assert.True(t, errors.As(err, &validationErr)) {
+				assert.Equal(t, []FieldError{tt.expectedField}, validationErr.Fields)
+			}
+		})
+	}
+}
+
+func TestParseReportTimestamp(t *testing.T) {
+	expected := time.Date(2025, 1, 30, 17, 30, 0, 123000000, time.UTC)
+
+	for _, value := range []string{
+		"2025-01-30T17:30:00.123Z",
+		"2025-01-30T17:30:00.123000000Z",
+		"2025-01-30T18:30:00.123+01:00",
+		"1738258200123",
+	} {
+		timestamp, err := ParseReportTimestamp(value)
+		assert.NoError(t, err, value)
+		assert.True(t, expected.Equal(timestamp), value)
+	}
+
+	_, err := ParseReportTimestamp("30/01/2025")
+	assert.Error(t, err)
+}
+
+func TestReportValidator_Decode_EpochMillis(t *testing.T) {
+	incoming, err := NewReportValidator().Decode([]byte(`{"timestamp": 1738258200000, "subgraphName": "users"}`))
+	assert.NoError(t, err)
+
+	report, _, err := incoming.ToDomainEntity(DefaultRuleCatalog)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 30, 17, 30, 0, 0, time.UTC), report.Timestamp)
+}
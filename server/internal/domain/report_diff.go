@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"sort"
+)
+
+// ReportDiff describes how violations changed between two schema reports
+type ReportDiff struct {
+	Base             *SchemaReport
+	Head             *SchemaReport
+	ScoreDelta       float64
+	TotalFieldsDelta int
+	NewCount         int
+	FixedCount       int
+	UnchangedCount   int
+	Rules            []RuleDiff
+}
+
+// RuleDiff contains the violation changes for a single rule
+type RuleDiff struct {
+	RuleName  string
+	New       []Violation
+	Fixed     []Violation
+	Unchanged []Violation
+}
+
+// HasChanges reports whether the rule gained or lost any violations
+func (rd RuleDiff) HasChanges() bool {
+	return len(rd.New) > 0 || len(rd.Fixed) > 0
+}
+
+// DiffReports matches the violations of two reports and returns the new, fixed
+// and unchanged violations per rule. Violations are matched by rule name and
+// location coordinate, falling back to the message when no coordinate is known.
+func DiffReports(base, head *SchemaReport) *ReportDiff {
+	diff := &ReportDiff{
+		Base:             base,
+		Head:             head,
+		ScoreDelta:       head.Score - base.Score,
+		TotalFieldsDelta: head.TotalFields - base.TotalFields,
+		Rules:            make([]RuleDiff, 0),
+	}
+
+	baseViolations := violationsByRule(base)
+	headViolations := violationsByRule(head)
+
+	ruleNames := make(map[string]bool)
+	for name := range baseViolations {
+		ruleNames[name] = true
+	}
+	for name := range headViolations {
+		ruleNames[name] = true
+	}
+
+	for name := range ruleNames {
+		ruleDiff := diffViolations(name, baseViolations[name], headViolations[name])
+		diff.NewCount += len(ruleDiff.New)
+		diff.FixedCount += len(ruleDiff.Fixed)
+		diff.UnchangedCount += len(ruleDiff.Unchanged)
+		diff.Rules = append(diff.Rules, ruleDiff)
+	}
+
+	// Rules with the most churn first, then alphabetically for a stable order
+	sort.Slice(diff.Rules, func(i, j int) bool {
+		ci := len(diff.Rules[i].New) + len(diff.Rules[i].Fixed)
+		cj := len(diff.Rules[j].New) + len(diff.Rules[j].Fixed)
+		if ci != cj {
+			return ci > cj
+		}
+		return diff.Rules[i].RuleName < diff.Rules[j].RuleName
+	})
+
+	return diff
+}
+
+// violationsByRule groups the violations of a report by rule name
+func violationsByRule(report *SchemaReport) map[string][]Violation {
+	grouped := make(map[string][]Violation)
+	for _, ruleResult := range report.RuleResults {
+		grouped[ruleResult.RuleName] = append(grouped[ruleResult.RuleName], ruleResult.Violations...)
+	}
+	return grouped
+}
+
+// diffViolations compares the violations of one rule. Matching keys are treated
+// as a multiset so that duplicate violations on the same location are counted.
+func diffViolations(ruleName string, base, head []Violation) RuleDiff {
+	ruleDiff := RuleDiff{
+		RuleName:  ruleName,
+		New:       make([]Violation, 0),
+		Fixed:     make([]Violation, 0),
+		Unchanged: make([]Violation, 0),
+	}
+
+	remaining := make(map[string][]Violation)
+	for _, violation := range base {
+		key := violationMatchKey(violation)
+		remaining[key] = append(remaining[key], violation)
+	}
+
+	for _, violation := range head {
+		key := violationMatchKey(violation)
+		if matches := remaining[key]; len(matches) > 0 {
+			remaining[key] = matches[1:]
+			ruleDiff.Unchanged = append(ruleDiff.Unchanged, violation)
+			continue
+		}
+		ruleDiff.New = append(ruleDiff.New, violation)
+	}
+
+	// Whatever is left in the base report no longer occurs in head
+	for _, violation := range base {
+		key := violationMatchKey(violation)
+		if matches := remaining[key]; len(matches) > 0 {
+			remaining[key] = matches[1:]
+			ruleDiff.Fixed = append(ruleDiff.Fixed, violation)
+		}
+	}
+
+	return ruleDiff
+}
+
+// violationMatchKey returns the key used to match a violation across reports
+func violationMatchKey(violation Violation) string {
+	if violation.LocationCoordinate != nil && *violation.LocationCoordinate != "" {
+		return "coordinate:" + *violation.LocationCoordinate
+	}
+	return "message:" + violation.Message
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newDiffReport(id string, score float64, totalFields int, ruleResults ...RuleResult) *SchemaReport {
+	return &SchemaReport{
+		ID:           id,
+		SubgraphName: "user-service",
+		Score:        score,
+		TotalFields:  totalFields,
+		RuleResults:  ruleResults,
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	base := newDiffReport("1", 80.0, 40,
+		RuleResult{
+			RuleName: "PII",
+			Violations: []Violation{
+				{Message: "Field 'email' contains PII", LocationCoordinate: stringPtr("User.email")},
+				{Message: "Field 'phone' contains PII", LocationCoordinate: stringPtr("User.phone")},
+			},
+		},
+		RuleResult{
+			RuleName: "Cycle Counter",
+			Violations: []Violation{
+				{Message: "Cycle detected: User -> Order -> User"},
+			},
+		},
+	)
+
+	head := newDiffReport("2", 85.5, 44,
+		RuleResult{
+			RuleName: "PII",
+			Violations: []Violation{
+				{Message: "Field 'email' contains PII (renamed message)", LocationCoordinate: stringPtr("User.email")},
+				{Message: "Field 'address' contains PII", LocationCoordinate: stringPtr("User.address")},
+			},
+		},
+		RuleResult{
+			RuleName: "Cycle Counter",
+			Violations: []Violation{
+				{Message: "Cycle detected: User -> Order -> User"},
+			},
+		},
+		RuleResult{
+			RuleName: "Boolean Prefix",
+			Violations: []Violation{
+				{Message: "Field 'isActive' is prefixed", LocationCoordinate: stringPtr("User.isActive")},
+			},
+		},
+	)
+
+	diff := DiffReports(base, head)
+
+	assert.Equal(t, 5.5, diff.ScoreDelta)
+	assert.Equal(t, 4, diff.TotalFieldsDelta)
+	assert.Equal(t, 2, diff.NewCount)
+	assert.Equal(t, 1, diff.FixedCount)
+	assert.Equal(t, 2, diff.UnchangedCount)
+	assert.Len(t, diff.Rules, 3)
+
+	rules := make(map[string]RuleDiff)
+	for _, rule := range diff.Rules {
+		rules[rule.RuleName] = rule
+	}
+
+	// Matched by coordinate even though the message changed
+	pii := rules["PII"]
+	assert.Len(t, pii.Unchanged, 1)
+	assert.Equal(t, "User.email", *pii.Unchanged[0].LocationCoordinate)
+	assert.Len(t, pii.New, 1)
+	assert.Equal(t, "User.address", *pii.New[0].LocationCoordinate)
+	assert.Len(t, pii.Fixed, 1)
+	assert.Equal(t, "User.phone", *pii.Fixed[0].LocationCoordinate)
+
+	// Matched by message when there is no coordinate
+	cycles := rules["Cycle Counter"]
+	assert.Len(t, cycles.Unchanged, 1)
+	assert.False(t, cycles.HasChanges())
+
+	booleans := rules["Boolean Prefix"]
+	assert.Len(t, booleans.New, 1)
+	assert.Empty(t, booleans.Fixed)
+
+	// Rules with the most changes are listed first
+	assert.Equal(t, "PII", diff.Rules[0].RuleName)
+	assert.Equal(t, "Cycle Counter", diff.Rules[2].RuleName)
+}
+
+func TestDiffReports_DuplicateViolations(t *testing.T) {
+	base := newDiffReport("1", 90.0, 10,
+		RuleResult{
+			RuleName: "Composite Keys",
+			Violations: []Violation{
+				{Message: "Too many keys", LocationCoordinate: stringPtr("Product")},
+				{Message: "Too many keys", LocationCoordinate: stringPtr("Product")},
+			},
+		},
+	)
+	head := newDiffReport("2", 95.0, 10,
+		RuleResult{
+			RuleName: "Composite Keys",
+			Violations: []Violation{
+				{Message: "Too many keys", LocationCoordinate: stringPtr("Product")},
+			},
+		},
+	)
+
+	diff := DiffReports(base, head)
+
+	assert.Equal(t, 1, diff.UnchangedCount)
+	assert.Equal(t, 1, diff.FixedCount)
+	assert.Equal(t, 0, diff.NewCount)
+}
+
+func TestDiffReports_IdenticalReports(t *testing.T) {
+	report := newDiffReport("1", 70.0, 20,
+		RuleResult{
+			RuleName: "Deprecation",
+			Violations: []Violation{
+				{Message: "Field deprecated without reason", LocationCoordinate: stringPtr("User.name")},
+			},
+		},
+	)
+
+	diff := DiffReports(report, report)
+
+	assert.Equal(t, 0.0, diff.ScoreDelta)
+	assert.Equal(t, 0, diff.TotalFieldsDelta)
+	assert.Equal(t, 0, diff.NewCount)
+	assert.Equal(t, 0, diff.FixedCount)
+	assert.Equal(t, 1, diff.UnchangedCount)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// legacyIDPrefix starts the UUIDs that replaced the integer IDs of reports, rule results
+// and violations stored before IDs became UUIDs. The integer is kept in the last twelve
+// hex digits, so the mapping works in both directions and in every database.
+const legacyIDPrefix = "00000000-0000-0000-0000-"
+
+// NewID returns a new ID for a report, rule result, violation, webhook event or ingestion
+// ticket. IDs are UUIDv7, which sort by creation time.
+func NewID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		// Only fails when the system random source does
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+// LegacyID returns the UUID of a row that was stored with an integer ID
+func LegacyID(id int64) string {
+	return fmt.Sprintf("%s%012x", legacyIDPrefix, id)
+}
+
+// ParseReportID returns the canonical form of a report ID, accepting both UUIDs and the
+// integer IDs of reports stored before IDs became UUIDs, so that existing links keep
+// working. It returns false when id is neither.
+func ParseReportID(id string) (string, bool) {
+	if parsed, err := uuid.Parse(id); err == nil {
+		return parsed.String(), true
+	}
+	if legacy, err := strconv.ParseInt(id, 10, 64); err == nil && legacy > 0 && legacy < 1<<48 {
+		return LegacyID(legacy), true
+	}
+	return "", false
+}
+
+// ShortID returns a short form of an ID for display: the integer of legacy IDs, and the
+// random last eight characters of a UUIDv7, whose leading characters only encode the time
+func ShortID(id string) string {
+	if hex, ok := strings.CutPrefix(id, legacyIDPrefix); ok {
+		if legacy, err := strconv.ParseInt(hex, 16, 64); err == nil {
+			return strconv.FormatInt(legacy, 10)
+		}
+	}
+	if len(id) > 8 {
+		return id[len(id)-8:]
+	}
+	return id
+}
+
+// AssignIDs gives the report, its rule results and their violations an ID where they
+// have none yet, and links the rule results and violations to their parent
+func (sr *SchemaReport) AssignIDs() {
+	if sr.ID == "" {
+		sr.ID = NewID()
+	}
+	for i := range sr.RuleResults {
+		ruleResult := &sr.RuleResults[i]
+		if ruleResult.ID == "" {
+			ruleResult.ID = NewID()
+		}
+		ruleResult.ReportID = sr.ID
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			if violation.ID == "" {
+				violation.ID = NewID()
+			}
+			violation.RuleResultID = ruleResult.ID
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewID(t *testing.T) {
+	id := NewID()
+	parsed, err := uuid.Parse(id)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), parsed.Version())
+	assert.NotEqual(t, id, NewID())
+}
+
+func TestParseReportID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+		valid    bool
+	}{
+		{name: "uuid", id: "0192a3b4-c5d6-7e8f-9a0b-1c2d3e4f5a6b", expected: "0192a3b4-c5d6-7e8f-9a0b-1c2d3e4f5a6b", valid: true},
+		{name: "uppercase uuid", id: "0192A3B4-C5D6-7E8F-9A0B-1C2D3E4F5A6B", expected: "0192a3b4-c5d6-7e8f-9a0b-1c2d3e4f5a6b", valid: true},
+		{name: "legacy integer", id: "123", expected: "00000000-0000-0000-0000-00000000007b", valid: true},
+		{name: "zero", id: "0"},
+		{name: "negative", id: "-5"},
+		{name: "too large", id: "281474976710656"},
+		{name: "empty", id: ""},
+		{name: "garbage", id: "not-an-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, valid := ParseReportID(tt.id)
+			assert.Equal(t, tt.valid, valid)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestShortID(t *testing.T) {
+	assert.Equal(t, "123", ShortID(LegacyID(123)))
+	assert.Equal(t, "3e4f5a6b", ShortID("0192a3b4-c5d6-7e8f-9a0b-1c2d3e4f5a6b"))
+	assert.Equal(t, "1", ShortID("1"))
+}
+
+func TestSchemaReport_AssignIDs(t *testing.T) {
+	report := &SchemaReport{
+		RuleResults: []RuleResult{
+			{RuleName: "field-descriptions", Violations: []Violation{{Message: "Missing description"}}},
+			{ID: LegacyID(5), RuleName: "naming"},
+		},
+	}
+
+	report.AssignIDs()
+
+	assert.NotEmpty(t, report.ID)
+	for _, ruleResult := range report.RuleResults {
+		assert.NotEmpty(t, ruleResult.ID)
+		assert.Equal(t, report.ID, ruleResult.ReportID)
+		for _, violation := range ruleResult.Violations {
+			assert.NotEmpty(t, violation.ID)
+			assert.Equal(t, ruleResult.ID, violation.RuleResultID)
+		}
+	}
+	assert.Equal(t, LegacyID(5), report.RuleResults[1].ID)
+
+	// IDs that are already set are kept
+	id := report.ID
+	report.AssignIDs()
+	assert.Equal(t, id, report.ID)
+}
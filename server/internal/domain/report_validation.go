@@ -0,0 +1,360 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// UnknownSubgraph is the subgraph that quarantined reports without a subgraph name are
+// filed under
+const UnknownSubgraph = "Unknown"
+
+// Limits of the columns incoming reports are stored in
+const (
+	maxSubgraphNameLength = 255
+	maxRuleNameLength     = 100
+	maxLocationLength     = 255
+	maxCategoryLength     = 50
+	// maxDecimal is the largest value of a DECIMAL(10,2) column
+	maxDecimal = 99999999.99
+)
+
+// DefaultMaxFutureSkew is how far ahead of the server clock a report timestamp may be
+const DefaultMaxFutureSkew = time.Hour
+
+// MissingSubgraphPolicy decides what happens to reports sent without a subgraph name
+type MissingSubgraphPolicy string
+
+const (
+	// MissingSubgraphQuarantine files reports without a subgraph name under UnknownSubgraph
+	// and records why in their metadata
+	MissingSubgraphQuarantine MissingSubgraphPolicy = "quarantine"
+	// MissingSubgraphReject rejects reports without a subgraph name
+	MissingSubgraphReject MissingSubgraphPolicy = "reject"
+)
+
+// DefaultScoreTolerance is how many points a claimed score may differ from the one the
+// server recomputes before the report is flagged
+const DefaultScoreTolerance = 0.1
+
+// ScoreMismatchPolicy decides what happens to reports whose claimed score does not match
+// the one recomputed from their rule results
+type ScoreMismatchPolicy string
+
+const (
+	// ScoreMismatchFlag stores such reports with ScoreMismatch set
+	ScoreMismatchFlag ScoreMismatchPolicy = "flag"
+	// ScoreMismatchReject rejects such reports
+	ScoreMismatchReject ScoreMismatchPolicy = "reject"
+)
+
+// ParseScoreMismatchPolicy parses the name of a score mismatch policy
+func ParseScoreMismatchPolicy(name string) (ScoreMismatchPolicy, error) {
+	switch policy := ScoreMismatchPolicy(strings.ToLower(name)); policy {
+	case ScoreMismatchFlag, ScoreMismatchReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown score mismatch policy %q, use %s or %s", name, ScoreMismatchFlag, ScoreMismatchReject)
+	}
+}
+
+// QuarantineMetadataKey is the metadata entry that records why a report was quarantined
+const QuarantineMetadataKey = "quarantine"
+
+// ParseMissingSubgraphPolicy parses the name of a missing subgraph policy
+func ParseMissingSubgraphPolicy(name string) (MissingSubgraphPolicy, error) {
+	switch policy := MissingSubgraphPolicy(strings.ToLower(name)); policy {
+	case MissingSubgraphQuarantine, MissingSubgraphReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown missing subgraph policy %q, use %s or %s", name, MissingSubgraphQuarantine, MissingSubgraphReject)
+	}
+}
+
+// ReportValidator checks the invariants of incoming reports before they are converted to
+// domain entities, so that bad reports are rejected with every problem at once instead of
+// failing in the database or skewing the scores
+type ReportValidator struct {
+	missingSubgraph       MissingSubgraphPolicy
+	scoreMismatch         ScoreMismatchPolicy
+	scoreTolerance        float64
+	maxFutureSkew         time.Duration
+	disallowUnknownFields bool
+	rules                 RuleDefinitionSource
+	now                   func() time.Time
+}
+
+// ReportValidatorOption configures a ReportValidator
+type ReportValidatorOption func(*ReportValidator)
+
+// WithMissingSubgraphPolicy sets what happens to reports without a subgraph name
+func WithMissingSubgraphPolicy(policy MissingSubgraphPolicy) ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.missingSubgraph = policy
+	}
+}
+
+// WithScoreMismatchPolicy sets what happens to reports whose claimed score does not match
+// the recomputed one
+func WithScoreMismatchPolicy(policy ScoreMismatchPolicy) ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.scoreMismatch = policy
+	}
+}
+
+// WithScoreTolerance sets how many points a claimed score may differ from the recomputed one
+func WithScoreTolerance(tolerance float64) ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.scoreTolerance = tolerance
+	}
+}
+
+// WithMaxFutureSkew sets how far ahead of the server clock a report timestamp may be
+func WithMaxFutureSkew(skew time.Duration) ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.maxFutureSkew = skew
+	}
+}
+
+// WithStrictFields rejects reports with JSON fields the report format does not define
+func WithStrictFields() ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.disallowUnknownFields = true
+	}
+}
+
+// WithRuleDefinitions sets where the rule definitions that reports are weighed and
+// verified with come from, usually the rule catalog
+func WithRuleDefinitions(rules RuleDefinitionSource) ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.rules = rules
+	}
+}
+
+// WithValidationClock sets the clock that report timestamps are compared with
+func WithValidationClock(now func() time.Time) ReportValidatorOption {
+	return func(v *ReportValidator) {
+		v.now = now
+	}
+}
+
+// NewReportValidator creates a validator that quarantines reports without a subgraph name,
+// flags reports with a mismatched score, accepts unknown JSON fields and weighs reports with
+// DefaultRuleCatalog unless configured otherwise
+func NewReportValidator(opts ...ReportValidatorOption) *ReportValidator {
+	v := &ReportValidator{
+		missingSubgraph: MissingSubgraphQuarantine,
+		scoreMismatch:   ScoreMismatchFlag,
+		scoreTolerance:  DefaultScoreTolerance,
+		maxFutureSkew:   DefaultMaxFutureSkew,
+		rules:           DefaultRuleCatalog,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// ScoreTolerance returns how many points a claimed score may differ from the recomputed one
+func (v *ReportValidator) ScoreTolerance() float64 {
+	return v.scoreTolerance
+}
+
+// RuleDefinitions returns the rule definitions that reports are weighed and verified with
+func (v *ReportValidator) RuleDefinitions(ctx context.Context) (RuleDefinitions, error) {
+	definitions, err := v.rules.RuleDefinitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule definitions: %w", err)
+	}
+	return definitions, nil
+}
+
+// Validate checks an incoming report. It returns a *ValidationError wrapping
+// ErrInvalidReport that lists every invalid field.
+func (v *ReportValidator) Validate(ir *IncomingReport) error {
+	var fields []FieldError
+	invalid := func(path, reason string) {
+		fields = append(fields, FieldError{Path: path, Reason: reason})
+	}
+
+	if timestamp, err := ParseReportTimestamp(ir.Timestamp); err != nil {
+		invalid("timestamp", timestampReason)
+	} else if timestamp.After(v.now().Add(v.maxFutureSkew)) {
+		invalid("timestamp", fmt.Sprintf("must not be more than %v in the future", v.maxFutureSkew))
+	}
+
+	if missingSubgraphName(ir.SubgraphName) {
+		if v.missingSubgraph == MissingSubgraphReject {
+			invalid("subgraphName", "is required")
+		}
+	} else if utf8.RuneCountInString(*ir.SubgraphName) > maxSubgraphNameLength {
+		invalid("subgraphName", fmt.Sprintf("must be at most %d characters", maxSubgraphNameLength))
+	}
+
+	if reason := invalidDecimal(ir.Score); reason != "" {
+		invalid("score", reason)
+	} else if ir.Score > 100 {
+		invalid("score", "must be at most 100")
+	}
+	if ir.TotalFields < 0 {
+		invalid("totalFields", "must not be negative")
+	}
+	if reason := invalidDecimal(ir.TotalWeightedViolations); reason != "" {
+		invalid("totalWeightedViolations", reason)
+	} else if ir.TotalWeightedViolations < 0 {
+		invalid("totalWeightedViolations", "must not be negative")
+	}
+
+	rulePaths := make(map[string]string, len(ir.RuleResults))
+	for i, ruleResult := range ir.RuleResults {
+		path := fmt.Sprintf("ruleResults[%d]", i)
+		switch {
+		case strings.TrimSpace(ruleResult.Rule) == "":
+			invalid(path+".rule", "is required")
+		case utf8.RuneCountInString(ruleResult.Rule) > maxRuleNameLength:
+			invalid(path+".rule", fmt.Sprintf("must be at most %d characters", maxRuleNameLength))
+		default:
+			if first, exists := rulePaths[ruleResult.Rule]; exists {
+				invalid(path+".rule", fmt.Sprintf("duplicates %s.rule", first))
+			} else {
+				rulePaths[ruleResult.Rule] = path
+			}
+		}
+
+		if ruleResult.Weight != nil {
+			if reason := invalidDecimal(*ruleResult.Weight); reason != "" {
+				invalid(path+".weight", reason)
+			} else if *ruleResult.Weight < 0 {
+				invalid(path+".weight", "must not be negative")
+			} else if WeightedViolations(*ruleResult.Weight, len(ruleResult.Violations)) > maxDecimal {
+				invalid(path+".weight", fmt.Sprintf("must keep weight × violations^1.5 at most %.2f", maxDecimal))
+			}
+		}
+		if ruleResult.Category != nil && utf8.RuneCountInString(*ruleResult.Category) > maxCategoryLength {
+			invalid(path+".category", fmt.Sprintf("must be at most %d characters", maxCategoryLength))
+		}
+
+		for j, violation := range ruleResult.Violations {
+			location := fmt.Sprintf("%s.violations[%d].location", path, j)
+			if violation.Location.Line != nil && *violation.Location.Line < 0 {
+				invalid(location+".line", "must not be negative")
+			}
+			if violation.Location.Column != nil && *violation.Location.Column < 0 {
+				invalid(location+".column", "must not be negative")
+			}
+			for _, text := range []struct {
+				name  string
+				value *string
+			}{
+				{"field", violation.Location.Field},
+				{"type", violation.Location.Type},
+				{"coordinate", violation.Location.Coordinate},
+			} {
+				if text.value != nil && utf8.RuneCountInString(*text.value) > maxLocationLength {
+					invalid(location+"."+text.name, fmt.Sprintf("must be at most %d characters", maxLocationLength))
+				}
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError(ErrInvalidReport, fields...)
+	}
+	return nil
+}
+
+// Convert validates an incoming report and converts it to domain entities. A report
+// without a subgraph name that is not rejected is quarantined under UnknownSubgraph, and
+// under ScoreMismatchReject a report whose claimed score or rule weights do not match the
+// server's is rejected.
+func (v *ReportValidator) Convert(ctx context.Context, ir *IncomingReport) (*SchemaReport, []RuleResult, error) {
+	if err := v.Validate(ir); err != nil {
+		return nil, nil, err
+	}
+
+	definitions, err := v.RuleDefinitions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report, ruleResults, err := ir.ToDomainEntity(definitions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if v.scoreMismatch == ScoreMismatchReject {
+		if err := verifyScore(report, ruleResults, definitions, v.scoreTolerance); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if missingSubgraphName(ir.SubgraphName) {
+		report.SubgraphName = UnknownSubgraph
+		if report.Metadata == nil {
+			report.Metadata = make(map[string]interface{})
+		}
+		report.Metadata[QuarantineMetadataKey] = "missing subgraph name"
+	}
+
+	return report, ruleResults, nil
+}
+
+// verifyScore rejects a report whose rule results claim other weights than the server's,
+// or whose claimed score differs from the one recomputed with the server's weights
+func verifyScore(report *SchemaReport, ruleResults []RuleResult, weights RuleDefinitions, tolerance float64) error {
+	var fields []FieldError
+	for i, ruleResult := range ruleResults {
+		if weight := weights.Weight(ruleResult.RuleName); ruleResult.Weight != weight {
+			fields = append(fields, FieldError{
+				Path:   fmt.Sprintf("ruleResults[%d].weight", i),
+				Reason: fmt.Sprintf("must be %g, the weight of the rule on this server", weight),
+			})
+		}
+	}
+
+	recomputed, ok := weights.ComputeScore(report.TotalFields, ruleResults)
+	if ok && math.Abs(report.Score-recomputed) > tolerance {
+		fields = append(fields, FieldError{
+			Path:   "score",
+			Reason: fmt.Sprintf("must match the score of %.2f computed from the rule results", recomputed),
+		})
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError(ErrInvalidReport, fields...)
+	}
+	return nil
+}
+
+// missingSubgraphName reports whether a report was sent without a usable subgraph name
+func missingSubgraphName(name *string) bool {
+	return name == nil || strings.TrimSpace(*name) == ""
+}
+
+// invalidContribution explains why the weighted violations of a weighed rule result cannot
+// be stored in a DECIMAL(10,2) column, or returns an empty string when they can. Weights
+// from the rule catalog are only known once the result is weighed.
+func invalidContribution(ruleResult RuleResult) string {
+	if ruleResult.Contribution <= maxDecimal {
+		return ""
+	}
+	return fmt.Sprintf("must be few enough to keep %.2f × violations^1.5 at most %.2f", ruleResult.Weight, maxDecimal)
+}
+
+// invalidDecimal explains why a number cannot be stored in a DECIMAL(10,2) column, or
+// returns an empty string when it can
+func invalidDecimal(value float64) string {
+	switch {
+	case math.IsNaN(value) || math.IsInf(value, 0):
+		return "must be a finite number"
+	case math.Abs(value) > maxDecimal:
+		return fmt.Sprintf("must be between %.2f and %.2f", -maxDecimal, maxDecimal)
+	default:
+		return ""
+	}
+}
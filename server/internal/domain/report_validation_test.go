@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validIncomingReport() IncomingReport {
+	subgraph := "user-service"
+	return IncomingReport{
+		Timestamp:               "2025-01-30T17:30:00Z",
+		SubgraphName:            &subgraph,
+		Score:                   85,
+		TotalFields:             20,
+		TotalWeightedViolations: 3,
+		RuleResults: []IncomingRuleResult{
+			{Rule: "PII", Violations: []IncomingViolation{{Message: "Field 'email' contains PII"}}},
+			{Rule: "Boolean Prefix"},
+		},
+	}
+}
+
+func TestReportValidator_Validate(t *testing.T) {
+	now := time.Date(2025, 1, 30, 18, 0, 0, 0, time.UTC)
+	long := strings.Repeat("x", 256)
+	negative := -1
+
+	tests := []struct {
+		name           string
+		modify         func(ir *IncomingReport)
+		options        []ReportValidatorOption
+		expectedFields []FieldError
+	}{
+		{
+			name:   "valid report",
+			modify: func(ir *IncomingReport) {},
+		},
+		{
+			name:   "missing subgraph is quarantined by default",
+			modify: func(ir *IncomingReport) { ir.SubgraphName = nil },
+		},
+		{
+			name:           "missing subgraph is rejected",
+			modify:         func(ir *IncomingReport) { blank := " "; ir.SubgraphName = &blank },
+			options:        []ReportValidatorOption{WithMissingSubgraphPolicy(MissingSubgraphReject)},
+			expectedFields: []FieldError{{Path: "subgraphName", Reason: "is required"}},
+		},
+		{
+			name: "every problem at once",
+			modify: func(ir *IncomingReport) {
+				ir.Timestamp = "2025-01-31T17:30:00Z"
+				ir.SubgraphName = &long
+				ir.Score = math.NaN()
+				ir.TotalFields = -3
+				ir.TotalWeightedViolations = math.Inf(1)
+				ir.RuleResults = append(ir.RuleResults,
+					IncomingRuleResult{Rule: "PII"},
+					IncomingRuleResult{Rule: ""},
+					IncomingRuleResult{Rule: long[:101]},
+					IncomingRuleResult{Rule: "Deprecation", Violations: []IncomingViolation{
+						{Location: IncomingLocation{Line: &negative, Coordinate: &long}},
+					}},
+				)
+			},
+			expectedFields: []FieldError{
+				{Path: "timestamp", Reason: "must not be more than 1h0m0s in the future"},
+				{Path: "subgraphName", Reason: "must be at most 255 characters"},
+				{Path: "score", Reason: "must be a finite number"},
+				{Path: "totalFields", Reason: "must not be negative"},
+				{Path: "totalWeightedViolations", Reason: "must be a finite number"},
+				{Path: "ruleResults[2].rule", Reason: "duplicates ruleResults[0].rule"},
+				{Path: "ruleResults[3].rule", Reason: "is required"},
+				{Path: "ruleResults[4].rule", Reason: "must be at most 100 characters"},
+				{Path: "ruleResults[5].violations[0].location.line", Reason: "must not be negative"},
+				{Path: "ruleResults[5].violations[0].location.coordinate", Reason: "must be at most 255 characters"},
+			},
+		},
+		{
+			name:           "invalid timestamp",
+			modify:         func(ir *IncomingReport) { ir.Timestamp = "30/01/2025" },
+			expectedFields: []FieldError{{Path: "timestamp", Reason: "must be an RFC3339 timestamp or milliseconds since the Unix epoch"}},
+		},
+		{
+			name:           "score above 100",
+			modify:         func(ir *IncomingReport) { ir.Score = 120 },
+			expectedFields: []FieldError{{Path: "score", Reason: "must be at most 100"}},
+		},
+		{
+			name:           "weighted violations too large to store",
+			modify:         func(ir *IncomingReport) { ir.TotalWeightedViolations = 1e9 },
+			expectedFields: []FieldError{{Path: "totalWeightedViolations", Reason: "must be between -99999999.99 and 99999999.99"}},
+		},
+		{
+			name: "invalid rule weights",
+			modify: func(ir *IncomingReport) {
+				negativeWeight, hugeWeight, category := -5.0, 5e7, long[:51]
+				ir.RuleResults[0].Weight = &negativeWeight
+				ir.RuleResults[0].Category = &category
+				ir.RuleResults[1].Weight = &hugeWeight
+				ir.RuleResults[1].Violations = make([]IncomingViolation, 2)
+			},
+			expectedFields: []FieldError{
+				{Path: "ruleResults[0].weight", Reason: "must not be negative"},
+				{Path: "ruleResults[0].category", Reason: "must be at most 50 characters"},
+				{Path: "ruleResults[1].weight", Reason: "must keep weight × violations^1.5 at most 99999999.99"},
+			},
+		},
+		{
+			name:    "allowed clock skew",
+			modify:  func(ir *IncomingReport) { ir.Timestamp = "2025-01-31T17:30:00Z" },
+			options: []ReportValidatorOption{WithMaxFutureSkew(48 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewReportValidator(append([]ReportValidatorOption{
+				WithValidationClock(func() time.Time { return now }),
+			}, tt.options...)...)
+
+			incoming := validIncomingReport()
+			tt.modify(&incoming)
+			err := validator.Validate(&incoming)
+
+			if tt.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidReport)
+			var validationErr *ValidationError
+			if assert.True(t, errors.As(err, &validationErr)) {
+				assert.Equal(t, tt.expectedFields, validationErr.Fields)
+			}
+		})
+	}
+}
+
+func TestReportValidator_Convert_Quarantine(t *testing.T) {
+	validator := NewReportValidator(WithValidationClock(func() time.Time {
+		return time.Date(2025, 1, 30, 18, 0, 0, 0, time.UTC)
+	}))
+
+	incoming := validIncomingReport()
+	incoming.SubgraphName = nil
+
+	report, ruleResults, err := validator.Convert(context.Background(), &incoming)
+	assert.NoError(t, err)
+	assert.Equal(t, UnknownSubgraph, report.SubgraphName)
+	assert.Equal(t, "missing subgraph name", report.Metadata[QuarantineMetadataKey])
+	assert.Len(t, ruleResults, 2)
+
+	incoming = validIncomingReport()
+	report, _, err = validator.Convert(context.Background(), &incoming)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-service", report.SubgraphName)
+	assert.NotContains(t, report.Metadata, QuarantineMetadataKey)
+}
+
+func TestReportValidator_Convert_CatalogWeightOverflow(t *testing.T) {
+	validator := NewReportValidator(
+		WithValidationClock(func() time.Time { return time.Date(2025, 1, 30, 18, 0, 0, 0, time.UTC) }),
+		WithRuleDefinitions(RuleDefinitions{"PII": {Weight: 1e7, Category: RuleCategoryImportant}}))
+
+	// 1e7 × 1^1.5 still fits the contribution column
+	incoming := validIncomingReport()
+	_, _, err := validator.Convert(context.Background(), &incoming)
+	assert.NoError(t, err)
+
+	// 1e7 × 5^1.5 does not, although the report does not send a weight
+	incoming.RuleResults[0].Violations = make([]IncomingViolation, 5)
+	_, _, err = validator.Convert(context.Background(), &incoming)
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []FieldError{{
+			Path:   "ruleResults[0].violations",
+			Reason: "must be few enough to keep 10000000.00 × violations^1.5 at most 99999999.99",
+		}}, validationErr.Fields)
+	}
+}
+
+func TestParseMissingSubgraphPolicy(t *testing.T) {
+	policy, err := ParseMissingSubgraphPolicy("Reject")
+	assert.NoError(t, err)
+	assert.Equal(t, MissingSubgraphReject, policy)
+
+	_, err = ParseMissingSubgraphPolicy("drop")
+	assert.Error(t, err)
+}
+
+func TestReportValidator_Convert_ScoreMismatch(t *testing.T) {
+	clock := WithValidationClock(func() time.Time {
+		return time.Date(2025, 1, 30, 18, 0, 0, 0, time.UTC)
+	})
+
+	// PII: 10 × 1^1.5 = 10 of 20 fields recomputes to 50, not the claimed 85
+	incoming := validIncomingReport()
+	_, _, err := NewReportValidator(clock).Convert(context.Background(), &incoming)
+	assert.NoError(t, err)
+
+	_, _, err = NewReportValidator(clock, WithScoreMismatchPolicy(ScoreMismatchReject)).Convert(context.Background(), &incoming)
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []FieldError{{Path: "score", Reason: "must match the score of 50.00 computed from the rule results"}},
+			validationErr.Fields)
+	}
+
+	incoming.Score = 50.05
+	_, _, err = NewReportValidator(clock, WithScoreMismatchPolicy(ScoreMismatchReject)).Convert(context.Background(), &incoming)
+	assert.NoError(t, err)
+
+	// Zeroed weights make the claimed score of 100 add up, but not with the server's weights
+	zero := 0.0
+	incoming.Score = 100
+	incoming.RuleResults[0].Weight = &zero
+	_, _, err = NewReportValidator(clock, WithScoreMismatchPolicy(ScoreMismatchReject)).Convert(context.Background(), &incoming)
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []FieldError{
+			{Path: "ruleResults[0].weight", Reason: "must be 10, the weight of the rule on this server"},
+			{Path: "score", Reason: "must match the score of 50.00 computed from the rule results"},
+		}, validationErr.Fields)
+	}
+}
+
+func TestParseScoreMismatchPolicy(t *testing.T) {
+	policy, err := ParseScoreMismatchPolicy("Reject")
+	assert.NoError(t, err)
+	assert.Equal(t, ScoreMismatchReject, policy)
+
+	_, err = ParseScoreMismatchPolicy("ignore")
+	assert.Error(t, err)
+}
@@ -1,35 +1,220 @@
 package domain
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 var (
 	ErrStoreReport        = errors.New("store report error")
+	ErrInvalidReport      = errors.New("invalid report")
 	ErrReportNotFound     = errors.New("report not found")
 	ErrGetDashboardData   = errors.New("get dashboard data error")
 	ErrGetSubgraphHistory = errors.New("get subgraph history error")
 	ErrHealthCheck        = errors.New("health check error")
+
+	ErrSuppressionNotFound = errors.New("suppression not found")
+	ErrInvalidSuppression  = errors.New("invalid suppression")
+
+	ErrGateConfigNotFound = errors.New("gate config not found")
+	ErrInvalidGateConfig  = errors.New("invalid gate config")
+
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrInvalidWebhook          = errors.New("invalid webhook")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrRuleNotFound = errors.New("rule not found")
+	ErrRuleExists   = errors.New("rule already exists")
+	ErrInvalidRule  = errors.New("invalid rule")
+
+	ErrRulesetVersionNotFound = errors.New("ruleset version not found")
+
+	ErrAPITokenNotFound = errors.New("API token not found")
+	ErrInvalidAPIToken  = errors.New("invalid API token")
+	ErrUnauthorized     = errors.New("unauthorized")
+
+	ErrIngestionQueueFull      = errors.New("ingestion queue is full")
+	ErrIngestionQueueClosed    = errors.New("ingestion queue is closed")
+	ErrIngestionTicketNotFound = errors.New("ingestion ticket not found")
 )
 
 // SchemaReportRepository defines the interface for schema report persistence
 type SchemaReportRepository interface {
 	// Store saves a new schema report
-	Store(report *SchemaReport) error
+	Store(ctx context.Context, report *SchemaReport) error
 
-	// GetByID retrieves a schema report by its ID
-	GetByID(id string) (*SchemaReport, error)
+	// GetByID retrieves a schema report by its ID. It returns an error wrapping
+	// ErrReportNotFound when there is no such report.
+	GetByID(ctx context.Context, id string) (*SchemaReport, error)
 
 	// GetRecentReports retrieves the most recent reports
-	GetRecentReports(limit int) ([]SchemaReport, error)
+	GetRecentReports(ctx context.Context, limit int) ([]SchemaReport, error)
 
 	// GetReportsBySubgraph retrieves reports for a specific subgraph
-	GetReportsBySubgraph(subgraphName string, limit int) ([]SchemaReport, error)
+	GetReportsBySubgraph(ctx context.Context, subgraphName string, limit int) ([]SchemaReport, error)
+
+	// GetPreviousReport retrieves the most recent report of a subgraph with a timestamp
+	// before the given one. It returns an error wrapping ErrReportNotFound when there is
+	// no such report.
+	GetPreviousReport(ctx context.Context, subgraphName string, before time.Time) (*SchemaReport, error)
+
+	// GetLatestReportOnBranch retrieves the most recent report of a subgraph whose metadata
+	// records the given branch and whose timestamp is before the given one. It returns an
+	// error wrapping ErrReportNotFound when there is no such report.
+	GetLatestReportOnBranch(ctx context.Context, subgraphName, branch string, before time.Time) (*SchemaReport, error)
 
 	// GetSubgraphSummaries retrieves aggregated data for all subgraphs
-	GetSubgraphSummaries() ([]SubgraphSummary, error)
+	GetSubgraphSummaries(ctx context.Context) ([]SubgraphSummary, error)
+
+	// GetLatestReports retrieves the most recent report of every subgraph with its
+	// rule results, without the individual violations
+	GetLatestReports(ctx context.Context) ([]SchemaReport, error)
+
+	// GetReportsWithRuleResults retrieves every report of a subgraph, oldest first, with its
+	// rule results but without the individual violations
+	GetReportsWithRuleResults(ctx context.Context, subgraphName string) ([]SchemaReport, error)
+
+	// GetOpenViolations retrieves the unresolved tracked violations of a subgraph, oldest first
+	GetOpenViolations(ctx context.Context, subgraphName string) ([]TrackedViolation, error)
 
 	// GetTotalReportCount returns the total number of reports
-	GetTotalReportCount() (int, error)
+	GetTotalReportCount(ctx context.Context) (int, error)
 
 	// HealthCheck verifies the repository is accessible
-	HealthCheck() error
+	HealthCheck(ctx context.Context) error
+}
+
+// SuppressionRepository defines the interface for suppression persistence
+type SuppressionRepository interface {
+	// Store saves a new suppression
+	Store(ctx context.Context, suppression *Suppression) error
+
+	// GetByID retrieves a suppression by its ID
+	GetByID(ctx context.Context, id string) (*Suppression, error)
+
+	// List retrieves all suppressions, or those of one subgraph when a name is given
+	List(ctx context.Context, subgraphName string) ([]Suppression, error)
+
+	// Update saves the changes to an existing suppression
+	Update(ctx context.Context, suppression *Suppression) error
+
+	// Delete removes a suppression
+	Delete(ctx context.Context, id string) error
+
+	// GetActive retrieves the suppressions of a subgraph that have not expired at the given time
+	GetActive(ctx context.Context, subgraphName string, at time.Time) ([]Suppression, error)
+}
+
+// GateRepository defines the interface for quality gate configuration persistence
+type GateRepository interface {
+	// GetGateConfig retrieves the gate configuration of a subgraph
+	GetGateConfig(ctx context.Context, subgraphName string) (*GateConfig, error)
+
+	// ListGateConfigs retrieves the gate configurations of all subgraphs
+	ListGateConfigs(ctx context.Context) ([]GateConfig, error)
+
+	// SaveGateConfig creates or replaces the gate configuration of a subgraph
+	SaveGateConfig(ctx context.Context, config *GateConfig) error
+
+	// DeleteGateConfig removes the gate configuration of a subgraph
+	DeleteGateConfig(ctx context.Context, subgraphName string) error
+}
+
+// RuleRepository defines the interface for rule catalog persistence
+type RuleRepository interface {
+	// GetRule retrieves a rule by its name. It returns an error wrapping ErrRuleNotFound
+	// when there is no such rule.
+	GetRule(ctx context.Context, name string) (*Rule, error)
+
+	// ListRules retrieves all rules ordered by name
+	ListRules(ctx context.Context) ([]Rule, error)
+
+	// CreateRule saves a new rule. It returns an error wrapping ErrRuleExists when the
+	// catalog already has a rule of that name.
+	CreateRule(ctx context.Context, rule *Rule) error
+
+	// UpdateRule saves the changes to an existing rule, keeping its creation time
+	UpdateRule(ctx context.Context, rule *Rule) error
+
+	// DeleteRule removes a rule
+	DeleteRule(ctx context.Context, name string) error
+
+	// RegisterRules adds the rules the catalog does not have yet, leaving existing rules
+	// untouched, and returns the names of the rules it added
+	RegisterRules(ctx context.Context, rules []Rule) ([]string, error)
+}
+
+// RescoreRepository defines the interface for ruleset version and rescored score persistence
+type RescoreRepository interface {
+	// SaveRulesetVersion saves a ruleset version unless it is stored already
+	SaveRulesetVersion(ctx context.Context, version *RulesetVersion) error
+
+	// GetRulesetVersion retrieves a ruleset version. It returns an error wrapping
+	// ErrRulesetVersionNotFound when there is no such version.
+	GetRulesetVersion(ctx context.Context, version string) (*RulesetVersion, error)
+
+	// ListRulesetVersions retrieves all ruleset versions, newest first
+	ListRulesetVersions(ctx context.Context) ([]RulesetVersion, error)
+
+	// SaveRescoredScores creates or replaces the scores of reports for their ruleset version
+	SaveRescoredScores(ctx context.Context, scores []RescoredScore) error
+
+	// GetRescoredScores retrieves the scores of the reports of a subgraph for a ruleset version
+	GetRescoredScores(ctx context.Context, subgraphName, version string) ([]RescoredScore, error)
+}
+
+// WebhookRepository defines the interface for webhook and delivery outbox persistence
+type WebhookRepository interface {
+	// StoreWebhook saves a new webhook
+	StoreWebhook(ctx context.Context, webhook *Webhook) error
+
+	// GetWebhook retrieves a webhook by its ID
+	GetWebhook(ctx context.Context, id string) (*Webhook, error)
+
+	// ListWebhooks retrieves all webhooks
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+
+	// ListWebhooksForSubgraph retrieves the global webhooks and those of one subgraph
+	ListWebhooksForSubgraph(ctx context.Context, subgraphName string) ([]Webhook, error)
+
+	// DeleteWebhook removes a webhook together with its deliveries
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// EnqueueDeliveries adds pending deliveries to the outbox
+	EnqueueDeliveries(ctx context.Context, deliveries []WebhookDelivery) error
+
+	// ClaimDueDeliveries returns up to limit pending deliveries due at the given time and
+	// postpones them by the lease, so that concurrent dispatchers do not send them twice
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]WebhookDelivery, error)
+
+	// SaveDeliveryAttempt records an attempt and the resulting delivery state
+	SaveDeliveryAttempt(ctx context.Context, delivery *WebhookDelivery, attempt DeliveryAttempt) error
+
+	// GetDelivery retrieves a delivery with its attempts
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+
+	// ListDeliveries retrieves the most recent deliveries, optionally filtered by webhook and status
+	ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]WebhookDelivery, error)
+
+	// RetryDelivery makes a delivery pending again, due at the given time
+	RetryDelivery(ctx context.Context, id string, at time.Time) error
+}
+
+// APITokenRepository defines the interface for API token persistence
+type APITokenRepository interface {
+	// Store saves a new token
+	Store(ctx context.Context, token *APIToken) error
+
+	// GetByHash retrieves a token by the hash of its plaintext value
+	GetByHash(ctx context.Context, hash string) (*APIToken, error)
+
+	// List retrieves all tokens
+	List(ctx context.Context) ([]APIToken, error)
+
+	// Revoke marks a token as revoked at the given time
+	Revoke(ctx context.Context, id string, at time.Time) error
+
+	// TouchLastUsed records when a token was last used
+	TouchLastUsed(ctx context.Context, id string, at time.Time) error
 }
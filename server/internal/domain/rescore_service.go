@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// RescoreService recomputes the scores of stored reports with the weights of a ruleset
+// version, so that scores stay comparable when rule weights change
+type RescoreService struct {
+	reports  SchemaReportRepository
+	rules    RuleRepository
+	rescores RescoreRepository
+}
+
+// NewRescoreService creates a new rescore service
+func NewRescoreService(reports SchemaReportRepository, rules RuleRepository, rescores RescoreRepository) *RescoreService {
+	return &RescoreService{
+		reports:  reports,
+		rules:    rules,
+		rescores: rescores,
+	}
+}
+
+// CurrentRulesetVersion returns the ruleset version of the current rule catalog without
+// saving it
+func (s *RescoreService) CurrentRulesetVersion(ctx context.Context) (*RulesetVersion, error) {
+	rules, err := s.rules.ListRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	return NewRulesetVersion(rules, time.Now()), nil
+}
+
+// ListRulesetVersions retrieves the ruleset versions reports were rescored with
+func (s *RescoreService) ListRulesetVersions(ctx context.Context) ([]RulesetVersion, error) {
+	versions, err := s.rescores.ListRulesetVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ruleset versions: %w", err)
+	}
+	return versions, nil
+}
+
+// Rescore recomputes the scores of the reports of a subgraph, or of all subgraphs when no
+// name is given, with a stored ruleset version. CurrentRulesetVersion or an empty version
+// takes a snapshot of the current rule catalog first. Rescoring again with the same
+// version replaces the scores.
+func (s *RescoreService) Rescore(ctx context.Context, version, subgraphName string) (*RescoreResult, error) {
+	ruleset, err := s.rulesetVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	subgraphs := []string{subgraphName}
+	if subgraphName == "" {
+		summaries, err := s.reports.GetSubgraphSummaries(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subgraphs: %w", err)
+		}
+		subgraphs = subgraphs[:0]
+		for _, summary := range summaries {
+			subgraphs = append(subgraphs, summary.Name)
+		}
+	}
+
+	result := &RescoreResult{RulesetVersion: ruleset.Version}
+	for _, subgraph := range subgraphs {
+		rescored, skipped, err := s.rescoreSubgraph(ctx, ruleset, subgraph)
+		if err != nil {
+			return nil, err
+		}
+		result.Rescored += rescored
+		result.Skipped += skipped
+	}
+
+	log.Printf("Rescored %d reports with ruleset version %s, skipped %d without fields",
+		result.Rescored, result.RulesetVersion, result.Skipped)
+	return result, nil
+}
+
+// rulesetVersion returns a stored ruleset version, saving a snapshot of the current
+// catalog when the current version is requested
+func (s *RescoreService) rulesetVersion(ctx context.Context, version string) (*RulesetVersion, error) {
+	if version != "" && version != CurrentRulesetVersion {
+		ruleset, err := s.rescores.GetRulesetVersion(ctx, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ruleset version: %w", err)
+		}
+		return ruleset, nil
+	}
+
+	ruleset, err := s.CurrentRulesetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rescores.SaveRulesetVersion(ctx, ruleset); err != nil {
+		return nil, fmt.Errorf("failed to save ruleset version: %w", err)
+	}
+	return ruleset, nil
+}
+
+// rescoreSubgraph rescores and saves the reports of one subgraph, returning how many were
+// rescored and skipped
+func (s *RescoreService) rescoreSubgraph(ctx context.Context, ruleset *RulesetVersion, subgraphName string) (int, int, error) {
+	reports, err := s.reports.GetReportsWithRuleResults(ctx, subgraphName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get reports of %s: %w", subgraphName, err)
+	}
+
+	now := time.Now()
+	scores := make([]RescoredScore, 0, len(reports))
+	for _, report := range reports {
+		score, ok := ruleset.Rescore(report)
+		if !ok {
+			continue
+		}
+		scores = append(scores, RescoredScore{
+			ReportID:       report.ID,
+			SubgraphName:   report.SubgraphName,
+			RulesetVersion: ruleset.Version,
+			Score:          score,
+			RescoredAt:     now,
+		})
+	}
+
+	if len(scores) > 0 {
+		if err := s.rescores.SaveRescoredScores(ctx, scores); err != nil {
+			return 0, 0, fmt.Errorf("failed to save rescored scores of %s: %w", subgraphName, err)
+		}
+	}
+	return len(scores), len(reports) - len(scores), nil
+}
+
+// GetCurrentScores returns the scores of the reports of a subgraph rescored with the ruleset
+// version of the current rule catalog. Until the reports are rescored with that version it
+// falls back to the latest stored version they were rescored with, and the scores are
+// empty when there is none.
+func (s *RescoreService) GetCurrentScores(ctx context.Context, subgraphName string) (*RescoredSeries, error) {
+	ruleset, err := s.CurrentRulesetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.rescoredSeries(ctx, subgraphName, ruleset)
+	if err != nil {
+		return nil, err
+	}
+	series.Current = true
+	if len(series.Scores) > 0 {
+		return series, nil
+	}
+
+	versions, err := s.rescores.ListRulesetVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ruleset versions: %w", err)
+	}
+	for i := range versions {
+		if versions[i].Version == ruleset.Version {
+			continue
+		}
+		fallback, err := s.rescoredSeries(ctx, subgraphName, &versions[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(fallback.Scores) > 0 {
+			return fallback, nil
+		}
+	}
+	return series, nil
+}
+
+// rescoredSeries returns the scores of the reports of a subgraph rescored with a ruleset
+// version, keyed by report ID
+func (s *RescoreService) rescoredSeries(ctx context.Context, subgraphName string, ruleset *RulesetVersion) (*RescoredSeries, error) {
+	rescored, err := s.rescores.GetRescoredScores(ctx, subgraphName, ruleset.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rescored scores: %w", err)
+	}
+
+	scores := make(map[string]float64, len(rescored))
+	for _, score := range rescored {
+		scores[score.ReportID] = score.Score
+	}
+	return &RescoredSeries{Ruleset: ruleset, Scores: scores}, nil
+}
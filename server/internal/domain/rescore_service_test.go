@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestRescoreService() (*RescoreService, *MockSchemaReportRepository, *MockRuleRepository, *MockRescoreRepository) {
+	reports := NewMockSchemaReportRepository()
+	reports.SubgraphSummaries = []SubgraphSummary{{Name: "user-service"}, {Name: "order-service"}}
+	reports.Reports["1"] = &SchemaReport{ID: "1", SubgraphName: "user-service", TotalFields: 100, Timestamp: time.Now(),
+		RuleResults: []RuleResult{{RuleName: "PII", ViolationCount: 1, Weight: 10}}}
+	reports.Reports["2"] = &SchemaReport{ID: "2", SubgraphName: "order-service", TotalFields: 50, Timestamp: time.Now(),
+		RuleResults: []RuleResult{{RuleName: "PII", ViolationCount: 1, Weight: 10}}}
+	reports.Reports["3"] = &SchemaReport{ID: "3", SubgraphName: "order-service", Timestamp: time.Now()}
+
+	rules := NewMockRuleRepository().WithRule(Rule{Name: "PII", Weight: 20, Category: RuleCategoryImportant})
+	rescores := NewMockRescoreRepository()
+	return NewRescoreService(reports, rules, rescores), reports, rules, rescores
+}
+
+func TestRescoreService_Rescore(t *testing.T) {
+	service, _, _, rescores := newTestRescoreService()
+	ctx := context.Background()
+
+	result, err := service.Rescore(ctx, CurrentRulesetVersion, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Rescored)
+	assert.Equal(t, 1, result.Skipped, "reports without fields cannot be scored")
+
+	// The current catalog is saved as the version the scores belong to
+	if assert.Contains(t, rescores.Versions, result.RulesetVersion) {
+		assert.Equal(t, 20.0, rescores.Versions[result.RulesetVersion].Weights["PII"])
+	}
+	scores, err := rescores.GetRescoredScores(ctx, "user-service", result.RulesetVersion)
+	if assert.NoError(t, err) && assert.Len(t, scores, 1) {
+		assert.InDelta(t, 80, scores[0].Score, 0.001)
+	}
+	scores, err = rescores.GetRescoredScores(ctx, "order-service", result.RulesetVersion)
+	if assert.NoError(t, err) && assert.Len(t, scores, 1) {
+		assert.InDelta(t, 60, scores[0].Score, 0.001)
+	}
+
+	// Rescoring again with the same version replaces the scores
+	again, err := service.Rescore(ctx, result.RulesetVersion, "user-service")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, again.Rescored)
+	assert.Len(t, rescores.Scores, 2)
+
+	_, err = service.Rescore(ctx, "unknown", "")
+	assert.ErrorIs(t, err, ErrRulesetVersionNotFound)
+}
+
+func TestRescoreService_Rescore_SaveError(t *testing.T) {
+	service, _, _, rescores := newTestRescoreService()
+	rescores.ShouldFailSaveScores = true
+
+	_, err := service.Rescore(context.Background(), "", "")
+	assert.Error(t, err)
+}
+
+func TestRescoreService_GetCurrentScores(t *testing.T) {
+	service, _, rules, _ := newTestRescoreService()
+	ctx := context.Background()
+
+	// Nothing is rescored with the current weights yet
+	series, err := service.GetCurrentScores(ctx, "user-service")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, series.Ruleset.Version)
+	assert.True(t, series.Current)
+	assert.Empty(t, series.Scores)
+
+	rescored, err := service.Rescore(ctx, "", "")
+	assert.NoError(t, err)
+	series, err = service.GetCurrentScores(ctx, "user-service")
+	assert.NoError(t, err)
+	assert.True(t, series.Current)
+	assert.InDelta(t, 80, series.Scores["1"], 0.001)
+
+	// Registering an unknown rule for review keeps the version
+	_, err = NewRuleCatalogService(rules).RegisterUnknownRules(ctx, []RuleResult{{RuleName: "field-descriptions", Weight: 10}})
+	assert.NoError(t, err)
+	series, err = service.GetCurrentScores(ctx, "user-service")
+	assert.NoError(t, err)
+	assert.True(t, series.Current)
+	assert.Equal(t, rescored.RulesetVersion, series.Ruleset.Version)
+
+	// Changing a weight falls back to the last rescore until the next one
+	rules.Rules["PII"].Weight = 5
+	series, err = service.GetCurrentScores(ctx, "user-service")
+	assert.NoError(t, err)
+	assert.False(t, series.Current)
+	assert.Equal(t, rescored.RulesetVersion, series.Ruleset.Version)
+	assert.InDelta(t, 80, series.Scores["1"], 0.001)
+
+	// Subgraphs that were never rescored have no scores
+	series, err = service.GetCurrentScores(ctx, "billing-service")
+	assert.NoError(t, err)
+	assert.True(t, series.Current)
+	assert.Empty(t, series.Scores)
+}
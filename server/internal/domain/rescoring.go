@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CurrentRulesetVersion selects the ruleset version of the current rule catalog
+const CurrentRulesetVersion = "current"
+
+// rulesetVersionLength is the number of hex characters of a ruleset version
+const rulesetVersionLength = 12
+
+// RulesetVersion is a snapshot of the weights of the reviewed rules of the catalog. The
+// version is derived from the weights, so unchanged weights always produce the same version.
+type RulesetVersion struct {
+	Version   string
+	Weights   map[string]float64
+	CreatedAt time.Time
+}
+
+// NewRulesetVersion takes a snapshot of the weights of the given rules. Rules that await
+// review are left out, so registering the unknown rules of a report keeps the version.
+func NewRulesetVersion(rules []Rule, at time.Time) *RulesetVersion {
+	weights := make(map[string]float64, len(rules))
+	for _, rule := range rules {
+		if rule.NeedsReview {
+			continue
+		}
+		weights[rule.Name] = rule.Weight
+	}
+
+	return &RulesetVersion{
+		Version:   rulesetVersionOf(weights),
+		Weights:   weights,
+		CreatedAt: at,
+	}
+}
+
+// rulesetVersionOf hashes the weights ordered by rule name
+func rulesetVersionOf(weights map[string]float64) string {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\x00%s\n", name, strconv.FormatFloat(weights[name], 'f', -1, 64))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:rulesetVersionLength]
+}
+
+// Rescore recomputes the score of a report with the weights of the ruleset. Rules the
+// ruleset does not know keep the weight the report was scored with. It returns false for
+// reports without fields, which the formula cannot score.
+func (v *RulesetVersion) Rescore(report SchemaReport) (float64, bool) {
+	ruleResults := make([]RuleResult, len(report.RuleResults))
+	for i, ruleResult := range report.RuleResults {
+		if weight, ok := v.Weights[ruleResult.RuleName]; ok {
+			ruleResult.Weight = weight
+		}
+		ruleResults[i] = ruleResult
+	}
+	return ComputeScore(report.TotalFields, ruleResults)
+}
+
+// RescoredSeries is the score series of a subgraph rescored with one ruleset version
+type RescoredSeries struct {
+	Ruleset *RulesetVersion
+	// Current is false when no report is rescored with the current weights yet and the
+	// series falls back to the latest ruleset version the subgraph was rescored with
+	Current bool
+	// Scores are keyed by report ID
+	Scores map[string]float64
+}
+
+// RescoredScore is the score of a report recomputed with the weights of a ruleset version
+type RescoredScore struct {
+	ReportID       string
+	SubgraphName   string
+	RulesetVersion string
+	Score          float64
+	RescoredAt     time.Time
+}
+
+// RescoreResult summarizes a rescore run
+type RescoreResult struct {
+	RulesetVersion string
+	// Rescored counts the reports that got a score, Skipped the reports without fields
+	Rescored int
+	Skipped  int
+}
+
+// IncomingRescore is a rescore request. An empty version rescores with the current rule
+// catalog, an empty subgraph rescores every subgraph.
+type IncomingRescore struct {
+	Version  string `json:"version"`
+	Subgraph string `json:"subgraph"`
+}
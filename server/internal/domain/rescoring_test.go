@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewRulesetVersion(t *testing.T) {
+	rules := []Rule{{Name: "PII", Weight: 10}, {Name: "Deprecation", Weight: 5}}
+	reversed := []Rule{{Name: "Deprecation", Weight: 5}, {Name: "PII", Weight: 10}}
+
+	version := NewRulesetVersion(rules, time.Now())
+	assert.Len(t, version.Version, rulesetVersionLength)
+	assert.Equal(t, map[string]float64{"PII": 10, "Deprecation": 5}, version.Weights)
+
+	// The version only depends on the weights
+	assert.Equal(t, version.Version, NewRulesetVersion(reversed, time.Now().Add(time.Hour)).Version)
+	assert.NotEqual(t, version.Version, NewRulesetVersion([]Rule{{Name: "PII", Weight: 12}, {Name: "Deprecation", Weight: 5}}, time.Now()).Version)
+	assert.NotEqual(t, version.Version, NewRulesetVersion(rules[:1], time.Now()).Version)
+}
+
+func TestRulesetVersion_Rescore(t *testing.T) {
+	version := NewRulesetVersion([]Rule{{Name: "PII", Weight: 5}}, time.Now())
+	report := SchemaReport{
+		TotalFields: 100,
+		RuleResults: []RuleResult{
+			{RuleName: "PII", ViolationCount: 4, Weight: 10},
+			{RuleName: "Custom", ViolationCount: 1, Weight: 2},
+		},
+	}
+
+	// PII takes 5 × 4^1.5 = 40 with the new weight, Custom keeps its weight of 2
+	score, ok := version.Rescore(report)
+	assert.True(t, ok)
+	assert.InDelta(t, 58, score, 0.001)
+	assert.Equal(t, 10.0, report.RuleResults[0].Weight)
+
+	_, ok = version.Rescore(SchemaReport{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// RuleCategories are the categories a rule in the catalog can be filed under, most
+// severe first. RuleCategoryUncategorized is left to rules that await review.
+var RuleCategories = []string{RuleCategoryCritical, RuleCategoryImportant, RuleCategoryStyle}
+
+// RuleExample shows how to fix a violation of a rule
+type RuleExample struct {
+	Title string
+	// Before is the schema that violates the rule, After the schema that fixes it
+	Before string
+	After  string
+}
+
+// Rule is an entry of the rule catalog, which documents the rules the schema scorer
+// checks and the weights they are scored with
+type Rule struct {
+	Name        string
+	Weight      float64
+	Category    string
+	Description string
+	Rationale   string
+	FixExamples []RuleExample
+	// NeedsReview is set on rules that were registered because a report used them,
+	// and cleared when someone saves the rule
+	NeedsReview bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate checks that the rule is complete. It returns a *ValidationError wrapping
+// ErrInvalidRule that lists every invalid field.
+func (r Rule) Validate() error {
+	var fields []FieldError
+	invalid := func(path, reason string) {
+		fields = append(fields, FieldError{Path: path, Reason: reason})
+	}
+
+	switch {
+	case strings.TrimSpace(r.Name) == "":
+		invalid("name", "is required")
+	case utf8.RuneCountInString(r.Name) > maxRuleNameLength:
+		invalid("name", fmt.Sprintf("must be at most %d characters", maxRuleNameLength))
+	}
+
+	if reason := invalidDecimal(r.Weight); reason != "" {
+		invalid("weight", reason)
+	} else if r.Weight < 0 {
+		invalid("weight", "must not be negative")
+	}
+
+	if !isRuleCategory(r.Category) {
+		invalid("category", "must be one of "+strings.Join(RuleCategories, ", "))
+	}
+
+	for i, example := range r.FixExamples {
+		if strings.TrimSpace(example.After) == "" {
+			invalid(fmt.Sprintf("fixExamples[%d].after", i), "is required")
+		}
+	}
+
+	if len(fields) > 0 {
+		return NewValidationError(ErrInvalidRule, fields...)
+	}
+	return nil
+}
+
+// isRuleCategory reports whether a category is one of RuleCategories
+func isRuleCategory(category string) bool {
+	for _, known := range RuleCategories {
+		if category == known {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownRule is the catalog entry registered for a rule result whose rule is not in the
+// catalog. It keeps the weight and category the rule was scored with until it is reviewed.
+func unknownRule(ruleResult RuleResult, at time.Time) Rule {
+	category := ruleResult.Category
+	if category == "" {
+		category = RuleCategoryUncategorized
+	}
+	return Rule{
+		Name:        ruleResult.RuleName,
+		Weight:      ruleResult.Weight,
+		Category:    category,
+		FixExamples: []RuleExample{},
+		NeedsReview: true,
+		CreatedAt:   at,
+		UpdatedAt:   at,
+	}
+}
+
+// IncomingRuleExample represents the JSON structure of a fix example
+type IncomingRuleExample struct {
+	Title  string `json:"title"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// IncomingRule represents the JSON structure used to create or update a rule
+type IncomingRule struct {
+	Name        string                `json:"name"`
+	Weight      float64               `json:"weight"`
+	Category    string                `json:"category"`
+	Description string                `json:"description"`
+	Rationale   string                `json:"rationale"`
+	FixExamples []IncomingRuleExample `json:"fixExamples"`
+}
+
+// ToDomainEntity converts the incoming DTO to a rule with the given name
+func (ir *IncomingRule) ToDomainEntity(name string) *Rule {
+	rule := &Rule{
+		Name:        name,
+		Weight:      ir.Weight,
+		Category:    strings.ToLower(ir.Category),
+		Description: ir.Description,
+		Rationale:   ir.Rationale,
+		FixExamples: make([]RuleExample, 0, len(ir.FixExamples)),
+	}
+	for _, example := range ir.FixExamples {
+		rule.FixExamples = append(rule.FixExamples, RuleExample{
+			Title:  example.Title,
+			Before: example.Before,
+			After:  example.After,
+		})
+	}
+	return rule
+}
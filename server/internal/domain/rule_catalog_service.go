@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RuleCatalogService contains the business logic for the rule catalog
+type RuleCatalogService struct {
+	rules RuleRepository
+}
+
+// NewRuleCatalogService creates a new rule catalog service
+func NewRuleCatalogService(rules RuleRepository) *RuleCatalogService {
+	return &RuleCatalogService{
+		rules: rules,
+	}
+}
+
+// ListRules retrieves all rules of the catalog
+func (s *RuleCatalogService) ListRules(ctx context.Context) ([]Rule, error) {
+	rules, err := s.rules.ListRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetRule retrieves a rule by its name
+func (s *RuleCatalogService) GetRule(ctx context.Context, name string) (*Rule, error) {
+	rule, err := s.rules.GetRule(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+	return rule, nil
+}
+
+// CreateRule validates and stores a new rule
+func (s *RuleCatalogService) CreateRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.NeedsReview = false
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if err := s.rules.CreateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+	return rule, nil
+}
+
+// UpdateRule validates and replaces an existing rule. Saving a rule marks it as reviewed.
+func (s *RuleCatalogService) UpdateRule(ctx context.Context, name string, rule *Rule) (*Rule, error) {
+	rule.Name = name
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.rules.GetRule(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+
+	rule.NeedsReview = false
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+
+	if err := s.rules.UpdateRule(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+	return rule, nil
+}
+
+// DeleteRule removes a rule from the catalog. A report that uses it registers it again.
+func (s *RuleCatalogService) DeleteRule(ctx context.Context, name string) error {
+	if err := s.rules.DeleteRule(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return nil
+}
+
+// RuleDefinitions returns the weights and categories of the reviewed rules of the catalog.
+// Rules that await review are left out, so reports that use them are weighed with
+// DefaultRuleWeight and flagged until someone reviews the rule.
+func (s *RuleCatalogService) RuleDefinitions(ctx context.Context) (RuleDefinitions, error) {
+	rules, err := s.rules.ListRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	definitions := make(RuleDefinitions, len(rules))
+	for _, rule := range rules {
+		if rule.NeedsReview {
+			continue
+		}
+		definitions[rule.Name] = RuleDefinition{Weight: rule.Weight, Category: rule.Category}
+	}
+	return definitions, nil
+}
+
+// RegisterUnknownRules adds the rules of the rule results that are not in the catalog,
+// flagged for review, and returns their names
+func (s *RuleCatalogService) RegisterUnknownRules(ctx context.Context, ruleResults []RuleResult) ([]string, error) {
+	if len(ruleResults) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	rules := make([]Rule, 0, len(ruleResults))
+	for _, ruleResult := range ruleResults {
+		rules = append(rules, unknownRule(ruleResult, now))
+	}
+
+	registered, err := s.rules.RegisterRules(ctx, rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register rules: %w", err)
+	}
+	return registered, nil
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRuleCatalogService_CreateRule(t *testing.T) {
+	rules := NewMockRuleRepository()
+	service := NewRuleCatalogService(rules)
+
+	created, err := service.CreateRule(context.Background(), &Rule{Name: "PII", Weight: 10, Category: RuleCategoryImportant, NeedsReview: true})
+	assert.NoError(t, err)
+	assert.False(t, created.NeedsReview)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.Contains(t, rules.Rules, "PII")
+
+	_, err = service.CreateRule(context.Background(), &Rule{Name: "PII", Weight: 10, Category: RuleCategoryImportant})
+	assert.ErrorIs(t, err, ErrRuleExists)
+
+	_, err = service.CreateRule(context.Background(), &Rule{Name: "Unknown", Category: "severe"})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+}
+
+func TestRuleCatalogService_UpdateRule(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rules := NewMockRuleRepository().WithRule(Rule{
+		Name:        "field-descriptions",
+		Weight:      10,
+		Category:    RuleCategoryUncategorized,
+		NeedsReview: true,
+		CreatedAt:   createdAt,
+	})
+	service := NewRuleCatalogService(rules)
+
+	// Saving a registered rule marks it as reviewed
+	updated, err := service.UpdateRule(context.Background(), "field-descriptions",
+		&Rule{Name: "ignored", Weight: 2, Category: RuleCategoryStyle})
+	assert.NoError(t, err)
+	assert.Equal(t, "field-descriptions", updated.Name)
+	assert.False(t, updated.NeedsReview)
+	assert.Equal(t, createdAt, updated.CreatedAt)
+	assert.Equal(t, 2.0, rules.Rules["field-descriptions"].Weight)
+
+	_, err = service.UpdateRule(context.Background(), "missing", &Rule{Weight: 2, Category: RuleCategoryStyle})
+	assert.ErrorIs(t, err, ErrRuleNotFound)
+}
+
+func TestRuleCatalogService_RuleDefinitions(t *testing.T) {
+	rules := NewMockRuleRepository().
+		WithRule(Rule{Name: "PII", Weight: 4, Category: RuleCategoryImportant}).
+		WithRule(Rule{Name: "field-descriptions", Weight: 1, Category: RuleCategoryUncategorized, NeedsReview: true})
+	service := NewRuleCatalogService(rules)
+
+	// Only reviewed rules are trusted
+	definitions, err := service.RuleDefinitions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, RuleDefinitions{"PII": {Weight: 4, Category: RuleCategoryImportant}}, definitions)
+	assert.Equal(t, DefaultRuleWeight, definitions.Weight("field-descriptions"))
+}
+
+func TestRuleCatalogService_RegisterUnknownRules(t *testing.T) {
+	rules := NewMockRuleRepository().WithRule(Rule{Name: "PII", Weight: 10, Category: RuleCategoryImportant})
+	service := NewRuleCatalogService(rules)
+
+	pii := RuleResult{RuleName: "PII"}
+	pii.Weigh(DefaultRuleCatalog, nil, nil)
+	unknown := RuleResult{RuleName: "field-descriptions", ViolationCount: 2}
+	unknown.Weigh(DefaultRuleCatalog, float64Ptr(3), nil)
+
+	registered, err := service.RegisterUnknownRules(context.Background(), []RuleResult{pii, unknown})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"field-descriptions"}, registered)
+
+	// Registered rules keep the weight and category they were scored with
+	rule := rules.Rules["field-descriptions"]
+	assert.Equal(t, 3.0, rule.Weight)
+	assert.Equal(t, RuleCategoryUncategorized, rule.Category)
+	assert.True(t, rule.NeedsReview)
+	assert.False(t, rules.Rules["PII"].NeedsReview)
+}
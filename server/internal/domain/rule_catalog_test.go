@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRule_Validate(t *testing.T) {
+	valid := Rule{Name: "PII", Weight: 10, Category: RuleCategoryImportant}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name           string
+		rule           Rule
+		expectedFields []FieldError
+	}{
+		{
+			name: "missing name and unknown category",
+			rule: Rule{Weight: 10, Category: RuleCategoryUncategorized},
+			expectedFields: []FieldError{
+				{Path: "name", Reason: "is required"},
+				{Path: "category", Reason: "must be one of critical, important, style"},
+			},
+		},
+		{
+			name: "long name and negative weight",
+			rule: Rule{Name: strings.Repeat("r", 101), Weight: -1, Category: RuleCategoryStyle},
+			expectedFields: []FieldError{
+				{Path: "name", Reason: "must be at most 100 characters"},
+				{Path: "weight", Reason: "must not be negative"},
+			},
+		},
+		{
+			name: "weight that is not a number",
+			rule: Rule{Name: "PII", Weight: math.NaN(), Category: RuleCategoryStyle},
+			expectedFields: []FieldError{
+				{Path: "weight", Reason: "must be a finite number"},
+			},
+		},
+		{
+			name: "fix example without a fix",
+			rule: Rule{Name: "PII", Weight: 10, Category: RuleCategoryImportant,
+				FixExamples: []RuleExample{{After: "email: String! @pii"}, {Before: "email: String!"}}},
+			expectedFields: []FieldError{
+				{Path: "fixExamples[1].after", Reason: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			assert.ErrorIs(t, err, ErrInvalidRule)
+
+			var validationErr *ValidationError
+			if assert.True(t, errors.As(err, &validationErr)) {
+				assert.Equal(t, tt.expectedFields, validationErr.Fields)
+			}
+		})
+	}
+}
+
+func TestIncomingRule_ToDomainEntity(t *testing.T) {
+	incoming := IncomingRule{
+		Name:        "ignored",
+		Weight:      10,
+		Category:    "Important",
+		Description: "Flags unmarked personal data.",
+		FixExamples: []IncomingRuleExample{{Title: "Mark the field", Before: "email: String!", After: "email: String! @pii"}},
+	}
+
+	rule := incoming.ToDomainEntity("PII")
+	assert.Equal(t, "PII", rule.Name)
+	assert.Equal(t, RuleCategoryImportant, rule.Category)
+	assert.Equal(t, []RuleExample{{Title: "Mark the field", Before: "email: String!", After: "email: String! @pii"}}, rule.FixExamples)
+
+	// Rules without examples never have nil examples
+	assert.NotNil(t, (&IncomingRule{}).ToDomainEntity("PII").FixExamples)
+}
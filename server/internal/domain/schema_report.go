@@ -11,23 +11,37 @@ type SchemaReport struct {
 	ID                      string
 	SubgraphName            string
 	Score                   float64
+	EffectiveScore          float64
+	SuppressedCount         int
 	TotalFields             int
 	TotalWeightedViolations float64
-	Timestamp               time.Time
-	Metadata                map[string]interface{}
-	CreatedAt               time.Time
-	RuleResults             []RuleResult
+	// RecomputedScore is the score the server computed from the rule results, nil for
+	// reports without fields. Score is the one the scorer claimed.
+	RecomputedScore *float64
+	// ScoreMismatch flags reports whose claimed score differs from the recomputed one
+	ScoreMismatch bool
+	Timestamp     time.Time
+	Metadata      map[string]interface{}
+	APITokenID    *string
+	CreatedAt     time.Time
+	RuleResults   []RuleResult
 }
 
 // RuleResult represents the result of a single rule validation
 type RuleResult struct {
-	ID             string
-	ReportID       string
-	RuleName       string
-	ViolationCount int
-	Message        string
-	CreatedAt      time.Time
-	Violations     []Violation
+	ID              string
+	ReportID        string
+	RuleName        string
+	ViolationCount  int
+	SuppressedCount int
+	Message         string
+	// Weight and Category are reported by the scorer, or taken from the rule catalog
+	Weight   float64
+	Category string
+	// Contribution is what the rule added to the total weighted violations
+	Contribution float64
+	CreatedAt    time.Time
+	Violations   []Violation
 }
 
 // Violation represents a single rule violation
@@ -40,6 +54,12 @@ type Violation struct {
 	LocationField      *string
 	LocationType       *string
 	LocationCoordinate *string
+	Fingerprint        string
+	FirstSeenReportID  *string
+	FirstSeenAt        *time.Time
+	AgeDays            int
+	Suppressed         bool
+	SuppressionID      *string
 	CreatedAt          time.Time
 }
 
@@ -62,7 +82,7 @@ func NewSchemaReport(
 	timestamp time.Time,
 	metadata map[string]interface{},
 ) *SchemaReport {
-	sName := "Unknown"
+	sName := UnknownSubgraph
 	if subgraphName != nil {
 		sName = *subgraphName
 	}
@@ -71,6 +91,7 @@ func NewSchemaReport(
 		ID:                      id,
 		SubgraphName:            sName,
 		Score:                   score,
+		EffectiveScore:          score,
 		TotalFields:             totalFields,
 		TotalWeightedViolations: totalWeightedViolations,
 		Timestamp:               timestamp,
@@ -85,6 +106,28 @@ func (sr *SchemaReport) AddRuleResult(ruleResult RuleResult) {
 	sr.RuleResults = append(sr.RuleResults, ruleResult)
 }
 
+// ActiveViolations returns the violations of the rule that are not suppressed
+func (rr RuleResult) ActiveViolations() []Violation {
+	violations := make([]Violation, 0, len(rr.Violations))
+	for _, violation := range rr.Violations {
+		if !violation.Suppressed {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// SuppressedViolations returns the violations of the rule that are suppressed
+func (rr RuleResult) SuppressedViolations() []Violation {
+	violations := make([]Violation, 0, rr.SuppressedCount)
+	for _, violation := range rr.Violations {
+		if violation.Suppressed {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
 // GetDisplayName returns the subgraph name or "Unknown" if nil
 func (sr *SchemaReport) GetDisplayName() string {
 	return sr.SubgraphName
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// Rule categories, as explained on the about page
+const (
+	// RuleCategoryCritical rules catch problems that break clients or the supergraph
+	RuleCategoryCritical = "critical"
+	// RuleCategoryImportant rules catch security and type system problems
+	RuleCategoryImportant = "important"
+	// RuleCategoryStyle rules enforce conventions and maintainability
+	RuleCategoryStyle = "style"
+	// RuleCategoryUncategorized is the category of rules the server does not know
+	RuleCategoryUncategorized = "uncategorized"
+)
+
+// DefaultRuleWeight is used for rules the server does not know a weight for
+const DefaultRuleWeight = 10.0
+
+// RuleDefinition is the weight and category of a rule
+type RuleDefinition struct {
+	Weight   float64
+	Category string
+}
+
+// RuleDefinitions are the weights and categories of the rules the server trusts, keyed by
+// rule name. Reports are weighed and verified with them instead of the weights they claim.
+type RuleDefinitions map[string]RuleDefinition
+
+// RuleDefinitionSource provides the rule definitions that reports are weighed and verified
+// with, such as the reviewed rules of the rule catalog
+type RuleDefinitionSource interface {
+	RuleDefinitions(ctx context.Context) (RuleDefinitions, error)
+}
+
+// DefaultRuleCatalog mirrors the rules of the schema scorer. It seeds the rule catalog of
+// the in-memory storage, the database catalog is seeded by its migrations.
+var DefaultRuleCatalog = RuleDefinitions{
+	"PII":                {Weight: 10, Category: RuleCategoryImportant},
+	"Composite Keys":     {Weight: 5, Category: RuleCategoryStyle},
+	"Cycle Counter":      {Weight: 15, Category: RuleCategoryCritical},
+	"Null Blast Radius":  {Weight: 20, Category: RuleCategoryCritical},
+	"Deprecation":        {Weight: 5, Category: RuleCategoryStyle},
+	"Problem Union":      {Weight: 10, Category: RuleCategoryImportant},
+	"Nullable External":  {Weight: 15, Category: RuleCategoryCritical},
+	"Plural Collections": {Weight: 5, Category: RuleCategoryStyle},
+	"Boolean Prefix":     {Weight: 5, Category: RuleCategoryStyle},
+}
+
+// RuleDefinitions returns the definitions themselves, so that fixed definitions can serve
+// as a RuleDefinitionSource
+func (d RuleDefinitions) RuleDefinitions(ctx context.Context) (RuleDefinitions, error) {
+	return d, nil
+}
+
+// Lookup returns the definition of a rule, falling back to DefaultRuleWeight and
+// RuleCategoryUncategorized
+func (d RuleDefinitions) Lookup(ruleName string) RuleDefinition {
+	if definition, ok := d[ruleName]; ok {
+		return definition
+	}
+	return RuleDefinition{Weight: DefaultRuleWeight, Category: RuleCategoryUncategorized}
+}
+
+// Weight returns the weight of a rule, falling back to DefaultRuleWeight
+func (d RuleDefinitions) Weight(ruleName string) float64 {
+	return d.Lookup(ruleName).Weight
+}
+
+// ComputeScore recomputes a score like the package-level ComputeScore, weighing the rule
+// results with these definitions instead of the weights they claim
+func (d RuleDefinitions) ComputeScore(totalFields int, ruleResults []RuleResult) (float64, bool) {
+	weighed := make([]RuleResult, len(ruleResults))
+	for i, ruleResult := range ruleResults {
+		ruleResult.Weight = d.Weight(ruleResult.RuleName)
+		weighed[i] = ruleResult
+	}
+	return ComputeScore(totalFields, weighed)
+}
+
+// WeightedViolations returns the contribution of a rule to the total weighted
+// violations, using the scorer's formula weight × count^1.5
+func WeightedViolations(weight float64, violationCount int) float64 {
+	if violationCount <= 0 {
+		return 0
+	}
+	return weight * math.Pow(float64(violationCount), 1.5)
+}
+
+// ComputeScore recomputes a score from rule results with the scorer's formula
+// score = 100 × (1 - totalWeightedViolations ÷ totalFields). It returns false when the
+// report has no fields, which the formula cannot score.
+func ComputeScore(totalFields int, ruleResults []RuleResult) (float64, bool) {
+	if totalFields <= 0 {
+		return 0, false
+	}
+
+	var weightedViolations float64
+	for _, ruleResult := range ruleResults {
+		weightedViolations += WeightedViolations(ruleResult.Weight, ruleResult.ViolationCount)
+	}
+	return 100 * (1 - weightedViolations/float64(totalFields)), true
+}
+
+// VerifyScore recomputes the score of the report from its rule results with the server's
+// weights. It flags the report when the claimed score differs by more than the tolerance,
+// or when a rule result claims another weight than the server's.
+func (sr *SchemaReport) VerifyScore(weights RuleDefinitions, tolerance float64) {
+	sr.RecomputedScore = nil
+	sr.ScoreMismatch = false
+
+	if score, ok := weights.ComputeScore(sr.TotalFields, sr.RuleResults); ok {
+		sr.RecomputedScore = &score
+		sr.ScoreMismatch = math.Abs(sr.Score-score) > tolerance
+	}
+	for _, ruleResult := range sr.RuleResults {
+		if ruleResult.Weight != weights.Weight(ruleResult.RuleName) {
+			sr.ScoreMismatch = true
+		}
+	}
+}
+
+// Weigh sets the weight and category of a rule result, taking the ones that are not
+// given from the definitions, and computes its contribution
+func (rr *RuleResult) Weigh(definitions RuleDefinitions, weight *float64, category *string) {
+	definition := definitions.Lookup(rr.RuleName)
+	rr.Weight = definition.Weight
+	if weight != nil {
+		rr.Weight = *weight
+	}
+	rr.Category = definition.Category
+	if category != nil && *category != "" {
+		rr.Category = *category
+	}
+	rr.Contribution = WeightedViolations(rr.Weight, rr.ViolationCount)
+}
+
+// RuleContribution is how much one rule moved the score of a report
+type RuleContribution struct {
+	RuleName       string
+	Category       string
+	Weight         float64
+	ViolationCount int
+	// Contribution is the rule's share of the total weighted violations
+	Contribution float64
+	// Points is how many points the rule took off the score
+	Points float64
+	// Share is the percentage of all lost points that the rule is responsible for
+	Share float64
+}
+
+// ScoreBreakdown explains the score of a report rule by rule
+type ScoreBreakdown struct {
+	// Rules lists the rules with violations, costliest first
+	Rules []RuleContribution
+	// Points is how many points the rules took off the score in total
+	Points float64
+	// Score is the score the rules leave, 100 minus Points
+	Score float64
+}
+
+// ScoreBreakdown returns how each rule with violations moved the score of the report,
+// following score = 100 × (1 - totalWeightedViolations ÷ totalFields)
+func (sr *SchemaReport) ScoreBreakdown() ScoreBreakdown {
+	var breakdown ScoreBreakdown
+	for _, ruleResult := range sr.RuleResults {
+		if ruleResult.Contribution <= 0 {
+			continue
+		}
+
+		contribution := RuleContribution{
+			RuleName:       ruleResult.RuleName,
+			Category:       ruleResult.Category,
+			Weight:         ruleResult.Weight,
+			ViolationCount: ruleResult.ViolationCount,
+			Contribution:   ruleResult.Contribution,
+		}
+		if sr.TotalFields > 0 {
+			contribution.Points = 100 * ruleResult.Contribution / float64(sr.TotalFields)
+		}
+		breakdown.Points += contribution.Points
+		breakdown.Rules = append(breakdown.Rules, contribution)
+	}
+
+	breakdown.Score = 100 - breakdown.Points
+
+	for i := range breakdown.Rules {
+		if breakdown.Points > 0 {
+			breakdown.Rules[i].Share = 100 * breakdown.Rules[i].Points / breakdown.Points
+		}
+	}
+	sort.SliceStable(breakdown.Rules, func(i, j int) bool {
+		return breakdown.Rules[i].Contribution > breakdown.Rules[j].Contribution
+	})
+
+	return breakdown
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRuleResult_Weigh(t *testing.T) {
+	// Rules without a reported weight fall back to the catalog
+	pii := RuleResult{RuleName: "PII", ViolationCount: 4}
+	pii.Weigh(DefaultRuleCatalog, nil, nil)
+	assert.Equal(t, 10.0, pii.Weight)
+	assert.Equal(t, RuleCategoryImportant, pii.Category)
+	assert.Equal(t, 80.0, pii.Contribution)
+
+	unknown := RuleResult{RuleName: "Field Descriptions", ViolationCount: 1}
+	unknown.Weigh(DefaultRuleCatalog, nil, nil)
+	assert.Equal(t, DefaultRuleWeight, unknown.Weight)
+	assert.Equal(t, RuleCategoryUncategorized, unknown.Category)
+
+	// Reported weights win, even a weight of zero
+	weight, category := 0.0, "experimental"
+	reported := RuleResult{RuleName: "PII", ViolationCount: 4}
+	reported.Weigh(DefaultRuleCatalog, &weight, &category)
+	assert.Equal(t, 0.0, reported.Weight)
+	assert.Equal(t, "experimental", reported.Category)
+	assert.Equal(t, 0.0, reported.Contribution)
+}
+
+func TestIncomingReport_ToDomainEntity_Weights(t *testing.T) {
+	weight, category := 12.5, RuleCategoryCritical
+	incoming := validIncomingReport()
+	incoming.RuleResults[0].Weight = &weight
+	incoming.RuleResults[0].Category = &category
+
+	_, ruleResults, err := incoming.ToDomainEntity(DefaultRuleCatalog)
+	assert.NoError(t, err)
+	if assert.Len(t, ruleResults, 2) {
+		assert.Equal(t, 12.5, ruleResults[0].Weight)
+		assert.Equal(t, RuleCategoryCritical, ruleResults[0].Category)
+		assert.Equal(t, 12.5, ruleResults[0].Contribution)
+
+		// Older scorers do not send weights
+		assert.Equal(t, 5.0, ruleResults[1].Weight)
+		assert.Equal(t, RuleCategoryStyle, ruleResults[1].Category)
+		assert.Equal(t, 0.0, ruleResults[1].Contribution)
+	}
+}
+
+func TestSchemaReport_ScoreBreakdown(t *testing.T) {
+	// PII: 10 × 1^1.5 = 10, Null Blast Radius: 20 × 4^1.5 = 160
+	report := NewSchemaReport("1", stringPtr("user-service"), 66, 500, 170, time.Now(), nil)
+	report.AddRuleResult(weighed(RuleResult{RuleName: "PII", ViolationCount: 1}))
+	report.AddRuleResult(weighed(RuleResult{RuleName: "Boolean Prefix"}))
+	report.AddRuleResult(weighed(RuleResult{RuleName: "Null Blast Radius", ViolationCount: 4}))
+
+	breakdown := report.ScoreBreakdown()
+	assert.InDelta(t, 34, breakdown.Points, 0.0001)
+	assert.InDelta(t, 66, breakdown.Score, 0.0001)
+	if assert.Len(t, breakdown.Rules, 2) {
+		assert.Equal(t, "Null Blast Radius", breakdown.Rules[0].RuleName)
+		assert.Equal(t, RuleCategoryCritical, breakdown.Rules[0].Category)
+		assert.Equal(t, 160.0, breakdown.Rules[0].Contribution)
+		assert.InDelta(t, 32, breakdown.Rules[0].Points, 0.0001)
+		assert.InDelta(t, 100*32/34.0, breakdown.Rules[0].Share, 0.0001)
+
+		assert.Equal(t, "PII", breakdown.Rules[1].RuleName)
+		assert.InDelta(t, 2, breakdown.Rules[1].Points, 0.0001)
+	}
+
+	// A report without fields cannot lose points
+	empty := NewSchemaReport("2", stringPtr("user-service"), 100, 0, 0, time.Now(), nil)
+	empty.AddRuleResult(weighed(RuleResult{RuleName: "PII", ViolationCount: 1}))
+	assert.Equal(t, 0.0, empty.ScoreBreakdown().Points)
+	assert.False(t, math.IsNaN(empty.ScoreBreakdown().Rules[0].Share))
+}
+
+func TestSchemaReport_VerifyScore(t *testing.T) {
+	// PII: 10 × 1^1.5 = 10 of 200 fields, so 95
+	report := NewSchemaReport("1", stringPtr("user-service"), 95.05, 200, 10, time.Now(), nil)
+	report.AddRuleResult(weighed(RuleResult{RuleName: "PII", ViolationCount: 1}))
+
+	report.VerifyScore(DefaultRuleCatalog, DefaultScoreTolerance)
+	if assert.NotNil(t, report.RecomputedScore) {
+		assert.InDelta(t, 95, *report.RecomputedScore, 0.0001)
+	}
+	assert.False(t, report.ScoreMismatch)
+
+	report.Score = 100
+	report.VerifyScore(DefaultRuleCatalog, DefaultScoreTolerance)
+	assert.True(t, report.ScoreMismatch)
+
+	// A report that zeroes the weight of its rules is recomputed with the server's weights
+	// and flagged even though its claimed score adds up
+	tampered := NewSchemaReport("3", stringPtr("user-service"), 100, 200, 0, time.Now(), nil)
+	zero := 0.0
+	ruleResult := RuleResult{RuleName: "PII", ViolationCount: 1}
+	ruleResult.Weigh(DefaultRuleCatalog, &zero, nil)
+	tampered.AddRuleResult(ruleResult)
+
+	tampered.VerifyScore(DefaultRuleCatalog, DefaultScoreTolerance)
+	if assert.NotNil(t, tampered.RecomputedScore) {
+		assert.InDelta(t, 95, *tampered.RecomputedScore, 0.0001)
+	}
+	assert.True(t, tampered.ScoreMismatch)
+
+	tampered.Score = 95
+	tampered.VerifyScore(DefaultRuleCatalog, DefaultScoreTolerance)
+	assert.True(t, tampered.ScoreMismatch)
+
+	// A report without fields cannot be recomputed
+	empty := NewSchemaReport("2", stringPtr("user-service"), 100, 0, 0, time.Now(), nil)
+	empty.VerifyScore(DefaultRuleCatalog, DefaultScoreTolerance)
+	assert.Nil(t, empty.RecomputedScore)
+	assert.False(t, empty.ScoreMismatch)
+}
+
+// weighed weighs a rule result with DefaultRuleCatalog
+func weighed(ruleResult RuleResult) RuleResult {
+	ruleResult.Weigh(DefaultRuleCatalog, nil, nil)
+	return ruleResult
+}
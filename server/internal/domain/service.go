@@ -1,25 +1,77 @@
 package domain
 
 import (
+	"context"
 	"fmt"
-	"github.com/google/uuid"
+	"log"
 	"time"
 )
 
+// followUpTimeout bounds the work done for a report after it was stored, such as queueing
+// its webhook notifications
+const followUpTimeout = 30 * time.Second
+
 // SchemaReportService contains the business logic for schema reports
 type SchemaReportService struct {
-	repo SchemaReportRepository
+	repo         SchemaReportRepository
+	suppressions SuppressionRepository
+	webhooks     *WebhookService
+	rules        *RuleCatalogService
+	validator    *ReportValidator
+}
+
+// ServiceOption configures optional dependencies of the SchemaReportService
+type ServiceOption func(*SchemaReportService)
+
+// WithSuppressions makes the service apply active suppressions to stored reports
+func WithSuppressions(suppressions SuppressionRepository) ServiceOption {
+	return func(s *SchemaReportService) {
+		s.suppressions = suppressions
+	}
+}
+
+// WithWebhooks makes the service queue webhook notifications for stored reports
+func WithWebhooks(webhooks *WebhookService) ServiceOption {
+	return func(s *SchemaReportService) {
+		s.webhooks = webhooks
+	}
+}
+
+// WithRuleCatalog makes the service register the rules of stored reports that are not
+// in the catalog yet, so that they can be reviewed
+func WithRuleCatalog(rules *RuleCatalogService) ServiceOption {
+	return func(s *SchemaReportService) {
+		s.rules = rules
+	}
+}
+
+// WithReportValidator sets the validator that incoming reports are checked with
+func WithReportValidator(validator *ReportValidator) ServiceOption {
+	return func(s *SchemaReportService) {
+		s.validator = validator
+	}
 }
 
 // NewSchemaReportService creates a new schema report service
-func NewSchemaReportService(repo SchemaReportRepository) *SchemaReportService {
-	return &SchemaReportService{
-		repo: repo,
+func NewSchemaReportService(repo SchemaReportRepository, opts ...ServiceOption) *SchemaReportService {
+	service := &SchemaReportService{
+		repo:      repo,
+		validator: NewReportValidator(),
 	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
+
+// ReportValidator returns the validator that incoming reports are checked with
+func (s *SchemaReportService) ReportValidator() *ReportValidator {
+	return s.validator
 }
 
 // StoreReport processes and stores a new schema report
 func (s *SchemaReportService) StoreReport(
+	ctx context.Context,
 	subgraphName *string,
 	score float64,
 	totalFields int,
@@ -29,8 +81,117 @@ func (s *SchemaReportService) StoreReport(
 	ruleResults []RuleResult,
 ) (*SchemaReport, error) {
 
-	id := uuid.NewString()
+	report, err := s.PrepareReport(ctx, subgraphName, score, totalFields, totalWeightedViolations,
+		timestamp, metadata, ruleResults)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.StorePreparedReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ReportSubmission is a received report whose rule results have not been added yet
+type ReportSubmission struct {
+	Report      *SchemaReport
+	RuleResults []RuleResult
+	// APITokenID is the token that submitted the report, if any
+	APITokenID *string
+}
+
+// StoreSubmission prepares and stores a received report under the ID it was given when
+// it was received
+func (s *SchemaReportService) StoreSubmission(ctx context.Context, submission ReportSubmission) (*SchemaReport, error) {
+	id := submission.Report.ID
+	if id == "" {
+		id = NewID()
+	}
+
+	report, err := s.prepareReport(
+		ctx,
+		id,
+		&submission.Report.SubgraphName,
+		submission.Report.Score,
+		submission.Report.TotalFields,
+		submission.Report.TotalWeightedViolations,
+		submission.Report.Timestamp,
+		submission.Report.Metadata,
+		submission.RuleResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report.APITokenID = submission.APITokenID
+
+	if err := s.StorePreparedReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// StorePreparedReport stores a report built by PrepareReport, which callers may annotate
+// first, for example with the API token that submitted it
+func (s *SchemaReportService) StorePreparedReport(ctx context.Context, report *SchemaReport) error {
+	if err := s.repo.Store(ctx, report); err != nil {
+		return fmt.Errorf("failed to store schema report: %w", err)
+	}
+
+	// The report is stored at this point, so the follow-up work must neither fail the request
+	// nor be cut short when the client disconnects or the request deadline passes. It is not
+	// retried, webhook events that cannot be queued now are lost.
+	followUpCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), followUpTimeout)
+	defer cancel()
+
+	if s.webhooks != nil {
+		if err := s.webhooks.NotifyReportStored(followUpCtx, report); err != nil {
+			log.Printf("ERROR: lost webhook notifications of report %s for subgraph %s: %v",
+				report.ID, report.SubgraphName, err)
+		}
+	}
+
+	if s.rules != nil {
+		registered, err := s.rules.RegisterUnknownRules(followUpCtx, report.RuleResults)
+		if err != nil {
+			log.Printf("Error registering the rules of report %s: %v", report.ID, err)
+		} else if len(registered) > 0 {
+			log.Printf("Registered unknown rules for review: %v", registered)
+		}
+	}
+
+	return nil
+}
+
+// PrepareReport builds a schema report exactly as StoreReport would, without storing it
+func (s *SchemaReportService) PrepareReport(
+	ctx context.Context,
+	subgraphName *string,
+	score float64,
+	totalFields int,
+	totalWeightedViolations float64,
+	timestamp time.Time,
+	metadata map[string]interface{},
+	ruleResults []RuleResult,
+) (*SchemaReport, error) {
+	return s.prepareReport(ctx, NewID(), subgraphName, score, totalFields, totalWeightedViolations,
+		timestamp, metadata, ruleResults)
+}
 
+func (s *SchemaReportService) prepareReport(
+	ctx context.Context,
+	id string,
+	subgraphName *string,
+	score float64,
+	totalFields int,
+	totalWeightedViolations float64,
+	timestamp time.Time,
+	metadata map[string]interface{},
+	ruleResults []RuleResult,
+) (*SchemaReport, error) {
 	// Create the report entity
 	report := NewSchemaReport(
 		id,
@@ -42,44 +203,83 @@ func (s *SchemaReportService) StoreReport(
 		metadata,
 	)
 
-	// Add rule results
-	for _, ruleResult := range ruleResults {
+	definitions, err := s.validator.RuleDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add rule results, weighing the ones that were never weighed with the rule catalog
+	for i, ruleResult := range ruleResults {
+		if ruleResult.Category == "" {
+			ruleResult.Weigh(definitions, nil, nil)
+			if reason := invalidContribution(ruleResult); reason != "" {
+				return nil, NewValidationError(ErrInvalidReport,
+					FieldError{Path: fmt.Sprintf("ruleResults[%d].violations", i), Reason: reason})
+			}
+		}
 		report.AddRuleResult(ruleResult)
 	}
+	report.AssignIDs()
 
-	// Store the report
-	if err := s.repo.Store(report); err != nil {
-		return nil, fmt.Errorf("failed to store schema report: %w", err)
+	// Recompute the score with the server's weights rather than trusting the scorer
+	report.VerifyScore(definitions, s.validator.ScoreTolerance())
+
+	// Fingerprint violations so they can be tracked across reports
+	report.AssignFingerprints()
+
+	// Mark accepted violations and compute the effective score
+	if s.suppressions != nil {
+		suppressions, err := s.suppressions.GetActive(ctx, report.SubgraphName, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active suppressions: %w", err)
+		}
+		report.ApplySuppressions(suppressions)
 	}
 
 	return report, nil
 }
 
 // GetReportByID retrieves a specific report with all details
-func (s *SchemaReportService) GetReportByID(id string) (*SchemaReport, error) {
-	report, err := s.repo.GetByID(id)
+func (s *SchemaReportService) GetReportByID(ctx context.Context, id string) (*SchemaReport, error) {
+	report, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get report by ID: %w", err)
 	}
+	report.ComputeViolationAges()
 	return report, nil
 }
 
+// CompareReports retrieves two reports and computes the violation diff between them
+func (s *SchemaReportService) CompareReports(ctx context.Context, baseID, headID string) (*ReportDiff, error) {
+	base, err := s.repo.GetByID(ctx, baseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base report: %w", err)
+	}
+
+	head, err := s.repo.GetByID(ctx, headID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head report: %w", err)
+	}
+
+	return DiffReports(base, head), nil
+}
+
 // GetDashboardData retrieves all data needed for the dashboard
-func (s *SchemaReportService) GetDashboardData() (*DashboardData, error) {
+func (s *SchemaReportService) GetDashboardData(ctx context.Context) (*DashboardData, error) {
 	// Get subgraph summaries
-	subgraphs, err := s.repo.GetSubgraphSummaries()
+	subgraphs, err := s.repo.GetSubgraphSummaries(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subgraph summaries: %w", err)
 	}
 
 	// Get recent reports
-	recentReports, err := s.repo.GetRecentReports(10)
+	recentReports, err := s.repo.GetRecentReports(ctx, 10)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent reports: %w", err)
 	}
 
 	// Get total report count
-	totalReports, err := s.repo.GetTotalReportCount()
+	totalReports, err := s.repo.GetTotalReportCount(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total report count: %w", err)
 	}
@@ -92,17 +292,35 @@ func (s *SchemaReportService) GetDashboardData() (*DashboardData, error) {
 }
 
 // GetSubgraphHistory retrieves the history for a specific subgraph
-func (s *SchemaReportService) GetSubgraphHistory(subgraphName string, limit int) ([]SchemaReport, error) {
-	reports, err := s.repo.GetReportsBySubgraph(subgraphName, limit)
+func (s *SchemaReportService) GetSubgraphHistory(ctx context.Context, subgraphName string, limit int) ([]SchemaReport, error) {
+	reports, err := s.repo.GetReportsBySubgraph(ctx, subgraphName, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subgraph history: %w", err)
 	}
 	return reports, nil
 }
 
+// GetLatestReports retrieves the most recent report of every subgraph
+func (s *SchemaReportService) GetLatestReports(ctx context.Context) ([]SchemaReport, error) {
+	reports, err := s.repo.GetLatestReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest reports: %w", err)
+	}
+	return reports, nil
+}
+
+// GetOpenViolations retrieves the violations of a subgraph that have not been resolved yet
+func (s *SchemaReportService) GetOpenViolations(ctx context.Context, subgraphName string) ([]TrackedViolation, error) {
+	violations, err := s.repo.GetOpenViolations(ctx, subgraphName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open violations: %w", err)
+	}
+	return violations, nil
+}
+
 // HealthCheck verifies the service is working
-func (s *SchemaReportService) HealthCheck() error {
-	return s.repo.HealthCheck()
+func (s *SchemaReportService) HealthCheck(ctx context.Context) error {
+	return s.repo.HealthCheck(ctx)
 }
 
 // DashboardData contains all data needed for the dashboard view
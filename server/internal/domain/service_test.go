@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -50,7 +51,9 @@ func TestSchemaReportService_StoreReport(t *testing.T) {
 					RuleName:       "TestRule",
 					ViolationCount: 1,
 					Message:        "Test violation",
-					Violations:     []Violation{},
+					Violations: []Violation{
+						{Message: "Field 'email' contains PII", LocationCoordinate: stringPtr("User.email")},
+					},
 				},
 			},
 			shouldFail: false,
@@ -79,6 +82,7 @@ func TestSchemaReportService_StoreReport(t *testing.T) {
 			metadata := map[string]interface{}{"version": "1.0.0"}
 
 			result, err := service.StoreReport(
+				context.Background(),
 				tt.subgraphName,
 				tt.score,
 				tt.totalFields,
@@ -103,11 +107,102 @@ func TestSchemaReportService_StoreReport(t *testing.T) {
 				assert.Equal(t, tt.score, stored.Score)
 				assert.Equal(t, tt.totalFields, stored.TotalFields)
 				assert.Equal(t, len(tt.ruleResults), len(stored.RuleResults))
+				for _, ruleResult := range stored.RuleResults {
+					for _, violation := range ruleResult.Violations {
+						assert.NotEmpty(t, violation.Fingerprint)
+					}
+				}
 			}
 		})
 	}
 }
 
+func TestSchemaReportService_StoreReport_WithSuppressions(t *testing.T) {
+	suppressions := NewMockSuppressionRepository().
+		WithSuppression(Suppression{
+			SubgraphName:      "user-service",
+			RuleName:          "Boolean Prefix",
+			CoordinatePattern: "User.*",
+			Reason:            "Legacy",
+			Author:            "jane",
+		})
+
+	ruleResults := []RuleResult{
+		{
+			RuleName:       "Boolean Prefix",
+			ViolationCount: 1,
+			Violations: []Violation{
+				{Message: "Field 'isActive' is prefixed", LocationCoordinate: stringPtr("User.isActive")},
+			},
+		},
+	}
+
+	t.Run("suppressed violations raise the effective score", func(t *testing.T) {
+		repo := NewMockSchemaReportRepository()
+		service := NewSchemaReportService(repo, WithSuppressions(suppressions))
+
+		result, err := service.StoreReport(context.Background(), stringPtr("user-service"), 90.0, 50, 5.0,
+			time.Now(), nil, ruleResults)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.SuppressedCount)
+		assert.True(t, repo.LastStore.RuleResults[0].Violations[0].Suppressed)
+		assert.InDelta(t, 100.0, result.EffectiveScore, 0.0001)
+	})
+
+	t.Run("suppression lookup failure", func(t *testing.T) {
+		suppressions.ShouldFailGetActive = true
+		defer func() { suppressions.ShouldFailGetActive = false }()
+
+		service := NewSchemaReportService(NewMockSchemaReportRepository(), WithSuppressions(suppressions))
+
+		result, err := service.StoreReport(context.Background(), stringPtr("user-service"), 90.0, 50, 5.0,
+			time.Now(), nil, ruleResults)
+
+		assert.ErrorContains(t, err, "failed to get active suppressions")
+		assert.Nil(t, result)
+	})
+}
+
+// cancelAfterStore cancels the request context once a report is stored, like a client
+// that disconnects at that moment
+type cancelAfterStore struct {
+	*MockSchemaReportRepository
+	cancel context.CancelFunc
+}
+
+func (r cancelAfterStore) Store(ctx context.Context, report *SchemaReport) error {
+	defer r.cancel()
+	return r.MockSchemaReportRepository.Store(ctx, report)
+}
+
+func TestSchemaReportService_StoreReport_WithWebhooks(t *testing.T) {
+	webhooks := NewMockWebhookRepository().
+		WithWebhook(Webhook{URL: "https://hooks.example.com", Secret: "s3cret", MinScore: float64Ptr(80)})
+	repo := NewMockSchemaReportRepository()
+	webhookService := NewWebhookService(webhooks, repo)
+	service := NewSchemaReportService(repo, WithWebhooks(webhookService))
+
+	result, err := service.StoreReport(context.Background(), stringPtr("user-service"), 60.0, 50, 20.0, time.Now(), nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks.Deliveries, 1)
+
+	// A report is notified even when its request is cancelled right after storing it
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelling := NewSchemaReportService(cancelAfterStore{repo, cancel}, WithWebhooks(webhookService))
+	_, err = cancelling.StoreReport(ctx, stringPtr("order-service"), 60.0, 50, 20.0, time.Now(), nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks.Deliveries, 2)
+	assert.Equal(t, int64(0), webhookService.EnqueueFailures())
+
+	// A failing outbox does not fail storing the report, but is counted
+	webhooks.ShouldFailEnqueue = true
+	result, err = service.StoreReport(context.Background(), stringPtr("billing-service"), 60.0, 50, 20.0, time.Now(), nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(1), webhookService.EnqueueFailures())
+}
+
 func TestSchemaReportService_GetReportByID(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -153,7 +248,7 @@ func TestSchemaReportService_GetReportByID(t *testing.T) {
 			}
 
 			service := NewSchemaReportService(repo)
-			result, err := service.GetReportByID(tt.reportID)
+			result, err := service.GetReportByID(context.Background(), tt.reportID)
 
 			if tt.shouldFail {
 				assert.Error(t, err)
@@ -168,6 +263,55 @@ func TestSchemaReportService_GetReportByID(t *testing.T) {
 	}
 }
 
+func TestSchemaReportService_CompareReports(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseID        string
+		headID        string
+		expectedError string
+	}{
+		{
+			name:   "successful comparison",
+			baseID: "1",
+			headID: "2",
+		},
+		{
+			name:          "base report not found",
+			baseID:        "999",
+			headID:        "2",
+			expectedError: "failed to get base report",
+		},
+		{
+			name:          "head report not found",
+			baseID:        "1",
+			headID:        "999",
+			expectedError: "failed to get head report",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSchemaReportRepository()
+			repo.Reports["1"] = &SchemaReport{ID: "1", Score: 80.0, TotalFields: 20}
+			repo.Reports["2"] = &SchemaReport{ID: "2", Score: 90.0, TotalFields: 25}
+
+			service := NewSchemaReportService(repo)
+			result, err := service.CompareReports(context.Background(), tt.baseID, tt.headID)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, 10.0, result.ScoreDelta)
+				assert.Equal(t, 5, result.TotalFieldsDelta)
+			}
+		})
+	}
+}
+
 func TestSchemaReportService_GetDashboardData(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -186,7 +330,7 @@ func TestSchemaReportService_GetDashboardData(t *testing.T) {
 				{Name: "order-service", LatestScore: 92.0, ReportCount: 3},
 			},
 			recentReports: []SchemaReport{
-				{ID: "1", Score: 85.0, TotalFields: 20},
+				{ID: "1", SubgraphName: "user-service", Score: 85.0, TotalFields: 20},
 				{ID: "2", Score: 92.0, TotalFields: 15},
 			},
 			totalReportCount: 10,
@@ -226,7 +370,7 @@ func TestSchemaReportService_GetDashboardData(t *testing.T) {
 			}
 
 			service := NewSchemaReportService(repo)
-			result, err := service.GetDashboardData()
+			result, err := service.GetDashboardData(context.Background())
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -257,8 +401,8 @@ func TestSchemaReportService_GetSubgraphHistory(t *testing.T) {
 			subgraphName: "user-service",
 			limit:        10,
 			reports: []SchemaReport{
-				{ID: "1", Score: 85.0, TotalFields: 20},
-				{ID: "2", Score: 87.0, TotalFields: 22},
+				{ID: "1", SubgraphName: "user-service", Score: 85.0, TotalFields: 20},
+				{ID: "2", SubgraphName: "user-service", Score: 87.0, TotalFields: 22},
 			},
 		},
 		{
@@ -286,7 +430,7 @@ func TestSchemaReportService_GetSubgraphHistory(t *testing.T) {
 			}
 
 			service := NewSchemaReportService(repo)
-			result, err := service.GetSubgraphHistory(tt.subgraphName, tt.limit)
+			result, err := service.GetSubgraphHistory(context.Background(), tt.subgraphName, tt.limit)
 
 			if tt.shouldFail {
 				assert.Error(t, err)
@@ -301,6 +445,47 @@ func TestSchemaReportService_GetSubgraphHistory(t *testing.T) {
 	}
 }
 
+func TestSchemaReportService_GetOpenViolations(t *testing.T) {
+	tests := []struct {
+		name          string
+		violations    []TrackedViolation
+		shouldFail    bool
+		expectedError string
+	}{
+		{
+			name: "successful retrieval",
+			violations: []TrackedViolation{
+				{Fingerprint: "abc", RuleName: "PII", SubgraphName: "user-service"},
+			},
+		},
+		{
+			name:          "repository failure",
+			shouldFail:    true,
+			expectedError: "failed to get open violations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSchemaReportRepository().
+				WithOpenViolations(tt.violations)
+			repo.ShouldFailGetOpenViolations = tt.shouldFail
+
+			service := NewSchemaReportService(repo)
+			result, err := service.GetOpenViolations(context.Background(), "user-service")
+
+			if tt.shouldFail {
+				assert.Error(t, err)
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, len(tt.violations), len(result))
+			}
+		})
+	}
+}
+
 func TestSchemaReportService_HealthCheck(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -324,7 +509,7 @@ func TestSchemaReportService_HealthCheck(t *testing.T) {
 			}
 
 			service := NewSchemaReportService(repo)
-			err := service.HealthCheck()
+			err := service.HealthCheck(context.Background())
 
 			if tt.shouldFail {
 				if err == nil {
@@ -339,7 +524,95 @@ func TestSchemaReportService_HealthCheck(t *testing.T) {
 	}
 }
 
+func TestSchemaReportService_CancelledContext(t *testing.T) {
+	repo := NewMockSchemaReportRepository()
+	repo.Reports["1"] = &SchemaReport{ID: "1", SubgraphName: "user-service"}
+	service := NewSchemaReportService(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := service.StoreReport(ctx, stringPtr("user-service"), 85.5, 42, 10.2, time.Now(), nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, report)
+	assert.Len(t, repo.Reports, 1, "nothing is stored once the context is cancelled")
+
+	_, err = service.GetReportByID(ctx, "1")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = service.GetDashboardData(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.ErrorIs(t, service.HealthCheck(ctx), context.Canceled)
+}
+
+func TestSchemaReportService_ContextDeadline(t *testing.T) {
+	service := NewSchemaReportService(NewMockSchemaReportRepository())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err := service.GetSubgraphHistory(ctx, "user-service", 10)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 // Helper function to create string pointers
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestSchemaReportService_StoreReport_WithRuleCatalog(t *testing.T) {
+	rules := NewMockRuleRepository().WithRule(Rule{Name: "PII", Weight: 10, Category: RuleCategoryImportant})
+	service := NewSchemaReportService(NewMockSchemaReportRepository(), WithRuleCatalog(NewRuleCatalogService(rules)))
+
+	ruleResults := []RuleResult{{RuleName: "PII"}, {RuleName: "field-descriptions"}}
+	_, err := service.StoreReport(context.Background(), stringPtr("user-service"), 100.0, 50, 0, time.Now(), nil, ruleResults)
+	assert.NoError(t, err)
+	if assert.Contains(t, rules.Rules, "field-descriptions") {
+		assert.True(t, rules.Rules["field-descriptions"].NeedsReview)
+		assert.Equal(t, DefaultRuleWeight, rules.Rules["field-descriptions"].Weight)
+	}
+
+	// A failing catalog does not fail storing the report
+	rules.ShouldFailRegister = true
+	result, err := service.StoreReport(context.Background(), stringPtr("user-service"), 100.0, 50, 0, time.Now(), nil,
+		[]RuleResult{{RuleName: "operation-names"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestSchemaReportService_StoreReport_WeighsWithRuleCatalog(t *testing.T) {
+	rules := NewMockRuleRepository().
+		WithRule(Rule{Name: "PII", Weight: 4, Category: RuleCategoryStyle}).
+		WithRule(Rule{Name: "field-descriptions", Weight: 1, Category: RuleCategoryUncategorized, NeedsReview: true})
+	catalog := NewRuleCatalogService(rules)
+	service := NewSchemaReportService(NewMockSchemaReportRepository(),
+		WithRuleCatalog(catalog), WithReportValidator(NewReportValidator(WithRuleDefinitions(catalog))))
+
+	// PII: 4 × 1^1.5 = 4 of 100 fields, so 96
+	report, err := service.StoreReport(context.Background(), stringPtr("user-service"), 96, 100, 4, time.Now(), nil,
+		[]RuleResult{{RuleName: "PII", ViolationCount: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, report.RuleResults[0].Weight)
+	assert.Equal(t, RuleCategoryStyle, report.RuleResults[0].Category)
+	assert.False(t, report.ScoreMismatch)
+
+	// Rules awaiting review are verified with DefaultRuleWeight, whatever weight they claim
+	ruleResult := RuleResult{RuleName: "field-descriptions", ViolationCount: 1}
+	ruleResult.Weigh(RuleDefinitions{}, float64Ptr(1), nil)
+	report, err = service.StoreReport(context.Background(), stringPtr("user-service"), 99, 100, 1, time.Now(), nil,
+		[]RuleResult{ruleResult})
+	assert.NoError(t, err)
+	if assert.NotNil(t, report.RecomputedScore) {
+		assert.InDelta(t, 90, *report.RecomputedScore, 0.0001)
+	}
+	assert.True(t, report.ScoreMismatch)
+
+	// Catalog weights whose weighted violations do not fit the contribution column reject the report
+	rules.WithRule(Rule{Name: "Null Blast Radius", Weight: 1e7, Category: RuleCategoryCritical})
+	_, err = service.StoreReport(context.Background(), stringPtr("user-service"), 0, 100, 0, time.Now(), nil,
+		[]RuleResult{{RuleName: "Null Blast Radius", ViolationCount: 5}})
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	assert.ErrorContains(t, err, "ruleResults[0].violations must be few enough")
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Suppression accepts violations of a rule in a subgraph so that they no longer
+// count towards the effective score
+type Suppression struct {
+	ID                string
+	SubgraphName      string
+	RuleName          string
+	CoordinatePattern string
+	Reason            string
+	Author            string
+	ExpiresAt         *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// IsActive reports whether the suppression applies at the given time
+func (s Suppression) IsActive(at time.Time) bool {
+	return s.ExpiresAt == nil || at.Before(*s.ExpiresAt)
+}
+
+// Matches reports whether the suppression covers a violation of the given rule
+// in the given subgraph. An empty pattern or "*" matches every violation, other
+// patterns are matched against the location coordinate using path.Match syntax.
+func (s Suppression) Matches(subgraphName, ruleName string, violation Violation) bool {
+	if s.SubgraphName != subgraphName || s.RuleName != ruleName {
+		return false
+	}
+
+	if s.CoordinatePattern == "" || s.CoordinatePattern == "*" {
+		return true
+	}
+
+	if violation.LocationCoordinate == nil {
+		return false
+	}
+
+	matched, err := path.Match(s.CoordinatePattern, *violation.LocationCoordinate)
+	return err == nil && matched
+}
+
+// Validate checks that the suppression is complete and its pattern is well formed
+func (s Suppression) Validate() error {
+	var problems []string
+	if strings.TrimSpace(s.SubgraphName) == "" {
+		problems = append(problems, "subgraph name is required")
+	}
+	if strings.TrimSpace(s.RuleName) == "" {
+		problems = append(problems, "rule name is required")
+	}
+	if strings.TrimSpace(s.Reason) == "" {
+		problems = append(problems, "reason is required")
+	}
+	if strings.TrimSpace(s.Author) == "" {
+		problems = append(problems, "author is required")
+	}
+	if _, err := path.Match(s.CoordinatePattern, ""); err != nil {
+		problems = append(problems, "coordinate pattern is malformed")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSuppression, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// ApplySuppressions marks the violations covered by the given suppressions and
+// computes the effective score, which leaves the suppressed violations out
+func (sr *SchemaReport) ApplySuppressions(suppressions []Suppression) {
+	var removedWeightedViolations float64
+	sr.SuppressedCount = 0
+
+	for i := range sr.RuleResults {
+		ruleResult := &sr.RuleResults[i]
+		ruleResult.SuppressedCount = 0
+
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			violation.Suppressed = false
+			violation.SuppressionID = nil
+
+			for _, suppression := range suppressions {
+				if suppression.Matches(sr.SubgraphName, ruleResult.RuleName, *violation) {
+					suppressionID := suppression.ID
+					violation.Suppressed = true
+					violation.SuppressionID = &suppressionID
+					ruleResult.SuppressedCount++
+					break
+				}
+			}
+		}
+
+		if ruleResult.SuppressedCount > 0 {
+			count := len(ruleResult.Violations)
+			removedWeightedViolations += WeightedViolations(ruleResult.Weight, count) -
+				WeightedViolations(ruleResult.Weight, count-ruleResult.SuppressedCount)
+			sr.SuppressedCount += ruleResult.SuppressedCount
+		}
+	}
+
+	sr.EffectiveScore = sr.Score
+	if removedWeightedViolations > 0 && sr.TotalFields > 0 {
+		sr.EffectiveScore = sr.Score + 100*removedWeightedViolations/float64(sr.TotalFields)
+		if sr.EffectiveScore > 100 {
+			sr.EffectiveScore = 100
+		}
+	}
+}
+
+// IncomingSuppression represents the JSON structure used to create or update a suppression
+type IncomingSuppression struct {
+	SubgraphName      string  `json:"subgraphName"`
+	RuleName          string  `json:"rule"`
+	CoordinatePattern string  `json:"coordinate"`
+	Reason            string  `json:"reason"`
+	Author            string  `json:"author"`
+	ExpiresAt         *string `json:"expiresAt"`
+}
+
+// ToDomainEntity converts the incoming DTO to a suppression
+func (is *IncomingSuppression) ToDomainEntity() (*Suppression, error) {
+	suppression := &Suppression{
+		SubgraphName:      is.SubgraphName,
+		RuleName:          is.RuleName,
+		CoordinatePattern: is.CoordinatePattern,
+		Reason:            is.Reason,
+		Author:            is.Author,
+	}
+
+	if is.ExpiresAt != nil && *is.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, *is.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expiresAt must be an RFC3339 timestamp", ErrInvalidSuppression)
+		}
+		suppression.ExpiresAt = &expiresAt
+	}
+
+	return suppression, nil
+}
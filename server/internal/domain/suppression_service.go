@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SuppressionService contains the business logic for suppressions
+type SuppressionService struct {
+	repo SuppressionRepository
+}
+
+// NewSuppressionService creates a new suppression service
+func NewSuppressionService(repo SuppressionRepository) *SuppressionService {
+	return &SuppressionService{
+		repo: repo,
+	}
+}
+
+// CreateSuppression validates and stores a new suppression
+func (s *SuppressionService) CreateSuppression(ctx context.Context, suppression *Suppression) (*Suppression, error) {
+	if err := suppression.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	suppression.CreatedAt = now
+	suppression.UpdatedAt = now
+
+	if err := s.repo.Store(ctx, suppression); err != nil {
+		return nil, fmt.Errorf("failed to store suppression: %w", err)
+	}
+
+	return suppression, nil
+}
+
+// GetSuppression retrieves a suppression by its ID
+func (s *SuppressionService) GetSuppression(ctx context.Context, id string) (*Suppression, error) {
+	suppression, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppression: %w", err)
+	}
+	return suppression, nil
+}
+
+// ListSuppressions retrieves all suppressions, optionally limited to one subgraph
+func (s *SuppressionService) ListSuppressions(ctx context.Context, subgraphName string) ([]Suppression, error) {
+	suppressions, err := s.repo.List(ctx, subgraphName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppressions: %w", err)
+	}
+	return suppressions, nil
+}
+
+// UpdateSuppression replaces the fields of an existing suppression
+func (s *SuppressionService) UpdateSuppression(ctx context.Context, id string, update *Suppression) (*Suppression, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppression: %w", err)
+	}
+
+	existing.SubgraphName = update.SubgraphName
+	existing.RuleName = update.RuleName
+	existing.CoordinatePattern = update.CoordinatePattern
+	existing.Reason = update.Reason
+	existing.Author = update.Author
+	existing.ExpiresAt = update.ExpiresAt
+	existing.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update suppression: %w", err)
+	}
+
+	return existing, nil
+}
+
+// DeleteSuppression removes a suppression
+func (s *SuppressionService) DeleteSuppression(ctx context.Context, id string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete suppression: %w", err)
+	}
+	return nil
+}
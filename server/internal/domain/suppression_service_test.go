@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestSuppression() *Suppression {
+	return &Suppression{
+		SubgraphName:      "user-service",
+		RuleName:          "Boolean Prefix",
+		CoordinatePattern: "User.*",
+		Reason:            "Legacy fields that cannot be renamed",
+		Author:            "jane",
+	}
+}
+
+func TestSuppressionService_CreateSuppression(t *testing.T) {
+	tests := []struct {
+		name          string
+		suppression   *Suppression
+		shouldFail    bool
+		expectedError string
+	}{
+		{
+			name:        "successful create",
+			suppression: newTestSuppression(),
+		},
+		{
+			name:          "invalid suppression",
+			suppression:   &Suppression{SubgraphName: "user-service"},
+			expectedError: "invalid suppression",
+		},
+		{
+			name:          "repository failure",
+			suppression:   newTestSuppression(),
+			shouldFail:    true,
+			expectedError: "failed to store suppression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockSuppressionRepository()
+			repo.ShouldFailStore = tt.shouldFail
+			service := NewSuppressionService(repo)
+
+			result, err := service.CreateSuppression(context.Background(), tt.suppression)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, result.ID)
+				assert.False(t, result.CreatedAt.IsZero())
+				assert.Len(t, repo.Suppressions, 1)
+			}
+		})
+	}
+}
+
+func TestSuppressionService_UpdateSuppression(t *testing.T) {
+	repo := NewMockSuppressionRepository().WithSuppression(*newTestSuppression())
+	service := NewSuppressionService(repo)
+
+	update := newTestSuppression()
+	update.Reason = "Renaming scheduled for next quarter"
+
+	result, err := service.UpdateSuppression(context.Background(), "1", update)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result.ID)
+	assert.Equal(t, "Renaming scheduled for next quarter", repo.Suppressions["1"].Reason)
+
+	_, err = service.UpdateSuppression(context.Background(), "999", update)
+	assert.ErrorIs(t, err, ErrSuppressionNotFound)
+}
+
+func TestSuppressionService_DeleteSuppression(t *testing.T) {
+	repo := NewMockSuppressionRepository().WithSuppression(*newTestSuppression())
+	service := NewSuppressionService(repo)
+
+	assert.NoError(t, service.DeleteSuppression(context.Background(), "1"))
+	assert.Empty(t, repo.Suppressions)
+
+	err := service.DeleteSuppression(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrSuppressionNotFound)
+}
+
+func TestSuppressionService_ListSuppressions(t *testing.T) {
+	other := newTestSuppression()
+	other.SubgraphName = "order-service"
+
+	repo := NewMockSuppressionRepository().
+		WithSuppression(*newTestSuppression()).
+		WithSuppression(*other)
+	service := NewSuppressionService(repo)
+
+	all, err := service.ListSuppressions(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	filtered, err := service.ListSuppressions(context.Background(), "order-service")
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSuppression_Matches(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		subgraph   string
+		rule       string
+		coordinate *string
+		expected   bool
+	}{
+		{
+			name:       "exact coordinate",
+			pattern:    "User.active",
+			subgraph:   "user-service",
+			rule:       "Boolean Prefix",
+			coordinate: stringPtr("User.active"),
+			expected:   true,
+		},
+		{
+			name:       "glob on type",
+			pattern:    "User.*",
+			subgraph:   "user-service",
+			rule:       "Boolean Prefix",
+			coordinate: stringPtr("User.enabled"),
+			expected:   true,
+		},
+		{
+			name:       "empty pattern matches everything",
+			pattern:    "",
+			subgraph:   "user-service",
+			rule:       "Boolean Prefix",
+			coordinate: nil,
+			expected:   true,
+		},
+		{
+			name:       "glob does not match other type",
+			pattern:    "User.*",
+			subgraph:   "user-service",
+			rule:       "Boolean Prefix",
+			coordinate: stringPtr("Order.enabled"),
+			expected:   false,
+		},
+		{
+			name:       "pattern does not match missing coordinate",
+			pattern:    "User.*",
+			subgraph:   "user-service",
+			rule:       "Boolean Prefix",
+			coordinate: nil,
+			expected:   false,
+		},
+		{
+			name:       "other subgraph",
+			pattern:    "*",
+			subgraph:   "order-service",
+			rule:       "Boolean Prefix",
+			coordinate: stringPtr("User.active"),
+			expected:   false,
+		},
+		{
+			name:       "other rule",
+			pattern:    "*",
+			subgraph:   "user-service",
+			rule:       "PII",
+			coordinate: stringPtr("User.active"),
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suppression := Suppression{
+				SubgraphName:      "user-service",
+				RuleName:          "Boolean Prefix",
+				CoordinatePattern: tt.pattern,
+			}
+			violation := Violation{Message: "violation", LocationCoordinate: tt.coordinate}
+
+			assert.Equal(t, tt.expected, suppression.Matches(tt.subgraph, tt.rule, violation))
+		})
+	}
+}
+
+func TestSuppression_IsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, Suppression{}.IsActive(now))
+	assert.True(t, Suppression{ExpiresAt: &future}.IsActive(now))
+	assert.False(t, Suppression{ExpiresAt: &past}.IsActive(now))
+}
+
+func TestSuppression_Validate(t *testing.T) {
+	valid := Suppression{
+		SubgraphName:      "user-service",
+		RuleName:          "Boolean Prefix",
+		CoordinatePattern: "User.*",
+		Reason:            "Legacy field that cannot be renamed",
+		Author:            "jane",
+	}
+	assert.NoError(t, valid.Validate())
+
+	missing := Suppression{CoordinatePattern: "["}
+	err := missing.Validate()
+	assert.ErrorIs(t, err, ErrInvalidSuppression)
+	assert.ErrorContains(t, err, "subgraph name is required")
+	assert.ErrorContains(t, err, "reason is required")
+	assert.ErrorContains(t, err, "coordinate pattern is malformed")
+}
+
+func TestSchemaReport_ApplySuppressions(t *testing.T) {
+	// Two Boolean Prefix violations (weight 5): 5 × 2^1.5 weighted violations
+	totalWeighted := 5 * math.Pow(2, 1.5)
+	score := 100 * (1 - totalWeighted/50)
+
+	report := NewSchemaReport("1", stringPtr("user-service"), score, 50, totalWeighted, time.Now(), nil)
+	report.AddRuleResult(weighed(RuleResult{
+		RuleName:       "Boolean Prefix",
+		ViolationCount: 2,
+		Violations: []Violation{
+			{Message: "Field 'isActive' is prefixed", LocationCoordinate: stringPtr("User.isActive")},
+			{Message: "Field 'isEnabled' is prefixed", LocationCoordinate: stringPtr("Order.isEnabled")},
+		},
+	}))
+
+	report.ApplySuppressions([]Suppression{
+		{ID: "7", SubgraphName: "user-service", RuleName: "Boolean Prefix", CoordinatePattern: "User.*"},
+	})
+
+	ruleResult := report.RuleResults[0]
+	assert.True(t, ruleResult.Violations[0].Suppressed)
+	assert.Equal(t, "7", *ruleResult.Violations[0].SuppressionID)
+	assert.False(t, ruleResult.Violations[1].Suppressed)
+	assert.Equal(t, 1, ruleResult.SuppressedCount)
+	assert.Equal(t, 1, report.SuppressedCount)
+	assert.Len(t, ruleResult.ActiveViolations(), 1)
+	assert.Len(t, ruleResult.SuppressedViolations(), 1)
+
+	// One remaining violation: 5 × 1^1.5
+	expected := 100 * (1 - 5.0/50)
+	assert.InDelta(t, expected, report.EffectiveScore, 0.0001)
+	assert.Equal(t, score, report.Score)
+}
+
+func TestSchemaReport_ApplySuppressions_NoMatches(t *testing.T) {
+	report := NewSchemaReport("1", stringPtr("user-service"), 80.0, 50, 10.0, time.Now(), nil)
+	report.AddRuleResult(RuleResult{
+		RuleName:   "PII",
+		Violations: []Violation{{Message: "Field 'email' contains PII", LocationCoordinate: stringPtr("User.email")}},
+	})
+
+	report.ApplySuppressions([]Suppression{
+		{ID: "1", SubgraphName: "user-service", RuleName: "Boolean Prefix"},
+	})
+
+	assert.Equal(t, 0, report.SuppressedCount)
+	assert.Equal(t, 80.0, report.EffectiveScore)
+}
+
+func TestIncomingSuppression_ToDomainEntity(t *testing.T) {
+	incoming := IncomingSuppression{
+		SubgraphName:      "user-service",
+		RuleName:          "Boolean Prefix",
+		CoordinatePattern: "User.*",
+		Reason:            "Legacy",
+		Author:            "jane",
+		ExpiresAt:         stringPtr("2030-01-01T00:00:00Z"),
+	}
+
+	suppression, err := incoming.ToDomainEntity()
+	assert.NoError(t, err)
+	assert.Equal(t, "User.*", suppression.CoordinatePattern)
+	assert.Equal(t, 2030, suppression.ExpiresAt.Year())
+
+	incoming.ExpiresAt = stringPtr("next week")
+	_, err = incoming.ToDomainEntity()
+	assert.ErrorIs(t, err, ErrInvalidSuppression)
+}
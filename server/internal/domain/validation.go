@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a field of a request that failed validation
+type FieldError struct {
+	// Path locates the field in the request body, such as "ruleResults[0].rule"
+	Path   string
+	Reason string
+}
+
+// ValidationError lists the fields of a request that failed validation. It wraps the
+// error of the kind of request, such as ErrInvalidReport, so callers can keep matching
+// it with errors.Is and get the fields with errors.As.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+// NewValidationError creates a validation error for the given fields
+func NewValidationError(err error, fields ...FieldError) *ValidationError {
+	return &ValidationError{Err: err, Fields: fields}
+}
+
+// Error lists the invalid fields after the wrapped error
+func (e *ValidationError) Error() string {
+	problems := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		problems = append(problems, fmt.Sprintf("%s %s", field.Path, field.Reason))
+	}
+	return fmt.Sprintf("%v: %s", e.Err, strings.Join(problems, ", "))
+}
+
+// Unwrap returns the error of the kind of request
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
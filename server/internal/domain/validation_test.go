@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	err := error(NewValidationError(ErrInvalidReport,
+		FieldError{Path: "timestamp", Reason: "must be an RFC3339 timestamp or milliseconds since the Unix epoch"},
+		FieldError{Path: "ruleResults[0].rule", Reason: "is required"},
+	))
+
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	assert.Equal(t, "invalid report: timestamp must be an RFC3339 timestamp or milliseconds since the Unix epoch, ruleResults[0].rule is required", err.Error())
+
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Len(t, validationErr.Fields, 2)
+	}
+}
+
+func TestIncomingReport_ToDomainEntity_InvalidTimestamp(t *testing.T) {
+	incoming := IncomingReport{Timestamp: "yesterday"}
+
+	_, _, err := incoming.ToDomainEntity(DefaultRuleCatalog)
+	assert.ErrorIs(t, err, ErrInvalidReport)
+
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []FieldError{{Path: "timestamp", Reason: "must be an RFC3339 timestamp or milliseconds since the Unix epoch"}}, validationErr.Fields)
+	}
+}
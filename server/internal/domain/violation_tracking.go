@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// Only standalone numbers such as counts, digits in names like "V2" identify the violation
+	numberPattern     = regexp.MustCompile(`\b[0-9]+(\.[0-9]+)?\b`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// TrackedViolation follows a single violation, identified by its fingerprint,
+// across the reports of a subgraph
+type TrackedViolation struct {
+	Fingerprint        string
+	SubgraphName       string
+	RuleName           string
+	LocationCoordinate *string
+	Message            string
+	FirstSeenReportID  string
+	FirstSeenAt        time.Time
+	LastSeenReportID   string
+	LastSeenAt         time.Time
+	ResolvedAt         *time.Time
+}
+
+// AgeDays returns the number of full days the violation has been open at the given time
+func (tv TrackedViolation) AgeDays(now time.Time) int {
+	return ageInDays(tv.FirstSeenAt, now)
+}
+
+// ViolationFingerprint returns a stable identifier for a violation that stays the
+// same across reports as long as the subgraph, rule, coordinate and message do
+func ViolationFingerprint(subgraphName, ruleName string, violation Violation) string {
+	coordinate := ""
+	if violation.LocationCoordinate != nil {
+		coordinate = *violation.LocationCoordinate
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{subgraphName, ruleName, coordinate, normalizeViolationMessage(violation.Message)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// normalizeViolationMessage removes the parts of a message that change between
+// runs without the violation itself changing, such as counts and whitespace
+func normalizeViolationMessage(message string) string {
+	normalized := strings.ToLower(strings.TrimSpace(message))
+	normalized = numberPattern.ReplaceAllString(normalized, "#")
+	return whitespacePattern.ReplaceAllString(normalized, " ")
+}
+
+// AssignFingerprints computes the fingerprint of every violation in the report
+func (sr *SchemaReport) AssignFingerprints() {
+	for i := range sr.RuleResults {
+		ruleResult := &sr.RuleResults[i]
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			violation.Fingerprint = ViolationFingerprint(sr.SubgraphName, ruleResult.RuleName, *violation)
+		}
+	}
+}
+
+// ComputeViolationAges sets the age of every tracked violation relative to the report timestamp
+func (sr *SchemaReport) ComputeViolationAges() {
+	for i := range sr.RuleResults {
+		ruleResult := &sr.RuleResults[i]
+		for j := range ruleResult.Violations {
+			violation := &ruleResult.Violations[j]
+			if violation.FirstSeenAt != nil {
+				violation.AgeDays = ageInDays(*violation.FirstSeenAt, sr.Timestamp)
+			}
+		}
+	}
+}
+
+// ageInDays returns the number of full days between since and now, never negative
+func ageInDays(since, now time.Time) int {
+	if now.Before(since) {
+		return 0
+	}
+	return int(now.Sub(since).Hours() / 24)
+}
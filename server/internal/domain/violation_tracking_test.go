@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestViolationFingerprint(t *testing.T) {
+	base := Violation{
+		Message:            "Type \"Product\" has 3 composite keys",
+		LocationCoordinate: stringPtr("Product"),
+	}
+
+	tests := []struct {
+		name      string
+		subgraph  string
+		rule      string
+		violation Violation
+		sameAs    bool
+	}{
+		{
+			name:      "identical violation",
+			subgraph:  "product-service",
+			rule:      "Composite Keys",
+			violation: base,
+			sameAs:    true,
+		},
+		{
+			name:     "counts and whitespace in message are ignored",
+			subgraph: "product-service",
+			rule:     "Composite Keys",
+			violation: Violation{
+				Message:            "  type \"Product\"  has 4 composite keys ",
+				LocationCoordinate: stringPtr("Product"),
+			},
+			sameAs: true,
+		},
+		{
+			name:     "numbers in names are kept",
+			subgraph: "product-service",
+			rule:     "Composite Keys",
+			violation: Violation{
+				Message:            "Type \"Product2\" has 3 composite keys",
+				LocationCoordinate: stringPtr("Product"),
+			},
+			sameAs: false,
+		},
+		{
+			name:      "different subgraph",
+			subgraph:  "order-service",
+			rule:      "Composite Keys",
+			violation: base,
+			sameAs:    false,
+		},
+		{
+			name:      "different rule",
+			subgraph:  "product-service",
+			rule:      "PII",
+			violation: base,
+			sameAs:    false,
+		},
+		{
+			name:     "different coordinate",
+			subgraph: "product-service",
+			rule:     "Composite Keys",
+			violation: Violation{
+				Message:            base.Message,
+				LocationCoordinate: stringPtr("Order"),
+			},
+			sameAs: false,
+		},
+	}
+
+	expected := ViolationFingerprint("product-service", "Composite Keys", base)
+	assert.Len(t, expected, 64)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fingerprint := ViolationFingerprint(tt.subgraph, tt.rule, tt.violation)
+			if tt.sameAs {
+				assert.Equal(t, expected, fingerprint)
+			} else {
+				assert.NotEqual(t, expected, fingerprint)
+			}
+		})
+	}
+}
+
+func TestViolationFingerprint_DistinctNames(t *testing.T) {
+	first := Violation{Message: "Enum value V1 is not UPPER_CASE", LocationCoordinate: stringPtr("Version")}
+	second := Violation{Message: "Enum value V2 is not UPPER_CASE", LocationCoordinate: stringPtr("Version")}
+
+	assert.NotEqual(t,
+		ViolationFingerprint("product-service", "Naming", first),
+		ViolationFingerprint("product-service", "Naming", second))
+}
+
+func TestSchemaReport_AssignFingerprints(t *testing.T) {
+	report := NewSchemaReport("1", stringPtr("user-service"), 90.0, 10, 1.0, time.Now(), nil)
+	report.AddRuleResult(RuleResult{
+		RuleName: "PII",
+		Violations: []Violation{
+			{Message: "Field 'email' contains PII", LocationCoordinate: stringPtr("User.email")},
+			{Message: "Field 'phone' contains PII", LocationCoordinate: stringPtr("User.phone")},
+		},
+	})
+
+	report.AssignFingerprints()
+
+	violations := report.RuleResults[0].Violations
+	assert.Equal(t, ViolationFingerprint("user-service", "PII", violations[0]), violations[0].Fingerprint)
+	assert.NotEmpty(t, violations[1].Fingerprint)
+	assert.NotEqual(t, violations[0].Fingerprint, violations[1].Fingerprint)
+}
+
+func TestSchemaReport_ComputeViolationAges(t *testing.T) {
+	reportTime := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tenDaysAgo := reportTime.Add(-10*24*time.Hour - time.Hour)
+	later := reportTime.Add(time.Hour)
+
+	report := NewSchemaReport("1", stringPtr("user-service"), 90.0, 10, 1.0, reportTime, nil)
+	report.AddRuleResult(RuleResult{
+		RuleName: "PII",
+		Violations: []Violation{
+			{Message: "old", FirstSeenAt: &tenDaysAgo},
+			{Message: "clock skew", FirstSeenAt: &later},
+			{Message: "untracked"},
+		},
+	})
+
+	report.ComputeViolationAges()
+
+	violations := report.RuleResults[0].Violations
+	assert.Equal(t, 10, violations[0].AgeDays)
+	assert.Equal(t, 0, violations[1].AgeDays)
+	assert.Equal(t, 0, violations[2].AgeDays)
+}
+
+func TestTrackedViolation_AgeDays(t *testing.T) {
+	now := time.Now()
+	violation := TrackedViolation{FirstSeenAt: now.Add(-72 * time.Hour)}
+
+	assert.Equal(t, 3, violation.AgeDays(now))
+}
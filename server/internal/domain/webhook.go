@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Webhook event types
+const (
+	WebhookEventScoreBelowThreshold = "score.below_threshold"
+	WebhookEventScoreRegression     = "score.regression"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookSignatureHeader = "X-Schema-Score-Signature"
+	WebhookEventHeader     = "X-Schema-Score-Event"
+	WebhookDeliveryHeader  = "X-Schema-Score-Delivery"
+)
+
+// MaxDeliveryAttempts is the number of attempts after which a delivery is given up
+const MaxDeliveryAttempts = 8
+
+const (
+	deliveryBaseBackoff = 30 * time.Second
+	deliveryMaxBackoff  = 6 * time.Hour
+)
+
+// Webhook is a registered endpoint notified about score regressions. A webhook
+// without a subgraph name receives events for every subgraph.
+type Webhook struct {
+	ID           string
+	URL          string
+	Secret       string
+	SubgraphName string
+	MinScore     *float64
+	MaxScoreDrop *float64
+	CreatedAt    time.Time
+}
+
+// WebhookEvent is the JSON payload delivered to a webhook
+type WebhookEvent struct {
+	ID               string    `json:"id"`
+	Type             string    `json:"type"`
+	SubgraphName     string    `json:"subgraph_name"`
+	ReportID         string    `json:"report_id"`
+	Score            float64   `json:"score"`
+	PreviousReportID *string   `json:"previous_report_id,omitempty"`
+	PreviousScore    *float64  `json:"previous_score,omitempty"`
+	Threshold        float64   `json:"threshold"`
+	Message          string    `json:"message"`
+	OccurredAt       time.Time `json:"occurred_at"`
+}
+
+// WebhookDelivery is an outbox entry holding one event for one webhook
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	LastStatusCode *int
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+	AttemptLog     []DeliveryAttempt
+}
+
+// DeliveryAttempt records the outcome of sending a delivery once
+type DeliveryAttempt struct {
+	Number      int
+	StatusCode  *int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// Succeeded reports whether the endpoint accepted the delivery
+func (da DeliveryAttempt) Succeeded() bool {
+	return da.Error == ""
+}
+
+// Validate checks that the webhook can be delivered to and has something to notify about
+func (w Webhook) Validate() error {
+	var problems []string
+
+	parsed, err := url.Parse(w.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		problems = append(problems, "url must be an absolute http or https URL")
+	}
+	if strings.TrimSpace(w.Secret) == "" {
+		problems = append(problems, "secret is required")
+	}
+	if w.MinScore == nil && w.MaxScoreDrop == nil {
+		problems = append(problems, "a minimum score or a maximum score drop is required")
+	}
+	if w.MaxScoreDrop != nil && *w.MaxScoreDrop < 0 {
+		problems = append(problems, "maximum score drop must not be negative")
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", ErrInvalidWebhook, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// AppliesTo reports whether the webhook is interested in reports of a subgraph
+func (w Webhook) AppliesTo(subgraphName string) bool {
+	return w.SubgraphName == "" || w.SubgraphName == subgraphName
+}
+
+// Events returns the events a stored report triggers for this webhook. The effective
+// score is compared, and a threshold event is only raised when the score crosses it
+// so that a subgraph staying below the threshold does not notify on every report.
+// The previous report may be nil.
+func (w Webhook) Events(report *SchemaReport, previous *SchemaReport, now time.Time) []WebhookEvent {
+	if !w.AppliesTo(report.SubgraphName) {
+		return nil
+	}
+
+	base := WebhookEvent{
+		SubgraphName: report.SubgraphName,
+		ReportID:     report.ID,
+		Score:        report.EffectiveScore,
+		OccurredAt:   now,
+	}
+	if previous != nil {
+		previousID := previous.ID
+		previousScore := previous.EffectiveScore
+		base.PreviousReportID = &previousID
+		base.PreviousScore = &previousScore
+	}
+
+	var events []WebhookEvent
+
+	if w.MinScore != nil && report.EffectiveScore < *w.MinScore &&
+		(previous == nil || previous.EffectiveScore >= *w.MinScore) {
+		event := base
+		event.Type = WebhookEventScoreBelowThreshold
+		event.Threshold = *w.MinScore
+		event.Message = fmt.Sprintf("%s scored %.2f, below the threshold of %.2f",
+			report.SubgraphName, report.EffectiveScore, *w.MinScore)
+		events = append(events, event)
+	}
+
+	if w.MaxScoreDrop != nil && previous != nil {
+		drop := previous.EffectiveScore - report.EffectiveScore
+		if drop > *w.MaxScoreDrop {
+			event := base
+			event.Type = WebhookEventScoreRegression
+			event.Threshold = *w.MaxScoreDrop
+			event.Message = fmt.Sprintf("%s dropped %.2f points from %.2f to %.2f",
+				report.SubgraphName, drop, previous.EffectiveScore, report.EffectiveScore)
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// RecordAttempt applies the outcome of an attempt. Failed deliveries are retried with
+// exponential backoff until MaxDeliveryAttempts is reached.
+func (d *WebhookDelivery) RecordAttempt(attempt DeliveryAttempt) {
+	d.Attempts++
+	d.LastStatusCode = attempt.StatusCode
+	d.LastError = attempt.Error
+	d.AttemptLog = append(d.AttemptLog, attempt)
+
+	switch {
+	case attempt.Succeeded():
+		deliveredAt := attempt.AttemptedAt
+		d.Status = DeliveryStatusDelivered
+		d.DeliveredAt = &deliveredAt
+	case d.Attempts >= MaxDeliveryAttempts:
+		d.Status = DeliveryStatusFailed
+	default:
+		d.Status = DeliveryStatusPending
+		d.NextAttemptAt = attempt.AttemptedAt.Add(RetryBackoff(d.Attempts))
+	}
+}
+
+// RetryBackoff returns the delay before the next attempt after the given number of
+// failed attempts: 30s, 1m, 2m, ... capped at six hours
+func RetryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	backoff := deliveryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= deliveryMaxBackoff {
+			return deliveryMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// SignWebhookPayload returns the signature header value for a payload. The HMAC-SHA256
+// covers the timestamp and the body, "t=<unix seconds>,v1=<hex digest>", so receivers
+// can reject replayed deliveries.
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	unix := timestamp.Unix()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", unix)
+	mac.Write(payload)
+
+	return fmt.Sprintf("t=%d,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// IncomingWebhook represents the JSON structure used to register a webhook
+type IncomingWebhook struct {
+	URL          string   `json:"url"`
+	Secret       string   `json:"secret"`
+	SubgraphName string   `json:"subgraphName"`
+	MinScore     *float64 `json:"minScore"`
+	MaxScoreDrop *float64 `json:"maxScoreDrop"`
+}
+
+// ToDomainEntity converts the incoming DTO to a webhook
+func (iw *IncomingWebhook) ToDomainEntity() *Webhook {
+	return &Webhook{
+		URL:          strings.TrimSpace(iw.URL),
+		Secret:       iw.Secret,
+		SubgraphName: iw.SubgraphName,
+		MinScore:     iw.MinScore,
+		MaxScoreDrop: iw.MaxScoreDrop,
+	}
+}
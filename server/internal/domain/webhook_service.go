@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// deliveryLease is how long a claimed delivery is hidden from other dispatchers
+const deliveryLease = 2 * time.Minute
+
+// WebhookService contains the business logic for webhook notifications
+type WebhookService struct {
+	webhooks WebhookRepository
+	reports  SchemaReportRepository
+	// enqueueFailures counts stored reports whose notifications could not be queued
+	enqueueFailures atomic.Int64
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(webhooks WebhookRepository, reports SchemaReportRepository) *WebhookService {
+	return &WebhookService{
+		webhooks: webhooks,
+		reports:  reports,
+	}
+}
+
+// CreateWebhook validates and stores a new webhook, generating a signing secret when none is given
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *Webhook) (*Webhook, error) {
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		webhook.Secret = secret
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	webhook.CreatedAt = time.Now()
+	if err := s.webhooks.StoreWebhook(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to store webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook by its ID
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	webhook, err := s.webhooks.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// ListWebhooks retrieves all webhooks
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	webhooks, err := s.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	if err := s.webhooks.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// NotifyReportStored compares a stored report with the previous report of its subgraph
+// and queues a delivery for every event it triggers. Deliveries are sent asynchronously.
+//
+// The deliveries are queued after the report has been committed, not in the same
+// transaction, so the events of a report are queued at most once: when queuing fails or
+// the process stops in between, they are lost. Failures are counted, see EnqueueFailures.
+// Queued deliveries are retried until they succeed or are given up on.
+func (s *WebhookService) NotifyReportStored(ctx context.Context, report *SchemaReport) error {
+	if err := s.notifyReportStored(ctx, report); err != nil {
+		s.enqueueFailures.Add(1)
+		return err
+	}
+	return nil
+}
+
+// EnqueueFailures returns how many stored reports lost their notifications because the
+// deliveries could not be queued
+func (s *WebhookService) EnqueueFailures() int64 {
+	return s.enqueueFailures.Load()
+}
+
+func (s *WebhookService) notifyReportStored(ctx context.Context, report *SchemaReport) error {
+	webhooks, err := s.webhooks.ListWebhooksForSubgraph(ctx, report.SubgraphName)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	previous, err := s.previousReport(ctx, report)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var deliveries []WebhookDelivery
+	for _, webhook := range webhooks {
+		for _, event := range webhook.Events(report, previous, now) {
+			event.ID = NewID()
+
+			payload, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal webhook event: %w", err)
+			}
+
+			deliveries = append(deliveries, WebhookDelivery{
+				WebhookID:     webhook.ID,
+				EventType:     event.Type,
+				Payload:       payload,
+				Status:        DeliveryStatusPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := s.webhooks.EnqueueDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries returns pending deliveries that are due to be sent
+func (s *WebhookService) ClaimDueDeliveries(ctx context.Context, limit int) ([]WebhookDelivery, error) {
+	deliveries, err := s.webhooks.ClaimDueDeliveries(ctx, time.Now(), deliveryLease, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RecordDeliveryAttempt stores the outcome of sending a delivery and schedules its retry
+func (s *WebhookService) RecordDeliveryAttempt(ctx context.Context, delivery *WebhookDelivery, attempt DeliveryAttempt) error {
+	attempt.Number = delivery.Attempts + 1
+	delivery.RecordAttempt(attempt)
+
+	if err := s.webhooks.SaveDeliveryAttempt(ctx, delivery, attempt); err != nil {
+		return fmt.Errorf("failed to save delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery retrieves a delivery with its attempts
+func (s *WebhookService) GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	delivery, err := s.webhooks.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries retrieves recent deliveries, optionally filtered by webhook and status
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID, status string, limit int) ([]WebhookDelivery, error) {
+	deliveries, err := s.webhooks.ListDeliveries(ctx, webhookID, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery schedules a delivery to be sent again right away, including one that
+// has been given up on
+func (s *WebhookService) RetryDelivery(ctx context.Context, id string) error {
+	if err := s.webhooks.RetryDelivery(ctx, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// previousReport finds the report of the same subgraph that precedes the given one by
+// timestamp, so that a report that arrives late is compared with the one before it
+func (s *WebhookService) previousReport(ctx context.Context, report *SchemaReport) (*SchemaReport, error) {
+	previous, err := s.reports.GetPreviousReport(ctx, report.SubgraphName, report.Timestamp)
+	if errors.Is(err, ErrReportNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous report: %w", err)
+	}
+	return previous, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	repo := NewMockWebhookRepository()
+	service := NewWebhookService(repo, NewMockSchemaReportRepository())
+
+	webhook, err := service.CreateWebhook(context.Background(), &Webhook{URL: "https://hooks.example.com", MinScore: float64Ptr(80)})
+	assert.NoError(t, err)
+	assert.Len(t, webhook.Secret, 64, "a secret is generated when none is given")
+	assert.Len(t, repo.Webhooks, 1)
+
+	_, err = service.CreateWebhook(context.Background(), &Webhook{URL: "not a url", MinScore: float64Ptr(80)})
+	assert.ErrorIs(t, err, ErrInvalidWebhook)
+}
+
+func TestWebhookService_NotifyReportStored(t *testing.T) {
+	previous := *newWebhookTestReport("1", 90)
+	previous.Timestamp = time.Now().Add(-time.Hour)
+	current := newWebhookTestReport("2", 70)
+
+	webhooks := NewMockWebhookRepository().
+		WithWebhook(Webhook{URL: "https://a.example.com", Secret: "a", MinScore: float64Ptr(80)}).
+		WithWebhook(Webhook{URL: "https://b.example.com", Secret: "b", SubgraphName: "user-service", MaxScoreDrop: float64Ptr(5)}).
+		WithWebhook(Webhook{URL: "https://c.example.com", Secret: "c", SubgraphName: "order-service", MinScore: float64Ptr(80)})
+	reports := NewMockSchemaReportRepository()
+	reports.Reports["1"] = &previous
+	reports.Reports["2"] = current
+	service := NewWebhookService(webhooks, reports)
+
+	err := service.NotifyReportStored(context.Background(), current)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks.Deliveries, 2)
+
+	for _, delivery := range webhooks.Deliveries {
+		assert.Equal(t, DeliveryStatusPending, delivery.Status)
+
+		var event WebhookEvent
+		assert.NoError(t, json.Unmarshal(delivery.Payload, &event))
+		assert.Equal(t, delivery.EventType, event.Type)
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, "2", event.ReportID)
+		assert.Equal(t, "1", *event.PreviousReportID)
+	}
+
+	webhooks.ShouldFailEnqueue = true
+	assert.ErrorContains(t, service.NotifyReportStored(context.Background(), current), "failed to enqueue webhook deliveries")
+}
+
+func TestWebhookService_NotifyReportStored_LateReport(t *testing.T) {
+	earlier := newWebhookTestReport("1", 90)
+	earlier.Timestamp = time.Now().Add(-2 * time.Hour)
+	later := newWebhookTestReport("2", 60)
+	late := newWebhookTestReport("3", 88)
+	late.Timestamp = time.Now().Add(-time.Hour)
+
+	webhooks := NewMockWebhookRepository().
+		WithWebhook(Webhook{URL: "https://a.example.com", Secret: "a", MaxScoreDrop: float64Ptr(1)})
+	reports := NewMockSchemaReportRepository()
+	for _, report := range []*SchemaReport{earlier, later, late} {
+		reports.Reports[report.ID] = report
+	}
+	service := NewWebhookService(webhooks, reports)
+
+	// The late report is compared with the report before it, not with the newest one
+	assert.NoError(t, service.NotifyReportStored(context.Background(), late))
+	assert.Len(t, webhooks.Deliveries, 1)
+	for _, delivery := range webhooks.Deliveries {
+		var event WebhookEvent
+		assert.NoError(t, json.Unmarshal(delivery.Payload, &event))
+		assert.Equal(t, "3", event.ReportID)
+		assert.Equal(t, "1", *event.PreviousReportID)
+	}
+
+	// The first report of a subgraph has nothing to regress from
+	assert.NoError(t, service.NotifyReportStored(context.Background(), earlier))
+	assert.Len(t, webhooks.Deliveries, 1)
+}
+
+func TestWebhookService_RecordDeliveryAttempt(t *testing.T) {
+	repo := NewMockWebhookRepository().
+		WithDelivery(WebhookDelivery{WebhookID: "1", Status: DeliveryStatusPending, NextAttemptAt: time.Now()})
+	service := NewWebhookService(repo, NewMockSchemaReportRepository())
+
+	deliveries, err := service.ClaimDueDeliveries(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+
+	again, err := service.ClaimDueDeliveries(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, again, "claimed deliveries are leased")
+
+	err = service.RecordDeliveryAttempt(context.Background(), &deliveries[0], DeliveryAttempt{Error: "timeout", AttemptedAt: time.Now()})
+	assert.NoError(t, err)
+
+	stored := repo.Deliveries["1"]
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, 1, stored.AttemptLog[0].Number)
+	assert.Equal(t, DeliveryStatusPending, stored.Status)
+
+	assert.NoError(t, service.RetryDelivery(context.Background(), "1"))
+	assert.ErrorIs(t, service.RetryDelivery(context.Background(), "999"), ErrWebhookDeliveryNotFound)
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newWebhookTestReport(id string, effectiveScore float64) *SchemaReport {
+	return NewSchemaReport(id, stringPtr("user-service"), effectiveScore, 100, 0, time.Now(), nil)
+}
+
+func TestWebhook_Events(t *testing.T) {
+	tests := []struct {
+		name           string
+		webhook        Webhook
+		report         *SchemaReport
+		previous       *SchemaReport
+		expectedEvents []string
+	}{
+		{
+			name:           "crosses threshold",
+			webhook:        Webhook{MinScore: float64Ptr(80)},
+			report:         newWebhookTestReport("2", 75),
+			previous:       newWebhookTestReport("1", 85),
+			expectedEvents: []string{WebhookEventScoreBelowThreshold},
+		},
+		{
+			name:           "first report below threshold",
+			webhook:        Webhook{MinScore: float64Ptr(80)},
+			report:         newWebhookTestReport("1", 75),
+			expectedEvents: []string{WebhookEventScoreBelowThreshold},
+		},
+		{
+			name:     "stays below threshold",
+			webhook:  Webhook{MinScore: float64Ptr(80)},
+			report:   newWebhookTestReport("2", 70),
+			previous: newWebhookTestReport("1", 75),
+		},
+		{
+			name:           "regression",
+			webhook:        Webhook{MaxScoreDrop: float64Ptr(5)},
+			report:         newWebhookTestReport("2", 84),
+			previous:       newWebhookTestReport("1", 90),
+			expectedEvents: []string{WebhookEventScoreRegression},
+		},
+		{
+			name:     "small drop",
+			webhook:  Webhook{MaxScoreDrop: float64Ptr(5)},
+			report:   newWebhookTestReport("2", 86),
+			previous: newWebhookTestReport("1", 90),
+		},
+		{
+			name:           "both events",
+			webhook:        Webhook{MinScore: float64Ptr(80), MaxScoreDrop: float64Ptr(5)},
+			report:         newWebhookTestReport("2", 70),
+			previous:       newWebhookTestReport("1", 90),
+			expectedEvents: []string{WebhookEventScoreBelowThreshold, WebhookEventScoreRegression},
+		},
+		{
+			name:     "other subgraph",
+			webhook:  Webhook{SubgraphName: "order-service", MinScore: float64Ptr(80)},
+			report:   newWebhookTestReport("1", 10),
+			previous: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := tt.webhook.Events(tt.report, tt.previous, time.Now())
+
+			types := make([]string, 0, len(events))
+			for _, event := range events {
+				types = append(types, event.Type)
+				assert.Equal(t, tt.report.ID, event.ReportID)
+				assert.NotEmpty(t, event.Message)
+			}
+
+			if tt.expectedEvents == nil {
+				assert.Empty(t, types)
+			} else {
+				assert.Equal(t, tt.expectedEvents, types)
+			}
+		})
+	}
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	valid := Webhook{URL: "https://hooks.example.com/schema", Secret: "s3cret", MinScore: float64Ptr(80)}
+	assert.NoError(t, valid.Validate())
+
+	invalid := Webhook{URL: "ftp://example.com", MaxScoreDrop: float64Ptr(-1)}
+	err := invalid.Validate()
+	assert.ErrorIs(t, err, ErrInvalidWebhook)
+	assert.ErrorContains(t, err, "url must be an absolute http or https URL")
+	assert.ErrorContains(t, err, "secret is required")
+	assert.ErrorContains(t, err, "maximum score drop must not be negative")
+
+	noThreshold := Webhook{URL: "https://hooks.example.com", Secret: "s3cret"}
+	assert.ErrorContains(t, noThreshold.Validate(), "a minimum score or a maximum score drop is required")
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	now := time.Now()
+	statusCode := 500
+
+	delivery := &WebhookDelivery{Status: DeliveryStatusPending}
+	delivery.RecordAttempt(DeliveryAttempt{StatusCode: &statusCode, Error: "unexpected status 500", AttemptedAt: now})
+
+	assert.Equal(t, DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, now.Add(30*time.Second), delivery.NextAttemptAt)
+	assert.Equal(t, "unexpected status 500", delivery.LastError)
+
+	delivery.RecordAttempt(DeliveryAttempt{AttemptedAt: now})
+	assert.Equal(t, DeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, now, *delivery.DeliveredAt)
+	assert.Len(t, delivery.AttemptLog, 2)
+
+	exhausted := &WebhookDelivery{Status: DeliveryStatusPending, Attempts: MaxDeliveryAttempts - 1}
+	exhausted.RecordAttempt(DeliveryAttempt{Error: "connection refused", AttemptedAt: now})
+	assert.Equal(t, DeliveryStatusFailed, exhausted.Status)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), RetryBackoff(0))
+	assert.Equal(t, 30*time.Second, RetryBackoff(1))
+	assert.Equal(t, time.Minute, RetryBackoff(2))
+	assert.Equal(t, 4*time.Minute, RetryBackoff(4))
+	assert.Equal(t, 6*time.Hour, RetryBackoff(20))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	payload := []byte(`{"type":"score.regression"}`)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	expected := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, SignWebhookPayload("s3cret", timestamp, payload))
+	assert.NotEqual(t, expected, SignWebhookPayload("other", timestamp, payload))
+}
@@ -0,0 +1,46 @@
+// Package web bundles the HTML templates and static assets of the web interface into the binary
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+)
+
+// Source directories, relative to the server directory, used in development mode
+const (
+	templatesDir = "internal/web/templates"
+	staticDir    = "internal/web/static"
+)
+
+//go:embed templates/*.html
+var embeddedTemplates embed.FS
+
+//go:embed static
+var embeddedStatic embed.FS
+
+// Templates returns the HTML templates. In development mode they are read from the
+// source tree, so edits show up without rebuilding.
+func Templates(dev bool) fs.FS {
+	if dev {
+		return os.DirFS(templatesDir)
+	}
+	return mustSub(embeddedTemplates, "templates")
+}
+
+// Static returns the static assets served under /static/
+func Static(dev bool) fs.FS {
+	if dev {
+		return os.DirFS(staticDir)
+	}
+	return mustSub(embeddedStatic, "static")
+}
+
+// mustSub roots an embedded filesystem at one of its directories, which always exist
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,4 @@
+-- Drop the report tables created by 001_initial
+DROP TABLE IF EXISTS violations;
+DROP TABLE IF EXISTS rule_results;
+DROP TABLE IF EXISTS schema_reports;
@@ -0,0 +1,8 @@
+-- Remove violation tracking
+DROP INDEX IF EXISTS idx_violations_fingerprint;
+
+ALTER TABLE violations DROP COLUMN IF EXISTS first_seen_at;
+ALTER TABLE violations DROP COLUMN IF EXISTS first_seen_report_id;
+ALTER TABLE violations DROP COLUMN IF EXISTS fingerprint;
+
+DROP TABLE IF EXISTS tracked_violations;
@@ -0,0 +1,10 @@
+-- Remove suppressions and the effective score
+ALTER TABLE violations DROP COLUMN IF EXISTS suppression_id;
+ALTER TABLE violations DROP COLUMN IF EXISTS suppressed;
+
+ALTER TABLE rule_results DROP COLUMN IF EXISTS suppressed_count;
+
+ALTER TABLE schema_reports DROP COLUMN IF EXISTS suppressed_count;
+ALTER TABLE schema_reports DROP COLUMN IF EXISTS effective_score;
+
+DROP TABLE IF EXISTS suppressions;
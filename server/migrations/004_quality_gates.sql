@@ -0,0 +1,13 @@
+-- Create gate_configs table holding the quality gate policy per subgraph
+CREATE TABLE IF NOT EXISTS gate_configs (
+    subgraph_name VARCHAR(255) PRIMARY KEY,
+    min_score DECIMAL(10,2),
+    max_score_drop DECIMAL(10,2),
+    default_branch VARCHAR(255) NOT NULL DEFAULT '',
+    rule_max_violations JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Look up the latest report on a branch for score drop checks
+CREATE INDEX IF NOT EXISTS idx_schema_reports_subgraph_branch
+    ON schema_reports(subgraph_name, (metadata->>'branch'), timestamp DESC);
@@ -0,0 +1,3 @@
+-- Remove API tokens
+ALTER TABLE schema_reports DROP COLUMN IF EXISTS api_token_id;
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,14 @@
+-- Create api_tokens table for authenticated report ingestion
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    subgraphs TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+-- Token that submitted each report
+ALTER TABLE schema_reports ADD COLUMN IF NOT EXISTS api_token_id INTEGER REFERENCES api_tokens(id) ON DELETE SET NULL;
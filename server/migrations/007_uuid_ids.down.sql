@@ -0,0 +1,76 @@
+-- Return to serial integer IDs. Rows stored before the UUIDs get their integer back, and
+-- rows stored since are numbered after the largest integer of their table.
+CREATE TEMP TABLE integer_ids (
+    table_name TEXT NOT NULL,
+    id UUID NOT NULL,
+    integer_id INTEGER NOT NULL,
+    PRIMARY KEY (table_name, id)
+) ON COMMIT DROP;
+
+CREATE FUNCTION pg_temp.number_ids(name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format($sql$
+        INSERT INTO pg_temp.integer_ids (table_name, id, integer_id)
+        SELECT %1$L, id, ('x' || substr(replace(id::text, '-', ''), 17))::bit(64)::bigint
+        FROM %1$I WHERE id::text LIKE '00000000-0000-0000-0000-%%'$sql$, name);
+    EXECUTE format($sql$
+        INSERT INTO pg_temp.integer_ids (table_name, id, integer_id)
+        SELECT %1$L, id,
+            (SELECT COALESCE(MAX(integer_id), 0) FROM pg_temp.integer_ids WHERE table_name = %1$L)
+                + ROW_NUMBER() OVER (ORDER BY id)
+        FROM %1$I WHERE id::text NOT LIKE '00000000-0000-0000-0000-%%'$sql$, name);
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION pg_temp.integer_id(name TEXT, value UUID) RETURNS INTEGER AS $$
+    SELECT integer_id FROM pg_temp.integer_ids WHERE table_name = name AND id = value
+$$ LANGUAGE sql STABLE;
+
+SELECT pg_temp.number_ids('schema_reports');
+SELECT pg_temp.number_ids('rule_results');
+SELECT pg_temp.number_ids('violations');
+
+ALTER TABLE rule_results DROP CONSTRAINT IF EXISTS rule_results_report_id_fkey;
+ALTER TABLE violations DROP CONSTRAINT IF EXISTS violations_rule_result_id_fkey;
+ALTER TABLE tracked_violations DROP CONSTRAINT IF EXISTS tracked_violations_first_seen_report_id_fkey;
+ALTER TABLE tracked_violations DROP CONSTRAINT IF EXISTS tracked_violations_last_seen_report_id_fkey;
+ALTER TABLE tracked_violations DROP CONSTRAINT IF EXISTS tracked_violations_resolved_report_id_fkey;
+
+ALTER TABLE schema_reports ALTER COLUMN id TYPE INTEGER USING pg_temp.integer_id('schema_reports', id);
+
+ALTER TABLE rule_results
+    ALTER COLUMN id TYPE INTEGER USING pg_temp.integer_id('rule_results', id),
+    ALTER COLUMN report_id TYPE INTEGER USING pg_temp.integer_id('schema_reports', report_id);
+
+ALTER TABLE violations
+    ALTER COLUMN id TYPE INTEGER USING pg_temp.integer_id('violations', id),
+    ALTER COLUMN rule_result_id TYPE INTEGER USING pg_temp.integer_id('rule_results', rule_result_id),
+    ALTER COLUMN first_seen_report_id TYPE INTEGER USING pg_temp.integer_id('schema_reports', first_seen_report_id);
+
+ALTER TABLE tracked_violations
+    ALTER COLUMN first_seen_report_id TYPE INTEGER USING pg_temp.integer_id('schema_reports', first_seen_report_id),
+    ALTER COLUMN last_seen_report_id TYPE INTEGER USING pg_temp.integer_id('schema_reports', last_seen_report_id),
+    ALTER COLUMN resolved_report_id TYPE INTEGER USING pg_temp.integer_id('schema_reports', resolved_report_id);
+
+CREATE SEQUENCE schema_reports_id_seq OWNED BY schema_reports.id;
+SELECT setval('schema_reports_id_seq', COALESCE(MAX(id), 0) + 1, false) FROM schema_reports;
+ALTER TABLE schema_reports ALTER COLUMN id SET DEFAULT nextval('schema_reports_id_seq');
+
+CREATE SEQUENCE rule_results_id_seq OWNED BY rule_results.id;
+SELECT setval('rule_results_id_seq', COALESCE(MAX(id), 0) + 1, false) FROM rule_results;
+ALTER TABLE rule_results ALTER COLUMN id SET DEFAULT nextval('rule_results_id_seq');
+
+CREATE SEQUENCE violations_id_seq OWNED BY violations.id;
+SELECT setval('violations_id_seq', COALESCE(MAX(id), 0) + 1, false) FROM violations;
+ALTER TABLE violations ALTER COLUMN id SET DEFAULT nextval('violations_id_seq');
+
+ALTER TABLE rule_results ADD CONSTRAINT rule_results_report_id_fkey
+    FOREIGN KEY (report_id) REFERENCES schema_reports(id) ON DELETE CASCADE;
+ALTER TABLE violations ADD CONSTRAINT violations_rule_result_id_fkey
+    FOREIGN KEY (rule_result_id) REFERENCES rule_results(id) ON DELETE CASCADE;
+ALTER TABLE tracked_violations ADD CONSTRAINT tracked_violations_first_seen_report_id_fkey
+    FOREIGN KEY (first_seen_report_id) REFERENCES schema_reports(id) ON DELETE SET NULL;
+ALTER TABLE tracked_violations ADD CONSTRAINT tracked_violations_last_seen_report_id_fkey
+    FOREIGN KEY (last_seen_report_id) REFERENCES schema_reports(id) ON DELETE SET NULL;
+ALTER TABLE tracked_violations ADD CONSTRAINT tracked_violations_resolved_report_id_fkey
+    FOREIGN KEY (resolved_report_id) REFERENCES schema_reports(id) ON DELETE SET NULL;
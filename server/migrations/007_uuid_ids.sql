@@ -0,0 +1,49 @@
+-- Identify reports, rule results and violations by UUID instead of a serial integer, so
+-- that the IDs the server hands out are the ones it stores. Existing rows keep their
+-- integer in the last twelve hex digits of the UUID, which is how links with the old
+-- integer IDs are still resolved.
+CREATE FUNCTION pg_temp.legacy_uuid(id INTEGER) RETURNS UUID AS $$
+    SELECT ('00000000-0000-0000-0000-' || lpad(to_hex(id), 12, '0'))::uuid
+$$ LANGUAGE sql IMMUTABLE;
+
+-- The referencing columns change type together with the keys
+ALTER TABLE rule_results DROP CONSTRAINT IF EXISTS rule_results_report_id_fkey;
+ALTER TABLE violations DROP CONSTRAINT IF EXISTS violations_rule_result_id_fkey;
+ALTER TABLE tracked_violations DROP CONSTRAINT IF EXISTS tracked_violations_first_seen_report_id_fkey;
+ALTER TABLE tracked_violations DROP CONSTRAINT IF EXISTS tracked_violations_last_seen_report_id_fkey;
+ALTER TABLE tracked_violations DROP CONSTRAINT IF EXISTS tracked_violations_resolved_report_id_fkey;
+
+ALTER TABLE schema_reports ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE schema_reports ALTER COLUMN id TYPE UUID USING pg_temp.legacy_uuid(id);
+
+ALTER TABLE rule_results ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE rule_results
+    ALTER COLUMN id TYPE UUID USING pg_temp.legacy_uuid(id),
+    ALTER COLUMN report_id TYPE UUID USING pg_temp.legacy_uuid(report_id);
+
+ALTER TABLE violations ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE violations
+    ALTER COLUMN id TYPE UUID USING pg_temp.legacy_uuid(id),
+    ALTER COLUMN rule_result_id TYPE UUID USING pg_temp.legacy_uuid(rule_result_id),
+    ALTER COLUMN first_seen_report_id TYPE UUID USING pg_temp.legacy_uuid(first_seen_report_id);
+
+ALTER TABLE tracked_violations
+    ALTER COLUMN first_seen_report_id TYPE UUID USING pg_temp.legacy_uuid(first_seen_report_id),
+    ALTER COLUMN last_seen_report_id TYPE UUID USING pg_temp.legacy_uuid(last_seen_report_id),
+    ALTER COLUMN resolved_report_id TYPE UUID USING pg_temp.legacy_uuid(resolved_report_id);
+
+-- IDs are assigned by the server from now on
+DROP SEQUENCE IF EXISTS schema_reports_id_seq;
+DROP SEQUENCE IF EXISTS rule_results_id_seq;
+DROP SEQUENCE IF EXISTS violations_id_seq;
+
+ALTER TABLE rule_results ADD CONSTRAINT rule_results_report_id_fkey
+    FOREIGN KEY (report_id) REFERENCES schema_reports(id) ON DELETE CASCADE;
+ALTER TABLE violations ADD CONSTRAINT violations_rule_result_id_fkey
+    FOREIGN KEY (rule_result_id) REFERENCES rule_results(id) ON DELETE CASCADE;
+ALTER TABLE tracked_violations ADD CONSTRAINT tracked_violations_first_seen_report_id_fkey
+    FOREIGN KEY (first_seen_report_id) REFERENCES schema_reports(id) ON DELETE SET NULL;
+ALTER TABLE tracked_violations ADD CONSTRAINT tracked_violations_last_seen_report_id_fkey
+    FOREIGN KEY (last_seen_report_id) REFERENCES schema_reports(id) ON DELETE SET NULL;
+ALTER TABLE tracked_violations ADD CONSTRAINT tracked_violations_resolved_report_id_fkey
+    FOREIGN KEY (resolved_report_id) REFERENCES schema_reports(id) ON DELETE SET NULL;
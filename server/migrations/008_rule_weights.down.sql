@@ -0,0 +1,4 @@
+-- Remove rule weights
+ALTER TABLE rule_results DROP COLUMN IF EXISTS contribution;
+ALTER TABLE rule_results DROP COLUMN IF EXISTS category;
+ALTER TABLE rule_results DROP COLUMN IF EXISTS weight;
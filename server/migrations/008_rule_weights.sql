@@ -0,0 +1,28 @@
+-- Store the weight and category of every rule result and how much it added to the
+-- weighted violations (weight × count^1.5), so that a score can be explained. Existing
+-- rule results get the weights of the rules in the schema scorer.
+ALTER TABLE rule_results ADD COLUMN IF NOT EXISTS weight DECIMAL(10,2) NOT NULL DEFAULT 10;
+ALTER TABLE rule_results ADD COLUMN IF NOT EXISTS category VARCHAR(50) NOT NULL DEFAULT 'uncategorized';
+ALTER TABLE rule_results ADD COLUMN IF NOT EXISTS contribution DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+UPDATE rule_results SET
+    weight = CASE rule_name
+        WHEN 'Null Blast Radius' THEN 20
+        WHEN 'Cycle Counter' THEN 15
+        WHEN 'Nullable External' THEN 15
+        WHEN 'PII' THEN 10
+        WHEN 'Problem Union' THEN 10
+        WHEN 'Composite Keys' THEN 5
+        WHEN 'Deprecation' THEN 5
+        WHEN 'Plural Collections' THEN 5
+        WHEN 'Boolean Prefix' THEN 5
+        ELSE 10
+    END,
+    category = CASE
+        WHEN rule_name IN ('Null Blast Radius', 'Cycle Counter', 'Nullable External') THEN 'critical'
+        WHEN rule_name IN ('PII', 'Problem Union') THEN 'important'
+        WHEN rule_name IN ('Composite Keys', 'Deprecation', 'Plural Collections', 'Boolean Prefix') THEN 'style'
+        ELSE 'uncategorized'
+    END;
+
+UPDATE rule_results SET contribution = weight * violation_count * sqrt(violation_count);
@@ -0,0 +1,5 @@
+-- Remove score verification
+DROP INDEX IF EXISTS idx_schema_reports_score_mismatch;
+
+ALTER TABLE schema_reports DROP COLUMN IF EXISTS score_mismatch;
+ALTER TABLE schema_reports DROP COLUMN IF EXISTS recomputed_score;
@@ -0,0 +1,20 @@
+-- Store the score the server recomputes from the rule results next to the claimed one,
+-- and flag reports where they differ. Existing reports are verified with the default
+-- tolerance of 0.1 points. The recomputed score has no upper bound on its penalty, so it
+-- is not limited to the DECIMAL(10,2) range of the claimed score.
+ALTER TABLE schema_reports ADD COLUMN IF NOT EXISTS recomputed_score DOUBLE PRECISION;
+ALTER TABLE schema_reports ADD COLUMN IF NOT EXISTS score_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE schema_reports SET recomputed_score = 100 * (1 - COALESCE((
+    SELECT SUM(rr.weight * rr.violation_count * sqrt(rr.violation_count))
+    FROM rule_results rr
+    WHERE rr.report_id = schema_reports.id
+), 0) / total_fields)
+WHERE total_fields > 0;
+
+UPDATE schema_reports SET score_mismatch = ABS(score - recomputed_score) > 0.1
+WHERE recomputed_score IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_score_mismatch
+    ON schema_reports(subgraph_name, timestamp)
+    WHERE score_mismatch;
@@ -0,0 +1,2 @@
+-- Remove the rule catalog
+DROP TABLE IF EXISTS rules;
@@ -0,0 +1,3 @@
+-- Remove the ruleset versions and rescored scores
+DROP TABLE IF EXISTS rescored_scores;
+DROP TABLE IF EXISTS ruleset_versions;
@@ -0,0 +1,19 @@
+-- Keep snapshots of the rule weights of the catalog and the scores of stored reports
+-- recomputed with them, so that scores stay comparable when weights change. Like the
+-- recomputed score, a rescored score has no upper bound on its penalty.
+CREATE TABLE IF NOT EXISTS ruleset_versions (
+    version VARCHAR(64) PRIMARY KEY,
+    weights JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS rescored_scores (
+    report_id UUID NOT NULL REFERENCES schema_reports(id) ON DELETE CASCADE,
+    ruleset_version VARCHAR(64) NOT NULL REFERENCES ruleset_versions(version) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    rescored_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (report_id, ruleset_version)
+);
+
+-- Look up the score series of a ruleset version
+CREATE INDEX IF NOT EXISTS idx_rescored_scores_ruleset_version ON rescored_scores(ruleset_version);
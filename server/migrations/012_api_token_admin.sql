@@ -0,0 +1,3 @@
+-- Admin endpoints require a token created as an admin token. Existing tokens,
+-- including tokens for all subgraphs, can only submit reports.
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS admin BOOLEAN NOT NULL DEFAULT FALSE;
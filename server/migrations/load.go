@@ -0,0 +1,147 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrChecksumMismatch is returned when an applied migration file was changed
+	// after it ran
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	// ErrNoDownMigration is returned when rolling back a migration without a down file
+	ErrNoDownMigration = errors.New("migration has no down file")
+)
+
+const downSuffix = ".down.sql"
+
+// Migration is an up migration file, NNN_description.sql, with its optional paired
+// NNN_description.down.sql
+type Migration struct {
+	Name     string
+	Up       string
+	Down     string
+	HasDown  bool
+	Checksum string
+}
+
+// Status describes a migration file and whether it has been applied. Applied migrations
+// without a file are listed too, with an empty Checksum.
+type Status struct {
+	Name            string
+	Applied         bool
+	AppliedAt       *time.Time
+	Checksum        string
+	AppliedChecksum string
+	HasDown         bool
+}
+
+// Drifted reports whether the file changed since the migration was applied. Migrations
+// recorded before checksums were stored have no applied checksum and never drift.
+func (s Status) Drifted() bool {
+	return s.Applied && s.Checksum != "" && s.AppliedChecksum != "" && s.Checksum != s.AppliedChecksum
+}
+
+// Load reads the migrations in the root of fsys, sorted by name
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration files: %w", err)
+	}
+	sort.Strings(files)
+
+	downs := make(map[string]string)
+	var migrations []Migration
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", file, err)
+		}
+
+		base := path.Base(file)
+		if strings.HasSuffix(base, downSuffix) {
+			downs[strings.TrimSuffix(base, downSuffix)] = string(content)
+			continue
+		}
+		migrations = append(migrations, Migration{
+			Name:     strings.TrimSuffix(base, ".sql"),
+			Up:       string(content),
+			Checksum: Checksum(content),
+		})
+	}
+
+	for i := range migrations {
+		if down, ok := downs[migrations[i].Name]; ok {
+			migrations[i].Down = down
+			migrations[i].HasDown = true
+			delete(downs, migrations[i].Name)
+		}
+	}
+	for name := range downs {
+		return nil, fmt.Errorf("down migration %s%s has no up migration", name, downSuffix)
+	}
+
+	return migrations, nil
+}
+
+// Checksum returns the hex SHA-256 of a migration file
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// AppliedMigration is a row of the schema_migrations table
+type AppliedMigration struct {
+	Name      string
+	AppliedAt time.Time
+	Checksum  string
+}
+
+// Statuses merges the migration files with the applied migrations, in name order
+func Statuses(files []Migration, applied []AppliedMigration) []Status {
+	byName := make(map[string]AppliedMigration, len(applied))
+	for _, a := range applied {
+		byName[a.Name] = a
+	}
+
+	statuses := make([]Status, 0, len(files))
+	for _, file := range files {
+		status := Status{Name: file.Name, Checksum: file.Checksum, HasDown: file.HasDown}
+		if a, ok := byName[file.Name]; ok {
+			appliedAt := a.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.AppliedChecksum = a.Checksum
+			delete(byName, file.Name)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, a := range byName {
+		appliedAt := a.AppliedAt
+		statuses = append(statuses, Status{Name: a.Name, Applied: true, AppliedAt: &appliedAt, AppliedChecksum: a.Checksum})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Verify returns ErrChecksumMismatch naming every applied migration whose file changed
+func Verify(statuses []Status) error {
+	var drifted []string
+	for _, s := range statuses {
+		if s.Drifted() {
+			drifted = append(drifted, s.Name)
+		}
+	}
+	if len(drifted) > 0 {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, strings.Join(drifted, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Package migrations embeds the SQL migrations so the server binary can migrate
+// the database without the migrations directory on disk
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// FS contains every PostgreSQL migration file, named NNN_description.sql
+//
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// SQLite contains the SQLite versions of the migrations, named like their PostgreSQL
+// counterparts so both databases report the same migration version
+var SQLite = mustSub(sqliteFS, "sqlite")
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
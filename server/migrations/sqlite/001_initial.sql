@@ -0,0 +1,47 @@
+-- Create schema_reports table
+CREATE TABLE IF NOT EXISTS schema_reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subgraph_name VARCHAR(255) NOT NULL,
+    score DECIMAL(10,2) NOT NULL,
+    total_fields INTEGER NOT NULL,
+    total_weighted_violations DECIMAL(10,2) NOT NULL,
+    timestamp DATETIME NOT NULL,
+    metadata TEXT,
+    created_at DATETIME NOT NULL
+);
+
+-- Create rule_results table
+CREATE TABLE IF NOT EXISTS rule_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    report_id INTEGER REFERENCES schema_reports(id) ON DELETE CASCADE,
+    rule_name VARCHAR(100) NOT NULL,
+    violation_count INTEGER NOT NULL,
+    message TEXT,
+    created_at DATETIME NOT NULL
+);
+
+-- Create violations table
+CREATE TABLE IF NOT EXISTS violations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule_result_id INTEGER REFERENCES rule_results(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    location_line INTEGER,
+    location_column INTEGER,
+    location_field VARCHAR(255),
+    location_type VARCHAR(255),
+    location_coordinate VARCHAR(255),
+    created_at DATETIME NOT NULL
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_schema_reports_subgraph_timestamp
+    ON schema_reports(subgraph_name, timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_timestamp
+    ON schema_reports(timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_rule_results_report_id
+    ON rule_results(report_id);
+
+CREATE INDEX IF NOT EXISTS idx_violations_rule_result_id
+    ON violations(rule_result_id);
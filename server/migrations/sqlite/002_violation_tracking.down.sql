@@ -0,0 +1,8 @@
+-- Remove violation tracking. SQLite cannot drop an indexed column, so the index goes first.
+DROP INDEX IF EXISTS idx_violations_fingerprint;
+
+ALTER TABLE violations DROP COLUMN first_seen_at;
+ALTER TABLE violations DROP COLUMN first_seen_report_id;
+ALTER TABLE violations DROP COLUMN fingerprint;
+
+DROP TABLE IF EXISTS tracked_violations;
@@ -0,0 +1,26 @@
+-- Track violations across reports by a stable fingerprint
+CREATE TABLE IF NOT EXISTS tracked_violations (
+    fingerprint VARCHAR(64) PRIMARY KEY,
+    subgraph_name VARCHAR(255) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    location_coordinate VARCHAR(255),
+    message TEXT NOT NULL,
+    first_seen_report_id INTEGER REFERENCES schema_reports(id) ON DELETE SET NULL,
+    first_seen_at DATETIME NOT NULL,
+    last_seen_report_id INTEGER REFERENCES schema_reports(id) ON DELETE SET NULL,
+    last_seen_at DATETIME NOT NULL,
+    resolved_report_id INTEGER REFERENCES schema_reports(id) ON DELETE SET NULL,
+    resolved_at DATETIME
+);
+
+-- Record the fingerprint and the start of the lifecycle on every stored violation
+ALTER TABLE violations ADD COLUMN fingerprint VARCHAR(64);
+ALTER TABLE violations ADD COLUMN first_seen_report_id INTEGER;
+ALTER TABLE violations ADD COLUMN first_seen_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_tracked_violations_open
+    ON tracked_violations(subgraph_name, first_seen_at)
+    WHERE resolved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_violations_fingerprint
+    ON violations(fingerprint);
@@ -0,0 +1,10 @@
+-- Remove suppressions and the effective score
+ALTER TABLE violations DROP COLUMN suppression_id;
+ALTER TABLE violations DROP COLUMN suppressed;
+
+ALTER TABLE rule_results DROP COLUMN suppressed_count;
+
+ALTER TABLE schema_reports DROP COLUMN suppressed_count;
+ALTER TABLE schema_reports DROP COLUMN effective_score;
+
+DROP TABLE IF EXISTS suppressions;
@@ -0,0 +1,25 @@
+-- Create suppressions table for accepted violations
+CREATE TABLE IF NOT EXISTS suppressions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subgraph_name VARCHAR(255) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    coordinate_pattern VARCHAR(255) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    expires_at DATETIME,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppressions_subgraph
+    ON suppressions(subgraph_name);
+
+-- Score without the suppressed violations
+ALTER TABLE schema_reports ADD COLUMN effective_score DECIMAL(10,2);
+ALTER TABLE schema_reports ADD COLUMN suppressed_count INTEGER NOT NULL DEFAULT 0;
+UPDATE schema_reports SET effective_score = score WHERE effective_score IS NULL;
+
+ALTER TABLE rule_results ADD COLUMN suppressed_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE violations ADD COLUMN suppressed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE violations ADD COLUMN suppression_id INTEGER REFERENCES suppressions(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+-- Remove quality gates
+DROP INDEX IF EXISTS idx_schema_reports_subgraph_branch;
+DROP TABLE IF EXISTS gate_configs;
@@ -0,0 +1,4 @@
+-- Remove webhooks and their delivery outbox
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,15 @@
+-- Create api_tokens table for authenticated report ingestion
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    -- JSON array of subgraph names, empty for all subgraphs
+    subgraphs TEXT NOT NULL DEFAULT '[]',
+    created_at DATETIME NOT NULL,
+    last_used_at DATETIME,
+    revoked_at DATETIME
+);
+
+-- Token that submitted each report
+ALTER TABLE schema_reports ADD COLUMN api_token_id INTEGER REFERENCES api_tokens(id) ON DELETE SET NULL;
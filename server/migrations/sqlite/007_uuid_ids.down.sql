@@ -0,0 +1,163 @@
+-- Return to autoincrement integer IDs. Rows stored before the UUIDs get their integer
+-- back, and rows stored since are numbered after the largest integer of their table.
+CREATE TEMP TABLE integer_ids (
+    table_name TEXT NOT NULL,
+    id TEXT NOT NULL,
+    integer_id INTEGER NOT NULL,
+    PRIMARY KEY (table_name, id)
+);
+
+-- Decode the hex digits of the UUIDs that replaced integer IDs
+INSERT INTO integer_ids (table_name, id, integer_id)
+WITH RECURSIVE legacy(table_name, id, rest, value) AS (
+    SELECT 'schema_reports', id, substr(id, 25), 0 FROM schema_reports WHERE id LIKE '00000000-0000-0000-0000-%'
+    UNION ALL
+    SELECT 'rule_results', id, substr(id, 25), 0 FROM rule_results WHERE id LIKE '00000000-0000-0000-0000-%'
+    UNION ALL
+    SELECT 'violations', id, substr(id, 25), 0 FROM violations WHERE id LIKE '00000000-0000-0000-0000-%'
+    UNION ALL
+    SELECT table_name, id, substr(rest, 2), value * 16 + instr('0123456789abcdef', lower(substr(rest, 1, 1))) - 1
+    FROM legacy WHERE rest <> ''
+)
+SELECT table_name, id, value FROM legacy WHERE rest = '';
+
+INSERT INTO integer_ids (table_name, id, integer_id)
+SELECT 'schema_reports', id,
+    (SELECT COALESCE(MAX(integer_id), 0) FROM integer_ids WHERE table_name = 'schema_reports')
+        + ROW_NUMBER() OVER (ORDER BY id)
+FROM schema_reports WHERE id NOT LIKE '00000000-0000-0000-0000-%';
+
+INSERT INTO integer_ids (table_name, id, integer_id)
+SELECT 'rule_results', id,
+    (SELECT COALESCE(MAX(integer_id), 0) FROM integer_ids WHERE table_name = 'rule_results')
+        + ROW_NUMBER() OVER (ORDER BY id)
+FROM rule_results WHERE id NOT LIKE '00000000-0000-0000-0000-%';
+
+INSERT INTO integer_ids (table_name, id, integer_id)
+SELECT 'violations', id,
+    (SELECT COALESCE(MAX(integer_id), 0) FROM integer_ids WHERE table_name = 'violations')
+        + ROW_NUMBER() OVER (ORDER BY id)
+FROM violations WHERE id NOT LIKE '00000000-0000-0000-0000-%';
+
+CREATE TABLE schema_reports_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    subgraph_name VARCHAR(255) NOT NULL,
+    score DECIMAL(10,2) NOT NULL,
+    total_fields INTEGER NOT NULL,
+    total_weighted_violations DECIMAL(10,2) NOT NULL,
+    timestamp DATETIME NOT NULL,
+    metadata TEXT,
+    created_at DATETIME NOT NULL,
+    effective_score DECIMAL(10,2),
+    suppressed_count INTEGER NOT NULL DEFAULT 0,
+    api_token_id INTEGER REFERENCES api_tokens(id) ON DELETE SET NULL
+);
+
+INSERT INTO schema_reports_old (id, subgraph_name, score, total_fields, total_weighted_violations,
+    timestamp, metadata, created_at, effective_score, suppressed_count, api_token_id)
+SELECT i.integer_id, r.subgraph_name, r.score, r.total_fields, r.total_weighted_violations,
+    r.timestamp, r.metadata, r.created_at, r.effective_score, r.suppressed_count, r.api_token_id
+FROM schema_reports r
+JOIN integer_ids i ON i.table_name = 'schema_reports' AND i.id = r.id;
+
+CREATE TABLE rule_results_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    report_id INTEGER REFERENCES schema_reports_old(id) ON DELETE CASCADE,
+    rule_name VARCHAR(100) NOT NULL,
+    violation_count INTEGER NOT NULL,
+    message TEXT,
+    created_at DATETIME NOT NULL,
+    suppressed_count INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO rule_results_old (id, report_id, rule_name, violation_count, message, created_at, suppressed_count)
+SELECT i.integer_id, report.integer_id, rr.rule_name, rr.violation_count, rr.message, rr.created_at, rr.suppressed_count
+FROM rule_results rr
+JOIN integer_ids i ON i.table_name = 'rule_results' AND i.id = rr.id
+LEFT JOIN integer_ids report ON report.table_name = 'schema_reports' AND report.id = rr.report_id;
+
+CREATE TABLE violations_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule_result_id INTEGER REFERENCES rule_results_old(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    location_line INTEGER,
+    location_column INTEGER,
+    location_field VARCHAR(255),
+    location_type VARCHAR(255),
+    location_coordinate VARCHAR(255),
+    created_at DATETIME NOT NULL,
+    fingerprint VARCHAR(64),
+    first_seen_report_id INTEGER,
+    first_seen_at DATETIME,
+    suppressed BOOLEAN NOT NULL DEFAULT FALSE,
+    suppression_id INTEGER REFERENCES suppressions(id) ON DELETE SET NULL
+);
+
+INSERT INTO violations_old (id, rule_result_id, message, location_line, location_column, location_field,
+    location_type, location_coordinate, created_at, fingerprint, first_seen_report_id, first_seen_at,
+    suppressed, suppression_id)
+SELECT i.integer_id, rr.integer_id, v.message, v.location_line, v.location_column, v.location_field,
+    v.location_type, v.location_coordinate, v.created_at, v.fingerprint, first_seen.integer_id, v.first_seen_at,
+    v.suppressed, v.suppression_id
+FROM violations v
+JOIN integer_ids i ON i.table_name = 'violations' AND i.id = v.id
+LEFT JOIN integer_ids rr ON rr.table_name = 'rule_results' AND rr.id = v.rule_result_id
+LEFT JOIN integer_ids first_seen ON first_seen.table_name = 'schema_reports' AND first_seen.id = v.first_seen_report_id;
+
+CREATE TABLE tracked_violations_old (
+    fingerprint VARCHAR(64) PRIMARY KEY,
+    subgraph_name VARCHAR(255) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    location_coordinate VARCHAR(255),
+    message TEXT NOT NULL,
+    first_seen_report_id INTEGER REFERENCES schema_reports_old(id) ON DELETE SET NULL,
+    first_seen_at DATETIME NOT NULL,
+    last_seen_report_id INTEGER REFERENCES schema_reports_old(id) ON DELETE SET NULL,
+    last_seen_at DATETIME NOT NULL,
+    resolved_report_id INTEGER REFERENCES schema_reports_old(id) ON DELETE SET NULL,
+    resolved_at DATETIME
+);
+
+INSERT INTO tracked_violations_old (fingerprint, subgraph_name, rule_name, location_coordinate, message,
+    first_seen_report_id, first_seen_at, last_seen_report_id, last_seen_at, resolved_report_id, resolved_at)
+SELECT t.fingerprint, t.subgraph_name, t.rule_name, t.location_coordinate, t.message,
+    first_seen.integer_id, t.first_seen_at, last_seen.integer_id, t.last_seen_at, resolved.integer_id, t.resolved_at
+FROM tracked_violations t
+LEFT JOIN integer_ids first_seen ON first_seen.table_name = 'schema_reports' AND first_seen.id = t.first_seen_report_id
+LEFT JOIN integer_ids last_seen ON last_seen.table_name = 'schema_reports' AND last_seen.id = t.last_seen_report_id
+LEFT JOIN integer_ids resolved ON resolved.table_name = 'schema_reports' AND resolved.id = t.resolved_report_id;
+
+DROP TABLE integer_ids;
+
+-- Children first, so that dropping a table does not cascade into the rebuilt ones
+DROP TABLE violations;
+DROP TABLE rule_results;
+DROP TABLE tracked_violations;
+DROP TABLE schema_reports;
+
+ALTER TABLE schema_reports_old RENAME TO schema_reports;
+ALTER TABLE rule_results_old RENAME TO rule_results;
+ALTER TABLE violations_old RENAME TO violations;
+ALTER TABLE tracked_violations_old RENAME TO tracked_violations;
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_subgraph_timestamp
+    ON schema_reports(subgraph_name, timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_timestamp
+    ON schema_reports(timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_subgraph_branch
+    ON schema_reports(subgraph_name, json_extract(metadata, '$.branch'), timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_rule_results_report_id
+    ON rule_results(report_id);
+
+CREATE INDEX IF NOT EXISTS idx_violations_rule_result_id
+    ON violations(rule_result_id);
+
+CREATE INDEX IF NOT EXISTS idx_violations_fingerprint
+    ON violations(fingerprint);
+
+CREATE INDEX IF NOT EXISTS idx_tracked_violations_open
+    ON tracked_violations(subgraph_name, first_seen_at)
+    WHERE resolved_at IS NULL;
@@ -0,0 +1,124 @@
+-- Identify reports, rule results and violations by UUID instead of an autoincrement
+-- integer, so that the IDs the server hands out are the ones it stores. Existing rows
+-- keep their integer in the last twelve hex digits of the UUID, which is how links with
+-- the old integer IDs are still resolved. SQLite cannot change the type of a primary
+-- key, so the tables are rebuilt.
+CREATE TABLE schema_reports_new (
+    id TEXT PRIMARY KEY NOT NULL,
+    subgraph_name VARCHAR(255) NOT NULL,
+    score DECIMAL(10,2) NOT NULL,
+    total_fields INTEGER NOT NULL,
+    total_weighted_violations DECIMAL(10,2) NOT NULL,
+    timestamp DATETIME NOT NULL,
+    metadata TEXT,
+    created_at DATETIME NOT NULL,
+    effective_score DECIMAL(10,2),
+    suppressed_count INTEGER NOT NULL DEFAULT 0,
+    api_token_id INTEGER REFERENCES api_tokens(id) ON DELETE SET NULL
+);
+
+INSERT INTO schema_reports_new (id, subgraph_name, score, total_fields, total_weighted_violations,
+    timestamp, metadata, created_at, effective_score, suppressed_count, api_token_id)
+SELECT printf('00000000-0000-0000-0000-%012x', id), subgraph_name, score, total_fields, total_weighted_violations,
+    timestamp, metadata, created_at, effective_score, suppressed_count, api_token_id
+FROM schema_reports;
+
+CREATE TABLE rule_results_new (
+    id TEXT PRIMARY KEY NOT NULL,
+    report_id TEXT REFERENCES schema_reports_new(id) ON DELETE CASCADE,
+    rule_name VARCHAR(100) NOT NULL,
+    violation_count INTEGER NOT NULL,
+    message TEXT,
+    created_at DATETIME NOT NULL,
+    suppressed_count INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO rule_results_new (id, report_id, rule_name, violation_count, message, created_at, suppressed_count)
+SELECT printf('00000000-0000-0000-0000-%012x', id), printf('00000000-0000-0000-0000-%012x', report_id),
+    rule_name, violation_count, message, created_at, suppressed_count
+FROM rule_results;
+
+CREATE TABLE violations_new (
+    id TEXT PRIMARY KEY NOT NULL,
+    rule_result_id TEXT REFERENCES rule_results_new(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    location_line INTEGER,
+    location_column INTEGER,
+    location_field VARCHAR(255),
+    location_type VARCHAR(255),
+    location_coordinate VARCHAR(255),
+    created_at DATETIME NOT NULL,
+    fingerprint VARCHAR(64),
+    first_seen_report_id TEXT,
+    first_seen_at DATETIME,
+    suppressed BOOLEAN NOT NULL DEFAULT FALSE,
+    suppression_id INTEGER REFERENCES suppressions(id) ON DELETE SET NULL
+);
+
+INSERT INTO violations_new (id, rule_result_id, message, location_line, location_column, location_field,
+    location_type, location_coordinate, created_at, fingerprint, first_seen_report_id, first_seen_at,
+    suppressed, suppression_id)
+SELECT printf('00000000-0000-0000-0000-%012x', id), printf('00000000-0000-0000-0000-%012x', rule_result_id),
+    message, location_line, location_column, location_field, location_type, location_coordinate, created_at,
+    fingerprint,
+    CASE WHEN first_seen_report_id IS NOT NULL THEN printf('00000000-0000-0000-0000-%012x', first_seen_report_id) END,
+    first_seen_at, suppressed, suppression_id
+FROM violations;
+
+CREATE TABLE tracked_violations_new (
+    fingerprint VARCHAR(64) PRIMARY KEY,
+    subgraph_name VARCHAR(255) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    location_coordinate VARCHAR(255),
+    message TEXT NOT NULL,
+    first_seen_report_id TEXT REFERENCES schema_reports_new(id) ON DELETE SET NULL,
+    first_seen_at DATETIME NOT NULL,
+    last_seen_report_id TEXT REFERENCES schema_reports_new(id) ON DELETE SET NULL,
+    last_seen_at DATETIME NOT NULL,
+    resolved_report_id TEXT REFERENCES schema_reports_new(id) ON DELETE SET NULL,
+    resolved_at DATETIME
+);
+
+INSERT INTO tracked_violations_new (fingerprint, subgraph_name, rule_name, location_coordinate, message,
+    first_seen_report_id, first_seen_at, last_seen_report_id, last_seen_at, resolved_report_id, resolved_at)
+SELECT fingerprint, subgraph_name, rule_name, location_coordinate, message,
+    CASE WHEN first_seen_report_id IS NOT NULL THEN printf('00000000-0000-0000-0000-%012x', first_seen_report_id) END,
+    first_seen_at,
+    CASE WHEN last_seen_report_id IS NOT NULL THEN printf('00000000-0000-0000-0000-%012x', last_seen_report_id) END,
+    last_seen_at,
+    CASE WHEN resolved_report_id IS NOT NULL THEN printf('00000000-0000-0000-0000-%012x', resolved_report_id) END,
+    resolved_at
+FROM tracked_violations;
+
+-- Children first, so that dropping a table does not cascade into the new ones
+DROP TABLE violations;
+DROP TABLE rule_results;
+DROP TABLE tracked_violations;
+DROP TABLE schema_reports;
+
+ALTER TABLE schema_reports_new RENAME TO schema_reports;
+ALTER TABLE rule_results_new RENAME TO rule_results;
+ALTER TABLE violations_new RENAME TO violations;
+ALTER TABLE tracked_violations_new RENAME TO tracked_violations;
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_subgraph_timestamp
+    ON schema_reports(subgraph_name, timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_timestamp
+    ON schema_reports(timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_schema_reports_subgraph_branch
+    ON schema_reports(subgraph_name, json_extract(metadata, '$.branch'), timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS idx_rule_results_report_id
+    ON rule_results(report_id);
+
+CREATE INDEX IF NOT EXISTS idx_violations_rule_result_id
+    ON violations(rule_result_id);
+
+CREATE INDEX IF NOT EXISTS idx_violations_fingerprint
+    ON violations(fingerprint);
+
+CREATE INDEX IF NOT EXISTS idx_tracked_violations_open
+    ON tracked_violations(subgraph_name, first_seen_at)
+    WHERE resolved_at IS NULL;
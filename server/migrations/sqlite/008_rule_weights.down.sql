@@ -0,0 +1,4 @@
+-- Remove rule weights
+ALTER TABLE rule_results DROP COLUMN contribution;
+ALTER TABLE rule_results DROP COLUMN category;
+ALTER TABLE rule_results DROP COLUMN weight;
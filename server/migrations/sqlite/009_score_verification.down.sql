@@ -0,0 +1,5 @@
+-- Remove score verification. SQLite cannot drop an indexed column, so the index goes first.
+DROP INDEX IF EXISTS idx_schema_reports_score_mismatch;
+
+ALTER TABLE schema_reports DROP COLUMN score_mismatch;
+ALTER TABLE schema_reports DROP COLUMN recomputed_score;
@@ -0,0 +1,61 @@
+-- Create the rule catalog, which documents the rules of the schema scorer and the weights
+-- they are scored with. It starts out with the scorer's rules, and rules that stored reports
+-- used but the scorer does not define are registered for review.
+CREATE TABLE IF NOT EXISTS rules (
+    name VARCHAR(100) PRIMARY KEY,
+    weight DECIMAL(10,2) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rationale TEXT NOT NULL DEFAULT '',
+    fix_examples TEXT NOT NULL DEFAULT '[]',
+    needs_review BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO rules (name, weight, category, description, rationale, fix_examples) VALUES
+    ('Boolean Prefix', 5, 'style',
+        'Flags Boolean fields whose names start with is.',
+        'The type already says that a field is a boolean, and names without the prefix read as properties and stay consistent across subgraphs.',
+        '[{"Title": "Drop the prefix", "Before": "type User {\n  isActive: Boolean!\n}", "After": "type User {\n  active: Boolean!\n}"}]'),
+    ('Composite Keys', 5, 'style',
+        'Flags entity types with more than two composite @key directives.',
+        'Every key is a contract that other subgraphs rely on to resolve the entity. Many composite keys make query plans expensive and the entity hard to evolve.',
+        '[{"Title": "Resolve the entity by a single identifier", "Before": "type Product @key(fields: \"sku region\") @key(fields: \"upc region\") @key(fields: \"name brand\") {\n  sku: String!\n  upc: String!\n  region: String!\n  name: String!\n  brand: String!\n}", "After": "type Product @key(fields: \"id\") @key(fields: \"sku region\") {\n  id: ID!\n  sku: String!\n  region: String!\n  name: String!\n  brand: String!\n}"}]'),
+    ('Cycle Counter', 15, 'critical',
+        'Detects circular references between types, such as posts that point back to their author.',
+        'Cycles let clients write arbitrarily deep queries that are expensive to resolve and hard to cache, and they tie the types of the cycle together.',
+        '[{"Title": "Reference the parent by its ID", "Before": "type User {\n  posts: [Post!]!\n}\n\ntype Post {\n  author: User!\n}", "After": "type User {\n  posts: [Post!]!\n}\n\ntype Post {\n  authorId: ID!\n}"}]'),
+    ('Deprecation', 5, 'style',
+        'Flags @deprecated fields without a reason, or with a reason that does not explain what to use instead.',
+        'Clients can only migrate off a deprecated field when they know what replaces it.',
+        '[{"Title": "Name the replacement", "Before": "type User {\n  name: String @deprecated\n}", "After": "type User {\n  name: String @deprecated(reason: \"Use fullName instead\")\n  fullName: String\n}"}]'),
+    ('Null Blast Radius', 20, 'critical',
+        'Flags non-null fields whose failure would null out more than five other fields. A null in a non-null field propagates to the nearest nullable parent and takes all of its fields with it.',
+        'A single failing resolver, often in another subgraph, can wipe out a whole page of data. Keeping the blast radius small contains partial failures.',
+        '[{"Title": "Make the failing field nullable", "Before": "type Order {\n  customer: Customer!\n  items: [OrderItem!]!\n}", "After": "type Order {\n  customer: Customer\n  items: [OrderItem!]!\n}"}]'),
+    ('Nullable External', 15, 'critical',
+        'Flags @external fields that are declared non-null.',
+        'An external field is resolved by another subgraph, which may fail or return null. Declaring it non-null lets that failure null out the entity in this subgraph as well.',
+        '[{"Title": "Declare the external field nullable", "Before": "type User @key(fields: \"id\") {\n  id: ID!\n  email: String! @external\n}", "After": "type User @key(fields: \"id\") {\n  id: ID!\n  email: String @external\n}"}]'),
+    ('PII', 10, 'important',
+        'Flags fields whose names suggest personal data, such as email addresses, phone numbers, names or birth dates, that are not marked with the @pii directive.',
+        'Personal data that is not marked cannot be found by privacy tooling, access control or retention policies, so it ends up in logs, caches and clients unnoticed.',
+        '[{"Title": "Mark the field as PII", "Before": "type User {\n  email: String!\n}", "After": "type User {\n  email: String! @pii\n}"}]'),
+    ('Plural Collections', 5, 'style',
+        'Flags fields that return a list but have a singular name.',
+        'Plural names tell clients at a glance that a field returns many items.',
+        '[{"Title": "Use a plural name", "Before": "type User {\n  order: [Order!]!\n}", "After": "type User {\n  orders: [Order!]!\n}"}]'),
+    ('Problem Union', 10, 'important',
+        'Flags mutations that do not return a union type whose name ends in Result.',
+        'Expected failures such as validation problems belong in the schema, where clients can see and handle them, rather than in the untyped errors array.',
+        '[{"Title": "Return a result union", "Before": "type Mutation {\n  createUser(input: CreateUserInput!): User\n}", "After": "union CreateUserResult = CreateUserSuccess | ValidationProblem\n\ntype Mutation {\n  createUser(input: CreateUserInput!): CreateUserResult!\n}"}]')
+ON CONFLICT (name) DO NOTHING;
+
+-- WHERE true keeps SQLite from reading ON CONFLICT as the constraint of a join
+INSERT INTO rules (name, weight, category, needs_review)
+SELECT rule_name, MAX(weight), MIN(category), TRUE
+FROM rule_results
+WHERE true
+GROUP BY rule_name
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,2 @@
+-- Remove admin tokens
+ALTER TABLE api_tokens DROP COLUMN admin;
@@ -13,19 +13,28 @@ export type Violation = {
     location: ViolationLocation;
 }
 
+export type RuleCategory = "critical" | "important" | "style";
+
 export type ValidationResult = {
     rule: string,
     violations: Violation[]
     message: string
+    weight?: number
+    category?: RuleCategory
 }
 
 export interface Rule {
     name: string
     weight: number
+    category: RuleCategory
     validate(ast: DocumentNode): ValidationResult
 }
 
+// REPORT_SCHEMA_VERSION is the version of the report format the server decodes SchemaReport with
+export const REPORT_SCHEMA_VERSION = 2;
+
 export type SchemaReport = {
+    schemaVersion: number;
     timestamp: string;
     subgraphName?: string;
     score: number;
@@ -29,10 +29,29 @@ export class Reporter {
             });
 
             if (!response.ok) {
-                throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+                // The server describes errors as RFC 7807 problem details
+                const problem = await response.json().catch(() => undefined);
+                for (const param of problem?.invalid_params ?? []) {
+                    console.error(`   - ${param.path} ${param.reason}`);
+                }
+                const detail = problem?.detail ?? response.statusText;
+                const requestID = problem?.request_id ? ` (request ${problem.request_id})` : '';
+                throw new Error(`HTTP ${response.status}: ${detail}${requestID}`);
             }
 
             console.log(`✅ Report sent successfully to ${this.config.endpoint}`);
+
+            const body = await response.json().catch(() => undefined);
+            if (body?.gate === 'failed') {
+                console.error('🚫 Quality gate failed:');
+                for (const failure of body.gate_result?.failures ?? []) {
+                    console.error(`   - ${failure.message}`);
+                }
+                // Fail the CI step once the report is sent
+                process.exitCode = 1;
+            } else if (body?.gate) {
+                console.log(`🚦 Quality gate ${body.gate}`);
+            }
         } catch (error) {
             console.error(`❌ Failed to send report to ${this.config.endpoint}:`, error);
             throw error;
@@ -1,9 +1,10 @@
 import {type DocumentNode, visit} from "graphql";
-import type {Rule, ValidationResult, Violation} from "../model.ts";
+import type {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 export class BooleanPrefixRule implements Rule {
     name = "Boolean Prefix";
     weight = 5;
+    category: RuleCategory = "style";
 
     validate(ast: DocumentNode): ValidationResult {
         const violations: Violation[] = [];
@@ -1,10 +1,11 @@
 import {visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 import {DocumentNode} from "graphql/index";
 
 export class CompositeKeyRule implements Rule {
     name = "Composite Keys";
     weight = 5;
+    category: RuleCategory = "style";
     private maxCompositeKeys: number;
 
     constructor(maxCompositeKeys = 2) {
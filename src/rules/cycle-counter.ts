@@ -1,9 +1,10 @@
 import {type DocumentNode, visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 export class CycleCounterRule implements Rule {
     name = "Cycle Counter";
     weight = 15;
+    category: RuleCategory = "critical";
     private readonly typeGraph: Map<any, any>;
 
     constructor() {
@@ -1,9 +1,10 @@
 import {type DocumentNode, visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 export class DeprecationRule implements Rule {
     name = "Deprecation";
     weight = 5;
+    category: RuleCategory = "style";
 
     validate(ast: DocumentNode): ValidationResult {
         const violations: Violation[] = [];
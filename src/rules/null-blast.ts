@@ -1,9 +1,10 @@
 import {type DocumentNode, visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 export class NullBlastRadiusRule implements Rule {
     name = "Null Blast Radius";
     weight = 20;
+    category: RuleCategory = "critical";
     private config: { maxBlastRadius: number; criticalTypePaths: string[]; warningThreshold: number };
     private typeMap: Map<any, any>;
     private nullabilityGraph: Map<any, any>;
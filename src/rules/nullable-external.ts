@@ -1,9 +1,10 @@
 import {type DocumentNode, visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 export class NullableExternalRule implements Rule {
     name = "Nullable External";
     weight = 15;
+    category: RuleCategory = "critical";
 
     validate(ast: DocumentNode): ValidationResult {
         const violations: Violation[] = [];
@@ -1,5 +1,5 @@
 import {type ConstDirectiveNode, type DocumentNode, visit} from "graphql";
-import type {Rule, ValidationResult, Violation} from "../model.ts";
+import type {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 // Helper to identify potential PII field names
 const piiPatterns = [
@@ -27,6 +27,7 @@ const piiPatterns = [
 export class PiiRule implements Rule {
     name = "PII";
     weight = 10;
+    category: RuleCategory = "important";
 
     validate(ast: DocumentNode): ValidationResult {
         const violations: Violation[] = [];
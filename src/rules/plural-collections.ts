@@ -1,10 +1,11 @@
 import {type DocumentNode, visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 import pluralize from "pluralize";
 
 export class PluralCollectionsRule implements Rule {
     name = "Plural Collections";
     weight = 5;
+    category: RuleCategory = "style";
 
     validate(ast: DocumentNode): ValidationResult {
         const violations: Violation[] = [];
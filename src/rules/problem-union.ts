@@ -1,9 +1,10 @@
 import {type DocumentNode, visit} from "graphql";
-import {Rule, ValidationResult, Violation} from "../model.ts";
+import {Rule, RuleCategory, ValidationResult, Violation} from "../model.ts";
 
 export class ProblemUnionRule implements Rule {
     name = "Problem Union";
     weight = 10;
+    category: RuleCategory = "important";
 
     validate(ast: DocumentNode): ValidationResult {
         const violations: Violation[] = [];
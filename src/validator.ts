@@ -2,6 +2,7 @@ import {parse, visit} from "graphql/index";
 import type {DocumentNode} from "graphql";
 import {PiiRule} from "./rules/pii.ts";
 import type {Rule, SchemaReport, ValidationResult} from "./model.ts";
+import {REPORT_SCHEMA_VERSION} from "./model.ts";
 import {CompositeKeyRule} from "./rules/composite-keys.ts";
 import {CycleCounterRule} from "./rules/cycle-counter.ts";
 import {NullBlastRadiusRule} from "./rules/null-blast.ts";
@@ -47,7 +48,8 @@ export class Validator {
                 console.log(`Running validator [${rule.name}]`)
             }
 
-            const result = rule.validate(this.ast)
+            // The server explains the score with the weight and category of every rule
+            const result = {...rule.validate(this.ast), weight: rule.weight, category: rule.category}
             ruleResults.push(result);
 
             if (result.violations.length > 0) {
@@ -68,6 +70,7 @@ export class Validator {
         // Send report if reporter config is provided
         if (options?.reporterConfig) {
             this.sendReport({
+                schemaVersion: REPORT_SCHEMA_VERSION,
                 timestamp: new Date().toISOString(),
                 subgraphName: options.subgraphName,
                 score,